@@ -29,6 +29,7 @@ import (
 const (
 	LASTNETWORKNAMESPACE        = "operator.sriovnetwork.openshift.io/last-network-namespace"
 	NETATTDEFFINALIZERNAME      = "netattdef.finalizers.sriovnetwork.openshift.io"
+	RESOURCEINUSEFINALIZERNAME  = "resourceinuse.finalizers.sriovnetwork.openshift.io"
 	POOLCONFIGFINALIZERNAME     = "poolconfig.finalizers.sriovnetwork.openshift.io"
 	OPERATORCONFIGFINALIZERNAME = "operatorconfig.finalizers.sriovnetwork.openshift.io"
 	ESwithModeLegacy            = "legacy"
@@ -41,6 +42,9 @@ const (
 	SriovCniStateOn      = "on"
 	SriovCniIpam         = "\"ipam\""
 	SriovCniIpamEmpty    = SriovCniIpam + ":{}"
+
+	VfNameTemplatePfName = "{pfName}"
+	VfNameTemplateVfID   = "{vfID}"
 )
 
 const invalidVfIndex = -1
@@ -60,6 +64,16 @@ type NetFilterType int
 const (
 	// OpenstackNetworkID network UUID
 	OpenstackNetworkID NetFilterType = iota
+	// GenericNetworkName network name from the metadata of the virtual machine
+	GenericNetworkName
+	// OpenstackTag device tag from the OpenStack metadata
+	OpenstackTag
+	// OpenstackPhysnet physical network name from the OpenStack network data
+	OpenstackPhysnet
+	// OpenstackVlan VLAN of the device from the OpenStack metadata
+	OpenstackVlan
+	// OpenstackTrusted trusted mode of the device from the OpenStack metadata
+	OpenstackTrusted
 
 	SupportedNicIDConfigmap = "supported-nic-ids"
 )
@@ -69,12 +83,23 @@ type ConfigurationModeType string
 const (
 	DaemonConfigurationMode  ConfigurationModeType = "daemon"
 	SystemdConfigurationMode ConfigurationModeType = "systemd"
+	HybridConfigurationMode  ConfigurationModeType = "hybrid"
 )
 
 func (e NetFilterType) String() string {
 	switch e {
 	case OpenstackNetworkID:
 		return "openstack/NetworkID"
+	case GenericNetworkName:
+		return "generic/Network"
+	case OpenstackTag:
+		return "openstack/Tag"
+	case OpenstackPhysnet:
+		return "openstack/Physnet"
+	case OpenstackVlan:
+		return "openstack/Vlan"
+	case OpenstackTrusted:
+		return "openstack/Trusted"
 	default:
 		return fmt.Sprintf("%d", int(e))
 	}
@@ -186,6 +211,17 @@ func ContainsSwitchdevInterface(interfaces []Interface) bool {
 	return false
 }
 
+// ContainsVfNameTemplate returns true if provided interface list contains interface
+// with VF name template
+func ContainsVfNameTemplate(interfaces []Interface) bool {
+	for _, iface := range interfaces {
+		if iface.VfNameTemplate != "" {
+			return true
+		}
+	}
+	return false
+}
+
 // GetEswitchModeFromSpec returns ESwitchMode from the interface spec, returns legacy if not set
 func GetEswitchModeFromSpec(ifaceSpec *Interface) string {
 	if ifaceSpec.EswitchMode == "" {
@@ -202,6 +238,11 @@ func GetEswitchModeFromStatus(ifaceStatus *InterfaceExt) string {
 	return ifaceStatus.EswitchMode
 }
 
+// RenderVfName returns the name of the VF netdev generated from the VF name template of the PF
+func RenderVfName(template, pfName string, vfID int) string {
+	return strings.NewReplacer(VfNameTemplatePfName, pfName, VfNameTemplateVfID, strconv.Itoa(vfID)).Replace(template)
+}
+
 func NeedToUpdateSriov(ifaceSpec *Interface, ifaceStatus *InterfaceExt) bool {
 	if ifaceSpec.Mtu > 0 {
 		mtu := ifaceSpec.Mtu
@@ -228,6 +269,15 @@ func NeedToUpdateSriov(ifaceSpec *Interface, ifaceStatus *InterfaceExt) bool {
 
 	if ifaceSpec.NumVfs > 0 {
 		for _, vfStatus := range ifaceStatus.VFs {
+			// the name is not reported for the VFs bound to a userspace driver or moved to a pod namespace
+			if ifaceSpec.VfNameTemplate != "" && vfStatus.Name != "" {
+				desiredName := RenderVfName(ifaceSpec.VfNameTemplate, ifaceSpec.Name, vfStatus.VfID)
+				if vfStatus.Name != desiredName {
+					log.V(0).Info("NeedToUpdateSriov(): VF name needs update",
+						"vf", vfStatus.VfID, "desired", desiredName, "current", vfStatus.Name)
+					return true
+				}
+			}
 			for _, groupSpec := range ifaceSpec.VfGroups {
 				if IndexInRange(vfStatus.VfID, groupSpec.VfRange) {
 					if vfStatus.Driver == "" {
@@ -283,6 +333,20 @@ func NeedToUpdateSriov(ifaceSpec *Interface, ifaceStatus *InterfaceExt) bool {
 	return false
 }
 
+// NeedToUpdateExistingVFs returns true if the VFs of the interface that are kept when the number of VFs changes,
+// i.e. the VFs with an index lower than the requested number of VFs, need to be reconfigured
+func NeedToUpdateExistingVFs(ifaceSpec *Interface, ifaceStatus *InterfaceExt) bool {
+	keptStatus := *ifaceStatus
+	keptStatus.NumVfs = ifaceSpec.NumVfs
+	keptStatus.VFs = nil
+	for _, vf := range ifaceStatus.VFs {
+		if vf.VfID < ifaceSpec.NumVfs {
+			keptStatus.VFs = append(keptStatus.VFs, vf)
+		}
+	}
+	return NeedToUpdateSriov(ifaceSpec, &keptStatus)
+}
+
 type ByPriority []SriovNetworkNodePolicy
 
 func (a ByPriority) Len() int {
@@ -360,6 +424,7 @@ func (p *SriovNetworkNodePolicy) Apply(state *SriovNetworkNodeState, equalPriori
 				EswitchMode:       p.Spec.EswitchMode,
 				NumVfs:            p.Spec.NumVfs,
 				ExternallyManaged: p.Spec.ExternallyManaged,
+				VfNameTemplate:    p.Spec.VfNameTemplate,
 			}
 			if p.Spec.NumVfs > 0 {
 				group, err := p.generatePfNameVfGroup(&iface)
@@ -402,21 +467,22 @@ func (p *SriovNetworkNodePolicy) ApplyBridgeConfig(state *SriovNetworkNodeState)
 		if p.Spec.ExternallyManaged {
 			return fmt.Errorf("software bridge management can't be used when link is externally managed")
 		}
+		if p.Spec.Bridge.OVS != nil && p.Spec.Bridge.Linux != nil {
+			return fmt.Errorf("OVS and Linux bridges can't be used together")
+		}
 	}
 	for _, iface := range state.Status.Interfaces {
 		if p.Spec.NicSelector.Selected(&iface) {
-			if p.Spec.Bridge.OVS == nil {
-				// The policy has no OVS bridge config, this means that the node's state should have no managed OVS bridges for the interfaces that match the policy.
-				// Currently PF to OVS bridge mapping is always 1 to 1 (bonding is not supported at the moment), meaning we can remove the OVS bridge
-				// config from the node's state if it has the interface (that matches "empty-bridge" policy) in the uplink section.
-				state.Spec.Bridges.OVS = slices.DeleteFunc(state.Spec.Bridges.OVS, func(br OVSConfigExt) bool {
-					return slices.ContainsFunc(br.Uplinks, func(uplink OVSUplinkConfigExt) bool {
-						return uplink.PciAddress == iface.PciAddress
-					})
-				})
-				if len(state.Spec.Bridges.OVS) == 0 {
-					state.Spec.Bridges.OVS = nil
-				}
+			if p.Spec.Bridge.IsEmpty() {
+				// The policy has no bridge config, this means that the node's state should have no managed bridges for the interfaces that match the policy.
+				// Remove the interface (that matches "empty-bridge" policy) from the uplinks of the bridges,
+				// the bridge is removed if the interface is its last uplink.
+				state.Spec.Bridges.OVS = removeUplinkFromBridges(state.Spec.Bridges.OVS, iface.PciAddress, "")
+				state.Spec.Bridges.Linux = removeUplinkFromBridges(state.Spec.Bridges.Linux, iface.PciAddress, "")
+				continue
+			}
+			if p.Spec.Bridge.Linux != nil {
+				p.applyLinuxBridgeConfig(state, &iface)
 				continue
 			}
 			ovsBridge := OVSConfigExt{
@@ -432,7 +498,32 @@ func (p *SriovNetworkNodePolicy) ApplyBridgeConfig(state *SriovNetworkNodeState)
 				mtu := p.Spec.Mtu
 				ovsBridge.Uplinks[0].Interface.MTURequest = &mtu
 			}
+			if p.Spec.Bridge.OVS.Representors != nil && p.Spec.NumVfs > 0 {
+				group, err := p.generatePfNameVfGroup(&iface)
+				if err != nil {
+					return err
+				}
+				ovsBridge.Uplinks[0].Representors = []OVSRepresentorsConfigExt{{
+					VfRange: group.VfRange,
+					Port:    *p.Spec.Bridge.OVS.Representors.DeepCopy(),
+				}}
+			}
+			if p.Spec.Bridge.OVS.Bond != nil {
+				bond := *p.Spec.Bridge.OVS.Bond
+				ovsBridge.Name = GenerateBondBridgeName(bond.Name)
+				ovsBridge.Bond = &bond
+			}
+			if p.Spec.Bridge.OVS.BridgeName != "" {
+				ovsBridge.Name = p.Spec.Bridge.OVS.BridgeName
+				if p.Spec.Bridge.OVS.PortBond != nil {
+					portBond := *p.Spec.Bridge.OVS.PortBond
+					ovsBridge.PortBond = &portBond
+				}
+			}
 			log.Info("Update bridge for interface", "name", iface.Name, "bridge", ovsBridge.Name)
+			// the PF can be an uplink of a single bridge only
+			state.Spec.Bridges.OVS = removeUplinkFromBridges(state.Spec.Bridges.OVS, iface.PciAddress, ovsBridge.Name)
+			state.Spec.Bridges.Linux = removeUplinkFromBridges(state.Spec.Bridges.Linux, iface.PciAddress, "")
 
 			// We need to keep slices with bridges ordered to avoid unnecessary updates in the K8S API.
 			// Use binary search to insert (or update) the bridge config to the right place in the slice to keep it sorted.
@@ -440,6 +531,16 @@ func (p *SriovNetworkNodePolicy) ApplyBridgeConfig(state *SriovNetworkNodeState)
 				return strings.Compare(x.Name, y.Name)
 			})
 			if exist {
+				// keep representors of the VF groups configured by other policies for the PF
+				for _, uplink := range state.Spec.Bridges.OVS[pos].Uplinks {
+					if uplink.PciAddress == iface.PciAddress {
+						ovsBridge.Uplinks[0].Representors = mergeRepresentors(uplink.Representors, ovsBridge.Uplinks[0].Representors)
+					}
+				}
+				if ovsBridge.Bond != nil || p.Spec.Bridge.OVS.BridgeName != "" {
+					// the shared bridge contains all the PFs selected by the policies as uplinks
+					ovsBridge.Uplinks = mergeUplinks(state.Spec.Bridges.OVS[pos].Uplinks, ovsBridge.Uplinks[0])
+				}
 				state.Spec.Bridges.OVS[pos] = ovsBridge
 			} else {
 				state.Spec.Bridges.OVS = slices.Insert(state.Spec.Bridges.OVS, pos, ovsBridge)
@@ -449,6 +550,157 @@ func (p *SriovNetworkNodePolicy) ApplyBridgeConfig(state *SriovNetworkNodeState)
 	return nil
 }
 
+// applyLinuxBridgeConfig adds the interface to the Linux bridge from the policy
+func (p *SriovNetworkNodePolicy) applyLinuxBridgeConfig(state *SriovNetworkNodeState, iface *InterfaceExt) {
+	linuxBridge := LinuxBridgeConfigExt{
+		Name:   GenerateBridgeName(iface),
+		Bridge: p.Spec.Bridge.Linux.Bridge,
+		Uplinks: []LinuxUplinkConfigExt{{
+			PciAddress: iface.PciAddress,
+			Name:       iface.Name,
+			Interface:  *p.Spec.Bridge.Linux.Uplink.DeepCopy(),
+		}},
+	}
+	if p.Spec.Bridge.Linux.BridgeName != "" {
+		linuxBridge.Name = p.Spec.Bridge.Linux.BridgeName
+	}
+	log.Info("Update Linux bridge for interface", "name", iface.Name, "bridge", linuxBridge.Name)
+	// the PF can be an uplink of a single bridge only
+	state.Spec.Bridges.OVS = removeUplinkFromBridges(state.Spec.Bridges.OVS, iface.PciAddress, "")
+	state.Spec.Bridges.Linux = removeUplinkFromBridges(state.Spec.Bridges.Linux, iface.PciAddress, linuxBridge.Name)
+
+	// keep the bridges ordered to avoid unnecessary updates in the K8S API
+	pos, exist := slices.BinarySearchFunc(state.Spec.Bridges.Linux, linuxBridge, func(x, y LinuxBridgeConfigExt) int {
+		return strings.Compare(x.Name, y.Name)
+	})
+	if !exist {
+		state.Spec.Bridges.Linux = slices.Insert(state.Spec.Bridges.Linux, pos, linuxBridge)
+		return
+	}
+	if p.Spec.Bridge.Linux.BridgeName != "" {
+		// the shared bridge contains all the PFs selected by the policies as uplinks
+		uplinks := slices.Clone(state.Spec.Bridges.Linux[pos].Uplinks)
+		uplinkPos, uplinkExist := slices.BinarySearchFunc(uplinks, linuxBridge.Uplinks[0], func(x, y LinuxUplinkConfigExt) int {
+			return strings.Compare(x.PciAddress, y.PciAddress)
+		})
+		if uplinkExist {
+			uplinks[uplinkPos] = linuxBridge.Uplinks[0]
+		} else {
+			uplinks = slices.Insert(uplinks, uplinkPos, linuxBridge.Uplinks[0])
+		}
+		linuxBridge.Uplinks = uplinks
+	}
+	state.Spec.Bridges.Linux[pos] = linuxBridge
+}
+
+// mergeRepresentors merges representors config of the VF groups, the groups from the input
+// have the highest priority and replace existing groups with overlapping VF ranges
+func mergeRepresentors(existing, input []OVSRepresentorsConfigExt) []OVSRepresentorsConfigExt {
+	result := slices.Clone(input)
+	for _, cur := range existing {
+		if slices.ContainsFunc(input, func(in OVSRepresentorsConfigExt) bool {
+			return VfGroup{VfRange: cur.VfRange}.isVFRangeOverlapping(VfGroup{VfRange: in.VfRange})
+		}) {
+			continue
+		}
+		result = append(result, cur)
+	}
+	slices.SortFunc(result, func(x, y OVSRepresentorsConfigExt) int {
+		xSt, _, _ := parseRange(x.VfRange)
+		ySt, _, _ := parseRange(y.VfRange)
+		return xSt - ySt
+	})
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// managedBridge is implemented by the OVS and the Linux bridges configs of the node state
+type managedBridge[B any, U bridgeUplink] interface {
+	getName() string
+	getUplinks() []U
+	withUplinks(uplinks []U) B
+}
+
+// bridgeUplink is implemented by the uplinks configs of the OVS and the Linux bridges
+type bridgeUplink interface {
+	getPciAddress() string
+}
+
+func (b OVSConfigExt) getName() string {
+	return b.Name
+}
+
+func (b OVSConfigExt) getUplinks() []OVSUplinkConfigExt {
+	return b.Uplinks
+}
+
+func (u OVSUplinkConfigExt) getPciAddress() string {
+	return u.PciAddress
+}
+
+func (b LinuxBridgeConfigExt) getName() string {
+	return b.Name
+}
+
+func (b LinuxBridgeConfigExt) getUplinks() []LinuxUplinkConfigExt {
+	return b.Uplinks
+}
+
+func (u LinuxUplinkConfigExt) getPciAddress() string {
+	return u.PciAddress
+}
+
+func (b OVSConfigExt) withUplinks(uplinks []OVSUplinkConfigExt) OVSConfigExt {
+	b.Uplinks = uplinks
+	return b
+}
+
+func (b LinuxBridgeConfigExt) withUplinks(uplinks []LinuxUplinkConfigExt) LinuxBridgeConfigExt {
+	b.Uplinks = uplinks
+	return b
+}
+
+// removeUplinkFromBridges removes the uplink with the provided PCI address from all bridges except skipBridge,
+// the bridge is removed if the uplink was its last uplink
+func removeUplinkFromBridges[B managedBridge[B, U], U bridgeUplink](bridges []B, pciAddress, skipBridge string) []B {
+	result := make([]B, 0, len(bridges))
+	for _, br := range bridges {
+		if br.getName() != skipBridge {
+			uplinks := slices.DeleteFunc(slices.Clone(br.getUplinks()), func(uplink U) bool {
+				return uplink.getPciAddress() == pciAddress
+			})
+			if len(uplinks) != len(br.getUplinks()) {
+				if len(uplinks) == 0 {
+					continue
+				}
+				br = br.withUplinks(uplinks)
+			}
+		}
+		result = append(result, br)
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// mergeUplinks adds or updates the uplink in the list of the bridge uplinks,
+// the uplinks are kept sorted by PCI address
+func mergeUplinks(uplinks []OVSUplinkConfigExt, uplink OVSUplinkConfigExt) []OVSUplinkConfigExt {
+	result := slices.Clone(uplinks)
+	pos, exist := slices.BinarySearchFunc(result, uplink, func(x, y OVSUplinkConfigExt) int {
+		return strings.Compare(x.PciAddress, y.PciAddress)
+	})
+	if exist {
+		result[pos] = uplink
+	} else {
+		result = slices.Insert(result, pos, uplink)
+	}
+	return result
+}
+
 // mergeConfigs merges configs from multiple polices where the last one has the
 // highest priority. This merge is dependent on: 1. SR-IOV partition is
 // configured with the #-notation in pfName, 2. The VF groups are
@@ -477,6 +729,9 @@ func (iface Interface) mergeConfigs(input *Interface, equalPriority bool) {
 	if input.NumVfs < iface.NumVfs {
 		input.NumVfs = iface.NumVfs
 	}
+	if input.VfNameTemplate == "" {
+		input.VfNameTemplate = iface.VfNameTemplate
+	}
 }
 
 func (gr VfGroup) isVFRangeOverlapping(group VfGroup) bool {
@@ -612,7 +867,7 @@ func (selector *SriovNetworkNicSelector) Selected(iface *InterfaceExt) bool {
 			return false
 		}
 	}
-	if selector.NetFilter != "" && !NetFilterMatch(selector.NetFilter, iface.NetFilter) {
+	if selector.NetFilter != "" && !iface.MatchNetFilter(selector.NetFilter) {
 		return false
 	}
 
@@ -908,6 +1163,19 @@ func NetFilterMatch(netFilter string, netValue string) (isMatch bool) {
 	return netFilterResult[0][1] == netValueResult[0][1] && netFilterResult[0][2] == netValueResult[0][2]
 }
 
+// MatchNetFilter returns true if the netFilter matches the NetFilter or one of the NetFilterTags of the interface
+func (iface *InterfaceExt) MatchNetFilter(netFilter string) bool {
+	if iface.NetFilter != "" && NetFilterMatch(netFilter, iface.NetFilter) {
+		return true
+	}
+	for _, tag := range iface.NetFilterTags {
+		if NetFilterMatch(netFilter, tag) {
+			return true
+		}
+	}
+	return false
+}
+
 // MaxUnavailable calculate the max number of unavailable nodes to represent the number of nodes
 // we can drain in parallel
 func (s *SriovNetworkPoolConfig) MaxUnavailable(numOfNodes int) (int, error) {
@@ -950,9 +1218,52 @@ func GenerateBridgeName(iface *InterfaceExt) string {
 	return fmt.Sprintf("br-%s", strings.ReplaceAll(iface.PciAddress, ":", "_"))
 }
 
-// NeedToUpdateBridges returns true if bridge for the host requires update
+// GenerateBondBridgeName generate predictable name for the software bridge with a bond uplink
+// current format is: br-bond0
+func GenerateBondBridgeName(bondName string) string {
+	return fmt.Sprintf("br-%s", bondName)
+}
+
+// NeedToUpdateOVSHwOffload returns true if the OVS hardware offload settings reported in the status
+// don't match the desired settings, nothing is requested if the desired settings are not set.
+// The settings are not reported if OVS doesn't run on the node, there is nothing to disable then.
+func NeedToUpdateOVSHwOffload(desired, current *OvsHardwareOffload) bool {
+	if desired == nil {
+		return false
+	}
+	if current == nil {
+		return desired.Enabled
+	}
+	return current.Enabled != desired.Enabled ||
+		(desired.TcPolicy != "" && current.TcPolicy != desired.TcPolicy)
+}
+
+// NeedToUpdateBridges returns true if bridge for the host requires update,
+// the order of the uplinks in the bridges is not taken into account
 func NeedToUpdateBridges(bridgeSpec, bridgeStatus *Bridges) bool {
-	return !equality.Semantic.DeepEqual(bridgeSpec, bridgeStatus)
+	return !equality.Semantic.DeepEqual(sortBridgeUplinks(bridgeSpec), sortBridgeUplinks(bridgeStatus))
+}
+
+// sortBridgeUplinks returns a copy of the bridges with the uplinks sorted by PCI address
+func sortBridgeUplinks(bridges *Bridges) *Bridges {
+	if bridges == nil {
+		return nil
+	}
+	result := bridges.DeepCopy()
+	for i := range result.OVS {
+		slices.SortFunc(result.OVS[i].Uplinks, func(x, y OVSUplinkConfigExt) int {
+			return strings.Compare(x.PciAddress, y.PciAddress)
+		})
+	}
+	for i := range result.Linux {
+		slices.SortFunc(result.Linux[i].Uplinks, func(x, y LinuxUplinkConfigExt) int {
+			return strings.Compare(x.PciAddress, y.PciAddress)
+		})
+		for j := range result.Linux[i].Uplinks {
+			slices.Sort(result.Linux[i].Uplinks[j].Interface.Vlans)
+		}
+	}
+	return result
 }
 
 // SetKeepUntilTime sets an annotation to hold the "keep until time" for the node’s state.
@@ -1113,6 +1113,44 @@ func TestNeedToUpdateSriov(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "VF name differs from the template",
+			args: args{
+				ifaceSpec: &v1.Interface{
+					Name:           "ens1f0",
+					NumVfs:         2,
+					VfNameTemplate: "{pfName}v{vfID}",
+					VfGroups:       []v1.VfGroup{{VfRange: "0-1"}},
+				},
+				ifaceStatus: &v1.InterfaceExt{
+					NumVfs: 2,
+					VFs: []v1.VirtualFunction{
+						{VfID: 0, Name: "ens1f0v0", Driver: "iavf"},
+						{VfID: 1, Name: "eth1", Driver: "iavf"},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "VF names match the template",
+			args: args{
+				ifaceSpec: &v1.Interface{
+					Name:           "ens1f0",
+					NumVfs:         2,
+					VfNameTemplate: "{pfName}v{vfID}",
+					VfGroups:       []v1.VfGroup{{VfRange: "0-1"}},
+				},
+				ifaceStatus: &v1.InterfaceExt{
+					NumVfs: 2,
+					VFs: []v1.VirtualFunction{
+						{VfID: 0, Name: "ens1f0v0", Driver: "iavf"},
+						{VfID: 1, Driver: "iavf"},
+					},
+				},
+			},
+			want: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1279,6 +1317,293 @@ func TestSriovNetworkNodePolicyApplyBridgeConfig(t *testing.T) {
 				},
 			}},
 		},
+		{
+			tname: "representors of the VF group",
+			currentState: &v1.SriovNetworkNodeState{
+				Spec: v1.SriovNetworkNodeStateSpec{
+					Bridges: v1.Bridges{OVS: []v1.OVSConfigExt{
+						{
+							Name: "br-0000_86_00.0",
+							Uplinks: []v1.OVSUplinkConfigExt{{
+								Name:       "ens803f0",
+								PciAddress: "0000:86:00.0",
+								Representors: []v1.OVSRepresentorsConfigExt{
+									{VfRange: "0-1", Port: v1.OVSRepresentorPortConfig{Tag: 10}},
+									{VfRange: "4-7", Port: v1.OVSRepresentorPortConfig{Tag: 20}},
+								},
+							}},
+						},
+					}},
+				},
+				Status: newNodeState().Status,
+			},
+			policy: &v1.SriovNetworkNodePolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "p1",
+				},
+				Spec: v1.SriovNetworkNodePolicySpec{
+					DeviceType: consts.DeviceTypeNetDevice,
+					NicSelector: v1.SriovNetworkNicSelector{
+						PfNames: []string{"ens803f0#1-3"},
+					},
+					NodeSelector: map[string]string{
+						"feature.node.kubernetes.io/network-sriov.capable": "true",
+					},
+					NumVfs:       8,
+					Priority:     99,
+					EswitchMode:  "switchdev",
+					ResourceName: "p1res",
+					Bridge: v1.Bridge{OVS: &v1.OVSConfig{
+						Representors: &v1.OVSRepresentorPortConfig{Trunks: []int{100, 200}, VlanMode: "trunk"},
+					}},
+				},
+			},
+			expectedBridges: v1.Bridges{OVS: []v1.OVSConfigExt{
+				{
+					Name: "br-0000_86_00.0",
+					Uplinks: []v1.OVSUplinkConfigExt{{
+						Name:       "ens803f0",
+						PciAddress: "0000:86:00.0",
+						Representors: []v1.OVSRepresentorsConfigExt{
+							{VfRange: "1-3", Port: v1.OVSRepresentorPortConfig{Trunks: []int{100, 200}, VlanMode: "trunk"}},
+							{VfRange: "4-7", Port: v1.OVSRepresentorPortConfig{Tag: 20}},
+						},
+					}},
+				},
+			}},
+		},
+		{
+			tname: "Linux bridge replaces OVS bridge",
+			currentState: &v1.SriovNetworkNodeState{
+				Spec: v1.SriovNetworkNodeStateSpec{
+					Bridges: v1.Bridges{OVS: []v1.OVSConfigExt{
+						{
+							Name: "br-0000_86_00.0",
+							Uplinks: []v1.OVSUplinkConfigExt{
+								{Name: "ens803f0", PciAddress: "0000:86:00.0"},
+							},
+						},
+					}},
+				},
+				Status: newNodeState().Status,
+			},
+			policy: &v1.SriovNetworkNodePolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "p1",
+				},
+				Spec: v1.SriovNetworkNodePolicySpec{
+					DeviceType: consts.DeviceTypeNetDevice,
+					NicSelector: v1.SriovNetworkNicSelector{
+						RootDevices: []string{"0000:86:00.0"},
+					},
+					NodeSelector: map[string]string{
+						"feature.node.kubernetes.io/network-sriov.capable": "true",
+					},
+					NumVfs:       2,
+					Priority:     99,
+					EswitchMode:  "switchdev",
+					ResourceName: "p1res",
+					Bridge: v1.Bridge{Linux: &v1.LinuxBridgeConfig{
+						Bridge: v1.LinuxBridgeOptions{VlanFiltering: true},
+						Uplink: v1.LinuxUplinkConfig{Vlans: []int{100, 200}},
+					}},
+				},
+			},
+			expectedBridges: v1.Bridges{Linux: []v1.LinuxBridgeConfigExt{
+				{
+					Name:   "br-0000_86_00.0",
+					Bridge: v1.LinuxBridgeOptions{VlanFiltering: true},
+					Uplinks: []v1.LinuxUplinkConfigExt{
+						{Name: "ens803f0", PciAddress: "0000:86:00.0", Interface: v1.LinuxUplinkConfig{Vlans: []int{100, 200}}},
+					},
+				},
+			}},
+		},
+		{
+			tname: "remove PF from the shared Linux bridge",
+			currentState: &v1.SriovNetworkNodeState{
+				Spec: v1.SriovNetworkNodeStateSpec{
+					Bridges: v1.Bridges{Linux: []v1.LinuxBridgeConfigExt{
+						{
+							Name: "br-edge",
+							Uplinks: []v1.LinuxUplinkConfigExt{
+								{Name: "ens803f0", PciAddress: "0000:86:00.0"},
+								{Name: "ens803f2", PciAddress: "0000:86:00.2"},
+							},
+						},
+					}},
+				},
+				Status: newNodeState().Status,
+			},
+			policy: &v1.SriovNetworkNodePolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "p1",
+				},
+				Spec: v1.SriovNetworkNodePolicySpec{
+					DeviceType: consts.DeviceTypeNetDevice,
+					NicSelector: v1.SriovNetworkNicSelector{
+						RootDevices: []string{"0000:86:00.0"},
+					},
+					NodeSelector: map[string]string{
+						"feature.node.kubernetes.io/network-sriov.capable": "true",
+					},
+					NumVfs:       2,
+					Priority:     99,
+					EswitchMode:  "switchdev",
+					ResourceName: "p1res",
+				},
+			},
+			expectedBridges: v1.Bridges{Linux: []v1.LinuxBridgeConfigExt{
+				{
+					Name: "br-edge",
+					Uplinks: []v1.LinuxUplinkConfigExt{
+						{Name: "ens803f2", PciAddress: "0000:86:00.2"},
+					},
+				},
+			}},
+		},
+		{
+			tname: "join the bridge by name",
+			currentState: &v1.SriovNetworkNodeState{
+				Spec: v1.SriovNetworkNodeStateSpec{
+					Bridges: v1.Bridges{OVS: []v1.OVSConfigExt{
+						{
+							Name: "br-0000_86_00.0",
+							Uplinks: []v1.OVSUplinkConfigExt{
+								{Name: "ens803f0", PciAddress: "0000:86:00.0"},
+							},
+						},
+						{
+							Name: "br-shared",
+							Uplinks: []v1.OVSUplinkConfigExt{
+								{Name: "ens803f2", PciAddress: "0000:86:00.2"},
+							},
+						},
+					}},
+				},
+				Status: newNodeState().Status,
+			},
+			policy: &v1.SriovNetworkNodePolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "p1",
+				},
+				Spec: v1.SriovNetworkNodePolicySpec{
+					DeviceType: consts.DeviceTypeNetDevice,
+					NicSelector: v1.SriovNetworkNicSelector{
+						RootDevices: []string{"0000:86:00.0"},
+					},
+					NodeSelector: map[string]string{
+						"feature.node.kubernetes.io/network-sriov.capable": "true",
+					},
+					NumVfs:       2,
+					Priority:     99,
+					EswitchMode:  "switchdev",
+					ResourceName: "p1res",
+					Bridge: v1.Bridge{OVS: &v1.OVSConfig{
+						BridgeName: "br-shared",
+						PortBond:   &v1.OVSPortBondConfig{Name: "bond-shared", Mode: "balance-tcp", LACP: "active"},
+					}},
+				},
+			},
+			expectedBridges: v1.Bridges{OVS: []v1.OVSConfigExt{
+				{
+					Name:     "br-shared",
+					PortBond: &v1.OVSPortBondConfig{Name: "bond-shared", Mode: "balance-tcp", LACP: "active"},
+					Uplinks: []v1.OVSUplinkConfigExt{
+						{Name: "ens803f0", PciAddress: "0000:86:00.0"},
+						{Name: "ens803f2", PciAddress: "0000:86:00.2"},
+					},
+				},
+			}},
+		},
+		{
+			tname:        "bond of the selected PFs",
+			currentState: newNodeState(),
+			policy: &v1.SriovNetworkNodePolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "p1",
+				},
+				Spec: v1.SriovNetworkNodePolicySpec{
+					DeviceType: consts.DeviceTypeNetDevice,
+					NicSelector: v1.SriovNetworkNicSelector{
+						RootDevices: []string{"0000:86:00.2", "0000:86:00.0"},
+					},
+					NodeSelector: map[string]string{
+						"feature.node.kubernetes.io/network-sriov.capable": "true",
+					},
+					NumVfs:       2,
+					Priority:     99,
+					EswitchMode:  "switchdev",
+					ResourceName: "p1res",
+					Bridge: v1.Bridge{OVS: &v1.OVSConfig{
+						Bridge: v1.OVSBridgeConfig{DatapathType: "test"},
+						Bond:   &v1.OVSBondConfig{Name: "bond0", Mode: "802.3ad", Miimon: 100},
+					}},
+				},
+			},
+			expectedBridges: v1.Bridges{OVS: []v1.OVSConfigExt{
+				{
+					Name:   "br-bond0",
+					Bridge: v1.OVSBridgeConfig{DatapathType: "test"},
+					Bond:   &v1.OVSBondConfig{Name: "bond0", Mode: "802.3ad", Miimon: 100},
+					Uplinks: []v1.OVSUplinkConfigExt{
+						{
+							Name:       "ens803f0",
+							PciAddress: "0000:86:00.0",
+						},
+						{
+							Name:       "ens803f2",
+							PciAddress: "0000:86:00.2",
+						},
+					},
+				},
+			}},
+		},
+		{
+			tname: "remove PF from the bond",
+			currentState: &v1.SriovNetworkNodeState{
+				Spec: v1.SriovNetworkNodeStateSpec{
+					Bridges: v1.Bridges{OVS: []v1.OVSConfigExt{
+						{
+							Name: "br-bond0",
+							Bond: &v1.OVSBondConfig{Name: "bond0"},
+							Uplinks: []v1.OVSUplinkConfigExt{
+								{Name: "ens803f0", PciAddress: "0000:86:00.0"},
+								{Name: "ens803f2", PciAddress: "0000:86:00.2"},
+							},
+						},
+					}},
+				},
+				Status: newNodeState().Status,
+			},
+			policy: &v1.SriovNetworkNodePolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "p2",
+				},
+				Spec: v1.SriovNetworkNodePolicySpec{
+					DeviceType: consts.DeviceTypeNetDevice,
+					NicSelector: v1.SriovNetworkNicSelector{
+						RootDevices: []string{"0000:86:00.2"},
+					},
+					NodeSelector: map[string]string{
+						"feature.node.kubernetes.io/network-sriov.capable": "true",
+					},
+					NumVfs:       2,
+					Priority:     99,
+					EswitchMode:  "switchdev",
+					ResourceName: "p2res",
+				},
+			},
+			expectedBridges: v1.Bridges{OVS: []v1.OVSConfigExt{
+				{
+					Name: "br-bond0",
+					Bond: &v1.OVSBondConfig{Name: "bond0"},
+					Uplinks: []v1.OVSUplinkConfigExt{
+						{Name: "ens803f0", PciAddress: "0000:86:00.0"},
+					},
+				},
+			}},
+		},
 		{
 			tname: "update bridge set by policy with lover priority",
 			currentState: &v1.SriovNetworkNodeState{
@@ -1524,6 +1849,22 @@ func TestNeedToUpdateBridges(t *testing.T) {
 			statusBridge:   &v1.Bridges{OVS: []v1.OVSConfigExt{}},
 			expectedResult: true,
 		},
+		{
+			tname: "multiple uplinks in different order, no update required",
+			specBridge: &v1.Bridges{OVS: []v1.OVSConfigExt{{Name: "br-test", Uplinks: []v1.OVSUplinkConfigExt{
+				{PciAddress: "0000:86:00.0", Name: "ens803f0"}, {PciAddress: "0000:86:00.2", Name: "ens803f2"}}}}},
+			statusBridge: &v1.Bridges{OVS: []v1.OVSConfigExt{{Name: "br-test", Uplinks: []v1.OVSUplinkConfigExt{
+				{PciAddress: "0000:86:00.2", Name: "ens803f2"}, {PciAddress: "0000:86:00.0", Name: "ens803f0"}}}}},
+			expectedResult: false,
+		},
+		{
+			tname: "uplink missing, update required",
+			specBridge: &v1.Bridges{OVS: []v1.OVSConfigExt{{Name: "br-test", Uplinks: []v1.OVSUplinkConfigExt{
+				{PciAddress: "0000:86:00.0", Name: "ens803f0"}, {PciAddress: "0000:86:00.2", Name: "ens803f2"}}}}},
+			statusBridge: &v1.Bridges{OVS: []v1.OVSConfigExt{{Name: "br-test", Uplinks: []v1.OVSUplinkConfigExt{
+				{PciAddress: "0000:86:00.0", Name: "ens803f0"}}}}},
+			expectedResult: true,
+		},
 	}
 	for _, tc := range testtable {
 		t.Run(tc.tname, func(t *testing.T) {
@@ -1534,3 +1875,78 @@ func TestNeedToUpdateBridges(t *testing.T) {
 		})
 	}
 }
+
+func TestNeedToUpdateOVSHwOffload(t *testing.T) {
+	testtable := []struct {
+		tname          string
+		desired        *v1.OvsHardwareOffload
+		current        *v1.OvsHardwareOffload
+		expectedResult bool
+	}{
+		{
+			tname:          "not requested",
+			current:        &v1.OvsHardwareOffload{Enabled: true},
+			expectedResult: false,
+		},
+		{
+			tname:          "enable",
+			desired:        &v1.OvsHardwareOffload{Enabled: true, TcPolicy: "skip_sw"},
+			current:        &v1.OvsHardwareOffload{Enabled: false},
+			expectedResult: true,
+		},
+		{
+			tname:          "enable, not reported",
+			desired:        &v1.OvsHardwareOffload{Enabled: true},
+			expectedResult: true,
+		},
+		{
+			tname:          "tc-policy changed",
+			desired:        &v1.OvsHardwareOffload{Enabled: true, TcPolicy: "skip_sw"},
+			current:        &v1.OvsHardwareOffload{Enabled: true, TcPolicy: "none"},
+			expectedResult: true,
+		},
+		{
+			tname:          "disable",
+			desired:        &v1.OvsHardwareOffload{Enabled: false},
+			current:        &v1.OvsHardwareOffload{Enabled: true},
+			expectedResult: true,
+		},
+		{
+			tname:          "disable, not reported",
+			desired:        &v1.OvsHardwareOffload{Enabled: false},
+			expectedResult: false,
+		},
+	}
+	for _, tc := range testtable {
+		t.Run(tc.tname, func(t *testing.T) {
+			result := v1.NeedToUpdateOVSHwOffload(tc.desired, tc.current)
+			if result != tc.expectedResult {
+				t.Errorf("unexpected result want: %t got: %t", tc.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestInterfaceExtMatchNetFilter(t *testing.T) {
+	iface := &v1.InterfaceExt{
+		NetFilter:     "openstack/NetworkID:5765e37b-0a13-49d2-a598-537178ce254f",
+		NetFilterTags: []string{"openstack/Tag:dpdk0", "openstack/Physnet:physnet1"},
+	}
+	testtable := []struct {
+		netFilter      string
+		expectedResult bool
+	}{
+		{netFilter: "openstack/NetworkID:5765e37b-0a13-49d2-a598-537178ce254f", expectedResult: true},
+		{netFilter: "openstack/Tag:dpdk0", expectedResult: true},
+		{netFilter: "openstack/Physnet:physnet1", expectedResult: true},
+		{netFilter: "openstack/Tag:dpdk1", expectedResult: false},
+		{netFilter: "openstack/Vlan:100", expectedResult: false},
+	}
+	for _, tc := range testtable {
+		t.Run(tc.netFilter, func(t *testing.T) {
+			if result := iface.MatchNetFilter(tc.netFilter); result != tc.expectedResult {
+				t.Errorf("unexpected result want: %t got: %t", tc.expectedResult, result)
+			}
+		})
+	}
+}
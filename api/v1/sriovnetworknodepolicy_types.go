@@ -24,7 +24,18 @@ import (
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
 // SriovNetworkNodePolicySpec defines the desired state of SriovNetworkNodePolicy
+// +kubebuilder:validation:XValidation:rule="!has(self.nicSelector.pfNames) || self.nicSelector.pfNames.all(pf, !pf.contains('#') || int(pf.split('#')[1].split('-')[0]) <= int(pf.split('#')[1].split('-')[1]))",message="the end of the VF index range in 'nicSelector.pfNames' shall not be smaller than the start"
+// +kubebuilder:validation:XValidation:rule="!has(self.nicSelector.pfNames) || self.nicSelector.pfNames.all(pf, !pf.contains('#') || int(pf.split('#')[1].split('-')[1]) < self.numVfs)",message="the end of the VF index range in 'nicSelector.pfNames' exceeds the maximum VF index, it must be lower than 'numVfs'"
+// +kubebuilder:validation:XValidation:rule="!(has(self.isRdma) && self.isRdma && has(self.deviceType) && self.deviceType in ['vfio-pci', 'uio_pci_generic', 'igb_uio'])",message="'isRdma: true' can be used only with 'deviceType: netdevice'"
+// +kubebuilder:validation:XValidation:rule="!(has(self.linkType) && self.linkType in ['ib', 'IB'] && has(self.eSwitchMode) && self.eSwitchMode == 'switchdev')",message="'eSwitchMode: switchdev' can be used only with ethernet links"
+// +kubebuilder:validation:XValidation:rule="!has(self.vdpaType) || !has(self.deviceType) || self.deviceType == 'netdevice'",message="'vdpaType' can be used only with 'deviceType: netdevice'"
+// +kubebuilder:validation:XValidation:rule="!has(self.vdpaType) || (has(self.eSwitchMode) && self.eSwitchMode == 'switchdev')",message="vdpa requires the device to be configured in switchdev mode"
+// +kubebuilder:validation:XValidation:rule="!has(self.bridge) || (!has(self.bridge.ovs) && !has(self.bridge.linux)) || (has(self.eSwitchMode) && self.eSwitchMode == 'switchdev')",message="software bridge management requires the device to be configured in switchdev mode"
+// +kubebuilder:validation:XValidation:rule="!has(self.bridge) || (!has(self.bridge.ovs) && !has(self.bridge.linux)) || !has(self.externallyManaged) || !self.externallyManaged",message="software bridge management can't be used when the device externally managed"
+// +kubebuilder:validation:XValidation:rule="!has(self.vfNameTemplate) || !has(self.eSwitchMode) || self.eSwitchMode != 'switchdev'",message="'vfNameTemplate' can be used only with 'eSwitchMode: legacy'"
+// +kubebuilder:validation:XValidation:rule="!has(self.vfNameTemplate) || !has(self.externallyManaged) || !self.externallyManaged",message="'vfNameTemplate' can't be used when the device externally managed"
 type SriovNetworkNodePolicySpec struct {
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_]+$`
 	// SRIOV Network device plugin endpoint resource name
 	ResourceName string `json:"resourceName"`
 	// NodeSelector selects the nodes to be configured
@@ -39,11 +50,13 @@ type SriovNetworkNodePolicySpec struct {
 	// +kubebuilder:validation:Minimum=0
 	// Number of VFs for each PF
 	NumVfs int `json:"numVfs"`
+	// +kubebuilder:validation:XValidation:rule="(has(self.vendor) && self.vendor != '') || (has(self.deviceID) && self.deviceID != '') || (has(self.pfNames) && size(self.pfNames) > 0) || (has(self.rootDevices) && size(self.rootDevices) > 0) || (has(self.netFilter) && self.netFilter != '')",message="at least one of these parameters (vendor, deviceID, pfNames, rootDevices or netFilter) has to be defined in nicSelector"
 	// NicSelector selects the NICs to be configured
 	NicSelector SriovNetworkNicSelector `json:"nicSelector"`
-	// +kubebuilder:validation:Enum=netdevice;vfio-pci
+	// +kubebuilder:validation:Enum=netdevice;vfio-pci;uio_pci_generic;igb_uio
 	// +kubebuilder:default=netdevice
-	// The driver type for configured VFs. Allowed value "netdevice", "vfio-pci". Defaults to netdevice.
+	// The driver type for configured VFs. Allowed value "netdevice", "vfio-pci", "uio_pci_generic", "igb_uio". Defaults to netdevice.
+	// The igb_uio kernel module is not part of the upstream kernel and must be installed on the host.
 	DeviceType string `json:"deviceType,omitempty"`
 	// RDMA mode. Defaults to false.
 	IsRdma bool `json:"isRdma,omitempty"`
@@ -65,6 +78,14 @@ type SriovNetworkNodePolicySpec struct {
 	// contains bridge configuration for matching PFs,
 	// valid only for eSwitchMode==switchdev
 	Bridge Bridge `json:"bridge,omitempty"`
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_.{}-]+$`
+	// +kubebuilder:validation:MaxLength=64
+	// +kubebuilder:validation:XValidation:rule="self.contains('{vfID}')",message="'vfNameTemplate' must contain the {vfID} placeholder"
+	// +kubebuilder:validation:XValidation:rule="!self.replace('{pfName}', '').replace('{vfID}', '').matches('[{}]')",message="'vfNameTemplate' contains an unknown placeholder, allowed placeholders are {pfName} and {vfID}"
+	// Template of the names of the VF netdevs, valid only for eSwitchMode==legacy.
+	// The placeholders {pfName} and {vfID} are replaced with the name of the PF and the index of the VF,
+	// e.g. "{pfName}v{vfID}". The VFs keep the names given by the kernel if not set.
+	VfNameTemplate string `json:"vfNameTemplate,omitempty"`
 }
 
 type SriovNetworkNicSelector struct {
@@ -74,29 +95,124 @@ type SriovNetworkNicSelector struct {
 	DeviceID string `json:"deviceID,omitempty"`
 	// PCI address of SR-IoV PF.
 	RootDevices []string `json:"rootDevices,omitempty"`
-	// Name of SR-IoV PF.
+	// +kubebuilder:validation:MaxItems=256
+	// +kubebuilder:validation:items:MaxLength=64
+	// +kubebuilder:validation:items:Pattern=`^[^#]+(#[0-9]+-[0-9]+)?$`
+	// Name of SR-IoV PF, the VFs of the PF can be partitioned with the <pfName>#<first VF index>-<last VF index> notation.
 	PfNames []string `json:"pfNames,omitempty"`
-	// Infrastructure Networking selection filter. Allowed value "openstack/NetworkID:xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx"
+	// Infrastructure Networking selection filter. Allowed values "openstack/NetworkID:xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx"
+	// and "generic/Network:<network name from the virtual machine metadata>". On OpenStack the devices can also be selected
+	// by "openstack/Tag:<device tag>", "openstack/Physnet:<physical network>", "openstack/Vlan:<VLAN>" and "openstack/Trusted:true"
 	NetFilter string `json:"netFilter,omitempty"`
 }
 
 // contains spec for the bridge
+// +kubebuilder:validation:XValidation:rule="!(has(self.ovs) && has(self.linux))",message="'bridge.ovs' and 'bridge.linux' can't be used together"
 type Bridge struct {
 	// contains configuration for the OVS bridge,
 	OVS *OVSConfig `json:"ovs,omitempty"`
+	// contains configuration for the Linux bridge,
+	// can be used on the nodes without OVS
+	Linux *LinuxBridgeConfig `json:"linux,omitempty"`
 }
 
 // IsEmpty return empty if the struct doesn't contain configuration
 func (b *Bridge) IsEmpty() bool {
-	return b.OVS == nil
+	return b.OVS == nil && b.Linux == nil
 }
 
 // OVSConfig optional configuration for OVS bridge and uplink Interface
+// +kubebuilder:validation:XValidation:rule="!has(self.portBond) || (has(self.bridgeName) && size(self.bridgeName) > 0)",message="'bridge.ovs.portBond' can be used only with 'bridge.ovs.bridgeName'"
+// +kubebuilder:validation:XValidation:rule="!has(self.bond) || !has(self.bridgeName) || size(self.bridgeName) == 0",message="'bridge.ovs.bond' can't be used with 'bridge.ovs.bridgeName'"
 type OVSConfig struct {
 	// contains bridge level settings
 	Bridge OVSBridgeConfig `json:"bridge,omitempty"`
 	// contains settings for uplink (PF)
 	Uplink OVSUplinkConfig `json:"uplink,omitempty"`
+	// contains configuration for the Linux bond of the selected PFs,
+	// the bond is used as the uplink of the bridge instead of a single PF, e.g. for VF LAG
+	Bond *OVSBondConfig `json:"bond,omitempty"`
+	// +kubebuilder:validation:MaxLength=15
+	// name of the bridge to join, all PFs selected by the policy are attached to this bridge as uplinks.
+	// The PFs selected by other policies with the same bridge name are attached to the same bridge.
+	// If not set, a separate bridge is created for each PF
+	BridgeName string `json:"bridgeName,omitempty"`
+	// contains configuration for the OVS bond port which aggregates the uplinks of the bridge,
+	// can be used only with bridgeName. If not set, each uplink is attached to the bridge as a separate port
+	PortBond *OVSPortBondConfig `json:"portBond,omitempty"`
+	// +kubebuilder:validation:XValidation:rule="!(has(self.trunks) && size(self.trunks) > 0 && has(self.vlanMode) && self.vlanMode == 'access')",message="'bridge.ovs.representors.trunks' can't be used with the 'access' VLAN mode"
+	// contains settings for the ports of the VF representors,
+	// if set the representors of the VFs selected by the policy are attached to the bridge
+	Representors *OVSRepresentorPortConfig `json:"representors,omitempty"`
+}
+
+// OVSRepresentorPortConfig contains some options from the Port table in OVSDB for the VF representor
+type OVSRepresentorPortConfig struct {
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=4095
+	// tag field in the Port table in OVSDB, VLAN of the access port
+	Tag int `json:"tag,omitempty"`
+	// +kubebuilder:validation:items:Minimum=0
+	// +kubebuilder:validation:items:Maximum=4095
+	// trunks field in the Port table in OVSDB, VLANs trunked by the port
+	Trunks []int `json:"trunks,omitempty"`
+	// +kubebuilder:validation:Enum=access;trunk;native-tagged;native-untagged
+	// vlan_mode field in the Port table in OVSDB
+	VlanMode string `json:"vlanMode,omitempty"`
+}
+
+// OVSPortBondConfig contains some options from the Port table in OVSDB for the bond port
+type OVSPortBondConfig struct {
+	// +kubebuilder:validation:MinLength=1
+	// name of the bond port
+	Name string `json:"name"`
+	// +kubebuilder:validation:Enum=active-backup;balance-slb;balance-tcp
+	// bond_mode field in the Port table in OVSDB
+	Mode string `json:"mode,omitempty"`
+	// +kubebuilder:validation:Enum=active;passive;off
+	// lacp field in the Port table in OVSDB
+	LACP string `json:"lacp,omitempty"`
+}
+
+// OVSBondConfig contains configuration for the Linux bond used as the uplink of the OVS bridge
+type OVSBondConfig struct {
+	// +kubebuilder:validation:MaxLength=15
+	// +kubebuilder:validation:MinLength=1
+	// name of the bond interface
+	Name string `json:"name"`
+	// +kubebuilder:validation:Enum=active-backup;balance-xor;"802.3ad"
+	// bonding mode, defaults to active-backup
+	Mode string `json:"mode,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	// MII link monitoring frequency in milliseconds
+	Miimon int `json:"miimon,omitempty"`
+}
+
+// LinuxBridgeConfig optional configuration for Linux bridge and uplink interface
+// +kubebuilder:validation:XValidation:rule="!has(self.uplink) || !has(self.uplink.vlans) || size(self.uplink.vlans) == 0 || (has(self.bridge) && has(self.bridge.vlanFiltering) && self.bridge.vlanFiltering)",message="'bridge.linux.uplink.vlans' requires 'bridge.linux.bridge.vlanFiltering'"
+type LinuxBridgeConfig struct {
+	// +kubebuilder:validation:MaxLength=15
+	// name of the bridge to join, all PFs selected by the policy are attached to this bridge as uplinks.
+	// If not set, a separate bridge is created for each PF
+	BridgeName string `json:"bridgeName,omitempty"`
+	// contains bridge level settings
+	Bridge LinuxBridgeOptions `json:"bridge,omitempty"`
+	// contains settings for uplink (PF)
+	Uplink LinuxUplinkConfig `json:"uplink,omitempty"`
+}
+
+// LinuxBridgeOptions contains bridge level settings for the Linux bridge
+type LinuxBridgeOptions struct {
+	// enables VLAN filtering on the bridge
+	VlanFiltering bool `json:"vlanFiltering,omitempty"`
+}
+
+// LinuxUplinkConfig contains PF interface configuration for the Linux bridge
+type LinuxUplinkConfig struct {
+	// +kubebuilder:validation:items:Minimum=2
+	// +kubebuilder:validation:items:Maximum=4094
+	// tagged VLANs allowed on the uplink port, requires VLAN filtering on the bridge
+	Vlans []int `json:"vlans,omitempty"`
 }
 
 // OVSBridgeConfig contains some options from the Bridge table in OVSDB
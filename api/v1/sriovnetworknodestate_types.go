@@ -41,6 +41,7 @@ type Interface struct {
 	EswitchMode       string    `json:"eSwitchMode,omitempty"`
 	VfGroups          []VfGroup `json:"vfGroups,omitempty"`
 	ExternallyManaged bool      `json:"externallyManaged,omitempty"`
+	VfNameTemplate    string    `json:"vfNameTemplate,omitempty"`
 }
 
 type VfGroup struct {
@@ -54,13 +55,19 @@ type VfGroup struct {
 }
 
 type InterfaceExt struct {
-	Name              string            `json:"name,omitempty"`
-	Mac               string            `json:"mac,omitempty"`
-	Driver            string            `json:"driver,omitempty"`
-	PciAddress        string            `json:"pciAddress"`
-	Vendor            string            `json:"vendor,omitempty"`
-	DeviceID          string            `json:"deviceID,omitempty"`
-	NetFilter         string            `json:"netFilter,omitempty"`
+	Name       string `json:"name,omitempty"`
+	Mac        string `json:"mac,omitempty"`
+	Driver     string `json:"driver,omitempty"`
+	PciAddress string `json:"pciAddress"`
+	Vendor     string `json:"vendor,omitempty"`
+	DeviceID   string `json:"deviceID,omitempty"`
+	NetFilter  string `json:"netFilter,omitempty"`
+	// Additional infrastructure networking filters of the device on the virtual platforms, e.g. "openstack/Tag:dpdk0"
+	NetFilterTags []string `json:"netFilterTags,omitempty"`
+	// VLAN of the port of the device on the virtual platforms
+	Vlan int `json:"vlan,omitempty"`
+	// Trusted mode of the port of the device on the virtual platforms
+	Trusted           bool              `json:"trusted,omitempty"`
 	Mtu               int               `json:"mtu,omitempty"`
 	NumVfs            int               `json:"numVfs,omitempty"`
 	LinkSpeed         string            `json:"linkSpeed,omitempty"`
@@ -91,7 +98,8 @@ type VirtualFunction struct {
 
 // Bridges contains list of bridges
 type Bridges struct {
-	OVS []OVSConfigExt `json:"ovs,omitempty"`
+	OVS   []OVSConfigExt         `json:"ovs,omitempty"`
+	Linux []LinuxBridgeConfigExt `json:"linux,omitempty"`
 }
 
 // OVSConfigExt contains configuration for the concrete OVS bridge
@@ -100,9 +108,14 @@ type OVSConfigExt struct {
 	Name string `json:"name"`
 	// bridge-level configuration for the bridge
 	Bridge OVSBridgeConfig `json:"bridge,omitempty"`
-	// uplink-level bridge configuration for each uplink(PF).
-	// currently must contain only one element
+	// uplink-level bridge configuration for each uplink(PF), sorted by PCI address.
+	// contains the member PFs if the Linux bond or the OVS bond port is set,
+	// otherwise each uplink is attached to the bridge as a separate port
 	Uplinks []OVSUplinkConfigExt `json:"uplinks,omitempty"`
+	// configuration for the Linux bond used as the uplink of the bridge
+	Bond *OVSBondConfig `json:"bond,omitempty"`
+	// configuration for the OVS bond port which aggregates the uplinks
+	PortBond *OVSPortBondConfig `json:"portBond,omitempty"`
 }
 
 // OVSUplinkConfigExt contains configuration for the concrete OVS uplink(PF)
@@ -113,21 +126,79 @@ type OVSUplinkConfigExt struct {
 	Name string `json:"name,omitempty"`
 	// configuration from the Interface OVS table for the PF
 	Interface OVSInterfaceConfig `json:"interface,omitempty"`
+	// configuration of the ports for the VF representors of the PF,
+	// each element contains the settings for a VF group
+	Representors []OVSRepresentorsConfigExt `json:"representors,omitempty"`
+}
+
+// OVSRepresentorsConfigExt contains configuration of the ports for the representors of the VFs in the range
+type OVSRepresentorsConfigExt struct {
+	// range of the VFs, same format as in the VF group
+	VfRange string `json:"vfRange"`
+	// configuration from the Port OVS table for each representor
+	Port OVSRepresentorPortConfig `json:"port,omitempty"`
+}
+
+// LinuxBridgeConfigExt contains configuration for the concrete Linux bridge
+type LinuxBridgeConfigExt struct {
+	// name of the bridge
+	Name string `json:"name"`
+	// bridge-level configuration for the bridge
+	Bridge LinuxBridgeOptions `json:"bridge,omitempty"`
+	// uplink-level bridge configuration for each uplink(PF), sorted by PCI address
+	Uplinks []LinuxUplinkConfigExt `json:"uplinks,omitempty"`
+}
+
+// LinuxUplinkConfigExt contains configuration for the concrete Linux bridge uplink(PF)
+type LinuxUplinkConfigExt struct {
+	// pci address of the PF
+	PciAddress string `json:"pciAddress"`
+	// name of the PF interface
+	Name string `json:"name,omitempty"`
+	// configuration of the bridge port for the PF
+	Interface LinuxUplinkConfig `json:"interface,omitempty"`
 }
 
 type System struct {
 	// +kubebuilder:validation:Enum=shared;exclusive
 	//RDMA subsystem. Allowed value "shared", "exclusive".
 	RdmaMode string `json:"rdmaMode,omitempty"`
+	// Additional kernel arguments to configure on the node
+	KernelArgs []string `json:"kernelArgs,omitempty"`
+	// Kernel modules parameters to persist on the node
+	KernelModules []KernelModule `json:"kernelModules,omitempty"`
+	// Load the vfio driver in the unsafe no-IOMMU mode
+	VfioNoIommuMode bool `json:"vfioNoIommuMode,omitempty"`
+	// Hugepages to allocate on the NUMA nodes of the PFs used by userspace VF groups
+	Hugepages *HugepagesConfig `json:"hugepages,omitempty"`
+	// OVS hardware offload configuration of the node
+	OvsHardwareOffload *OvsHardwareOffload `json:"ovsHardwareOffload,omitempty"`
+}
+
+// OvsHardwareOffload contains the OVS hardware offload settings from the Open_vSwitch table of OVSDB
+type OvsHardwareOffload struct {
+	// Enabled is true if other_config:hw-offload is set to true
+	Enabled bool `json:"enabled"`
+	// TcPolicy is the value of other_config:tc-policy
+	TcPolicy string `json:"tcPolicy,omitempty"`
+}
+
+// HugepagesStatus contains the hugepages allocated on a NUMA node
+type HugepagesStatus struct {
+	NumaNode int    `json:"numaNode"`
+	Size     string `json:"size"`
+	Total    int    `json:"total"`
+	Free     int    `json:"free"`
 }
 
 // SriovNetworkNodeStateStatus defines the observed state of SriovNetworkNodeState
 type SriovNetworkNodeStateStatus struct {
-	Interfaces    InterfaceExts `json:"interfaces,omitempty"`
-	Bridges       Bridges       `json:"bridges,omitempty"`
-	System        System        `json:"system,omitempty"`
-	SyncStatus    string        `json:"syncStatus,omitempty"`
-	LastSyncError string        `json:"lastSyncError,omitempty"`
+	Interfaces    InterfaceExts     `json:"interfaces,omitempty"`
+	Bridges       Bridges           `json:"bridges,omitempty"`
+	System        System            `json:"system,omitempty"`
+	Hugepages     []HugepagesStatus `json:"hugepages,omitempty"`
+	SyncStatus    string            `json:"syncStatus,omitempty"`
+	LastSyncError string            `json:"lastSyncError,omitempty"`
 }
 
 //+kubebuilder:object:root=true
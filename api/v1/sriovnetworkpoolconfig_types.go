@@ -25,6 +25,43 @@ type SriovNetworkPoolConfigSpec struct {
 	// +kubebuilder:validation:Enum=shared;exclusive
 	// RDMA subsystem. Allowed value "shared", "exclusive".
 	RdmaMode string `json:"rdmaMode,omitempty"`
+
+	// +kubebuilder:validation:items:Pattern=`^[a-zA-Z0-9_.-]+(=[a-zA-Z0-9_.,:=/+-]+)?$`
+	// KernelArgs is a list of additional kernel arguments to configure on the nodes of the pool,
+	// e.g. "hugepagesz=1G" or "isolcpus=2-5". Updating the kernel arguments requires a node reboot.
+	KernelArgs []string `json:"kernelArgs,omitempty"`
+
+	// VfioNoIommuMode loads the vfio driver in the unsafe no-IOMMU mode, for virtual machines without a virtual IOMMU.
+	// The IOMMU kernel arguments are not configured in this mode and the devices bound to vfio-pci have no DMA isolation.
+	VfioNoIommuMode bool `json:"vfioNoIommuMode,omitempty"`
+
+	// KernelModules is a list of kernel modules parameters to persist on the nodes of the pool.
+	// A node reboot is requested if a loaded module doesn't run with the requested parameters.
+	KernelModules []KernelModule `json:"kernelModules,omitempty"`
+
+	// Hugepages to allocate on the NUMA nodes of the PFs with VFs bound to a userspace driver (DPDK).
+	Hugepages *HugepagesConfig `json:"hugepages,omitempty"`
+}
+
+type HugepagesConfig struct {
+	// +kubebuilder:validation:Enum="2M";"1G"
+	// +kubebuilder:default="1G"
+	// Size of the hugepages. Allowed value "2M", "1G".
+	Size string `json:"size,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	// Count of hugepages to allocate on every NUMA node of the PFs used by userspace VF groups.
+	// The hugepages are allocated at runtime, if the memory is too fragmented for that the
+	// allocation is done with kernel arguments and the node is rebooted.
+	Count int `json:"count"`
+}
+
+type KernelModule struct {
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_-]+$`
+	// Name of the kernel module, e.g. "mlx5_core" or "vfio_pci"
+	Name string `json:"name"`
+	// +kubebuilder:validation:items:Pattern=`^[a-zA-Z0-9_]+=[a-zA-Z0-9_.,:-]+$`
+	// Params is a list of the module parameters in the key=value format, e.g. "ids=8086:154c"
+	Params []string `json:"params,omitempty"`
 }
 
 type OvsHardwareOffloadConfig struct {
@@ -34,6 +71,10 @@ type OvsHardwareOffloadConfig struct {
 	// On OpenShift:
 	// Name is the name of MachineConfigPool to be enabled with OVS hardware offload
 	Name string `json:"name,omitempty"`
+	// +kubebuilder:validation:Enum=none;skip_sw;skip_hw
+	// TcPolicy is the policy used with HW offloading, set as other_config:tc-policy of OVS.
+	// Allowed value "none", "skip_sw", "skip_hw". OVS default is used if not set.
+	TcPolicy string `json:"tcPolicy,omitempty"`
 }
 
 // SriovNetworkPoolConfigStatus defines the observed state of SriovNetworkPoolConfig
@@ -56,8 +56,9 @@ type SriovOperatorConfigSpec struct {
 	// Flag to enable OVS hardware offload. Set to 'true' to provision switchdev-configuration.service and enable OpenvSwitch hw-offload on nodes.
 	EnableOvsOffload bool `json:"enableOvsOffload,omitempty"`
 	// Flag to enable the sriov-network-config-daemon to use a systemd service to configure SR-IOV devices on boot
+	// In hybrid mode the daemon applies the configuration live and the systemd service restores it on boot
 	// Default mode: daemon
-	// +kubebuilder:validation:Enum=daemon;systemd
+	// +kubebuilder:validation:Enum=daemon;systemd;hybrid
 	ConfigurationMode ConfigurationModeType `json:"configurationMode,omitempty"`
 	// Flag to enable Container Device Interface mode for SR-IOV Network Device Plugin
 	UseCDI bool `json:"useCDI,omitempty"`
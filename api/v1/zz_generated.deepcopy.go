@@ -34,6 +34,11 @@ func (in *Bridge) DeepCopyInto(out *Bridge) {
 		*out = new(OVSConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Linux != nil {
+		in, out := &in.Linux, &out.Linux
+		*out = new(LinuxBridgeConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Bridge.
@@ -56,6 +61,13 @@ func (in *Bridges) DeepCopyInto(out *Bridges) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Linux != nil {
+		in, out := &in.Linux, &out.Linux
+		*out = make([]LinuxBridgeConfigExt, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Bridges.
@@ -89,6 +101,36 @@ func (in ByPriority) DeepCopy() ByPriority {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HugepagesConfig) DeepCopyInto(out *HugepagesConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HugepagesConfig.
+func (in *HugepagesConfig) DeepCopy() *HugepagesConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HugepagesConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HugepagesStatus) DeepCopyInto(out *HugepagesStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HugepagesStatus.
+func (in *HugepagesStatus) DeepCopy() *HugepagesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HugepagesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Interface) DeepCopyInto(out *Interface) {
 	*out = *in
@@ -112,6 +154,11 @@ func (in *Interface) DeepCopy() *Interface {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InterfaceExt) DeepCopyInto(out *InterfaceExt) {
 	*out = *in
+	if in.NetFilterTags != nil {
+		in, out := &in.NetFilterTags, &out.NetFilterTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.VFs != nil {
 		in, out := &in.VFs, &out.VFs
 		*out = make([]VirtualFunction, len(*in))
@@ -171,6 +218,132 @@ func (in Interfaces) DeepCopy() Interfaces {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KernelModule) DeepCopyInto(out *KernelModule) {
+	*out = *in
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KernelModule.
+func (in *KernelModule) DeepCopy() *KernelModule {
+	if in == nil {
+		return nil
+	}
+	out := new(KernelModule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinuxBridgeConfig) DeepCopyInto(out *LinuxBridgeConfig) {
+	*out = *in
+	out.Bridge = in.Bridge
+	in.Uplink.DeepCopyInto(&out.Uplink)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinuxBridgeConfig.
+func (in *LinuxBridgeConfig) DeepCopy() *LinuxBridgeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LinuxBridgeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinuxBridgeConfigExt) DeepCopyInto(out *LinuxBridgeConfigExt) {
+	*out = *in
+	out.Bridge = in.Bridge
+	if in.Uplinks != nil {
+		in, out := &in.Uplinks, &out.Uplinks
+		*out = make([]LinuxUplinkConfigExt, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinuxBridgeConfigExt.
+func (in *LinuxBridgeConfigExt) DeepCopy() *LinuxBridgeConfigExt {
+	if in == nil {
+		return nil
+	}
+	out := new(LinuxBridgeConfigExt)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinuxBridgeOptions) DeepCopyInto(out *LinuxBridgeOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinuxBridgeOptions.
+func (in *LinuxBridgeOptions) DeepCopy() *LinuxBridgeOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(LinuxBridgeOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinuxUplinkConfig) DeepCopyInto(out *LinuxUplinkConfig) {
+	*out = *in
+	if in.Vlans != nil {
+		in, out := &in.Vlans, &out.Vlans
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinuxUplinkConfig.
+func (in *LinuxUplinkConfig) DeepCopy() *LinuxUplinkConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LinuxUplinkConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinuxUplinkConfigExt) DeepCopyInto(out *LinuxUplinkConfigExt) {
+	*out = *in
+	in.Interface.DeepCopyInto(&out.Interface)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinuxUplinkConfigExt.
+func (in *LinuxUplinkConfigExt) DeepCopy() *LinuxUplinkConfigExt {
+	if in == nil {
+		return nil
+	}
+	out := new(LinuxUplinkConfigExt)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVSBondConfig) DeepCopyInto(out *OVSBondConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVSBondConfig.
+func (in *OVSBondConfig) DeepCopy() *OVSBondConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OVSBondConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OVSBridgeConfig) DeepCopyInto(out *OVSBridgeConfig) {
 	*out = *in
@@ -205,6 +378,21 @@ func (in *OVSConfig) DeepCopyInto(out *OVSConfig) {
 	*out = *in
 	in.Bridge.DeepCopyInto(&out.Bridge)
 	in.Uplink.DeepCopyInto(&out.Uplink)
+	if in.Bond != nil {
+		in, out := &in.Bond, &out.Bond
+		*out = new(OVSBondConfig)
+		**out = **in
+	}
+	if in.PortBond != nil {
+		in, out := &in.PortBond, &out.PortBond
+		*out = new(OVSPortBondConfig)
+		**out = **in
+	}
+	if in.Representors != nil {
+		in, out := &in.Representors, &out.Representors
+		*out = new(OVSRepresentorPortConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVSConfig.
@@ -228,6 +416,16 @@ func (in *OVSConfigExt) DeepCopyInto(out *OVSConfigExt) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Bond != nil {
+		in, out := &in.Bond, &out.Bond
+		*out = new(OVSBondConfig)
+		**out = **in
+	}
+	if in.PortBond != nil {
+		in, out := &in.PortBond, &out.PortBond
+		*out = new(OVSPortBondConfig)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVSConfigExt.
@@ -381,6 +579,57 @@ func (in *OVSNetworkStatus) DeepCopy() *OVSNetworkStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVSPortBondConfig) DeepCopyInto(out *OVSPortBondConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVSPortBondConfig.
+func (in *OVSPortBondConfig) DeepCopy() *OVSPortBondConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OVSPortBondConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVSRepresentorPortConfig) DeepCopyInto(out *OVSRepresentorPortConfig) {
+	*out = *in
+	if in.Trunks != nil {
+		in, out := &in.Trunks, &out.Trunks
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVSRepresentorPortConfig.
+func (in *OVSRepresentorPortConfig) DeepCopy() *OVSRepresentorPortConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OVSRepresentorPortConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVSRepresentorsConfigExt) DeepCopyInto(out *OVSRepresentorsConfigExt) {
+	*out = *in
+	in.Port.DeepCopyInto(&out.Port)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVSRepresentorsConfigExt.
+func (in *OVSRepresentorsConfigExt) DeepCopy() *OVSRepresentorsConfigExt {
+	if in == nil {
+		return nil
+	}
+	out := new(OVSRepresentorsConfigExt)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OVSUplinkConfig) DeepCopyInto(out *OVSUplinkConfig) {
 	*out = *in
@@ -401,6 +650,13 @@ func (in *OVSUplinkConfig) DeepCopy() *OVSUplinkConfig {
 func (in *OVSUplinkConfigExt) DeepCopyInto(out *OVSUplinkConfigExt) {
 	*out = *in
 	in.Interface.DeepCopyInto(&out.Interface)
+	if in.Representors != nil {
+		in, out := &in.Representors, &out.Representors
+		*out = make([]OVSRepresentorsConfigExt, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVSUplinkConfigExt.
@@ -413,6 +669,21 @@ func (in *OVSUplinkConfigExt) DeepCopy() *OVSUplinkConfigExt {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OvsHardwareOffload) DeepCopyInto(out *OvsHardwareOffload) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvsHardwareOffload.
+func (in *OvsHardwareOffload) DeepCopy() *OvsHardwareOffload {
+	if in == nil {
+		return nil
+	}
+	out := new(OvsHardwareOffload)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OvsHardwareOffloadConfig) DeepCopyInto(out *OvsHardwareOffloadConfig) {
 	*out = *in
@@ -788,7 +1059,7 @@ func (in *SriovNetworkNodeStateSpec) DeepCopyInto(out *SriovNetworkNodeStateSpec
 		}
 	}
 	in.Bridges.DeepCopyInto(&out.Bridges)
-	out.System = in.System
+	in.System.DeepCopyInto(&out.System)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovNetworkNodeStateSpec.
@@ -812,7 +1083,12 @@ func (in *SriovNetworkNodeStateStatus) DeepCopyInto(out *SriovNetworkNodeStateSt
 		}
 	}
 	in.Bridges.DeepCopyInto(&out.Bridges)
-	out.System = in.System
+	in.System.DeepCopyInto(&out.System)
+	if in.Hugepages != nil {
+		in, out := &in.Hugepages, &out.Hugepages
+		*out = make([]HugepagesStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovNetworkNodeStateStatus.
@@ -898,6 +1174,23 @@ func (in *SriovNetworkPoolConfigSpec) DeepCopyInto(out *SriovNetworkPoolConfigSp
 		*out = new(intstr.IntOrString)
 		**out = **in
 	}
+	if in.KernelArgs != nil {
+		in, out := &in.KernelArgs, &out.KernelArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.KernelModules != nil {
+		in, out := &in.KernelModules, &out.KernelModules
+		*out = make([]KernelModule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Hugepages != nil {
+		in, out := &in.Hugepages, &out.Hugepages
+		*out = new(HugepagesConfig)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovNetworkPoolConfigSpec.
@@ -1076,6 +1369,28 @@ func (in *SriovOperatorConfigStatus) DeepCopy() *SriovOperatorConfigStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *System) DeepCopyInto(out *System) {
 	*out = *in
+	if in.KernelArgs != nil {
+		in, out := &in.KernelArgs, &out.KernelArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.KernelModules != nil {
+		in, out := &in.KernelModules, &out.KernelModules
+		*out = make([]KernelModule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Hugepages != nil {
+		in, out := &in.Hugepages, &out.Hugepages
+		*out = new(HugepagesConfig)
+		**out = **in
+	}
+	if in.OvsHardwareOffload != nil {
+		in, out := &in.OvsHardwareOffload, &out.OvsHardwareOffload
+		*out = new(OvsHardwareOffload)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new System.
@@ -0,0 +1,46 @@
+#!/bin/bash
+set -x
+
+# Loads the default kernel of the bootloader together with its configured
+# kernel arguments into kexec, so the node can be restarted with the updated
+# kernel arguments without going through the firmware.
+# Exit code 127 means that kexec is not supported on the host.
+
+# Kernel args configuration isn't supported for Ubuntu now, so we shouldn't use kexec here
+if [[ "$(chroot /host/ grep -i ubuntu /etc/os-release -c)" != "0" ]]; then
+    exit 127
+fi
+
+# rpm-ostree manages the boot entries and stages the deployments on reboot
+if chroot /host/ test -f /run/ostree-booted ; then
+    exit 127
+fi
+
+for cmd in kexec grubby; do
+    chroot /host/ which ${cmd} > /dev/null 2>&1
+    # if the command is not there, let's tell it
+    if [ $? -ne 0 ]; then
+        exit 127
+    fi
+done
+
+kernel=$(chroot /host/ grubby --default-kernel)
+if [[ -z "${kernel}" ]]; then
+    exit 1
+fi
+
+info=$(chroot /host/ grubby --info="${kernel}")
+args=$(echo "${info}" | grep '^args=' | sed -e 's/^args="//' -e 's/"$//')
+root=$(echo "${info}" | grep '^root=' | sed -e 's/^root="//' -e 's/"$//')
+initrd=$(echo "${info}" | grep '^initrd=' | sed -e 's/^initrd="//' -e 's/"$//' | awk '{print $1}')
+
+cmdline="${args}"
+if [[ -n "${root}" ]]; then
+    cmdline="root=${root} ${args}"
+fi
+
+if [[ -n "${initrd}" ]]; then
+    chroot /host/ kexec --load "${kernel}" --initrd="${initrd}" --append="${cmdline}"
+else
+    chroot /host/ kexec --load "${kernel}" --append="${cmdline}"
+fi
@@ -0,0 +1,14 @@
+#!/bin/bash
+# prints the PCI address of the PF and the index of the VF for the VF netdev provided as argument,
+# nothing is printed if the netdev is not a VF
+NETDEV="$1"
+DEVICE_PATH="/sys/class/net/${NETDEV}/device"
+[ -e "${DEVICE_PATH}/physfn" ] || exit 0
+VF_PCI_ADDRESS=$(basename "$(readlink -f "${DEVICE_PATH}")")
+for VIRTFN in "${DEVICE_PATH}"/physfn/virtfn*; do
+  if [ "$(basename "$(readlink -f "${VIRTFN}")")" == "${VF_PCI_ADDRESS}" ]; then
+    echo "SRIOV_VF_PF=$(basename "$(readlink -f "${DEVICE_PATH}/physfn")")"
+    echo "SRIOV_VF_ID=${VIRTFN##*virtfn}"
+    exit 0
+  fi
+done
@@ -163,6 +163,8 @@ func (s *ServiceConfig) readConf() error {
 		}
 	}
 	s.sriovConfig = nodeStateSpec
+	// the platform helper selects the virtual platform provider by the platform type
+	vars.PlatformType = nodeStateSpec.PlatformType
 	return nil
 }
 
@@ -251,7 +253,7 @@ func (s *ServiceConfig) getPlugin(phase string) (plugin.VendorPlugin, error) {
 		if err != nil {
 			return nil, fmt.Errorf("failed to create generic plugin for %v", err)
 		}
-	case consts.VirtualOpenStack:
+	case consts.VirtualOpenStack, consts.VirtualGeneric:
 		switch phase {
 		case PhasePre:
 			configPlugin, err = newVirtualPluginFunc(s.hostHelper)
@@ -285,14 +287,14 @@ func (s *ServiceConfig) getNetworkNodeState(phase string) (*sriovv1.SriovNetwork
 				return nil, fmt.Errorf("failed to discover managed bridges on the host:  %v", err)
 			}
 		}
-	case consts.VirtualOpenStack:
+	case consts.VirtualOpenStack, consts.VirtualGeneric:
 		platformHelper, err := newPlatformHelperFunc()
 		if err != nil {
 			return nil, fmt.Errorf("failed to create platformHelpers")
 		}
-		err = platformHelper.CreateOpenstackDevicesInfo()
+		err = platformHelper.CreateDevicesInfo()
 		if err != nil {
-			return nil, fmt.Errorf("failed to read OpenStack data: %v", err)
+			return nil, fmt.Errorf("failed to read the devices metadata of the %s platform: %v", s.sriovConfig.PlatformType, err)
 		}
 		ifaceStatuses, err = platformHelper.DiscoverSriovDevicesVirtual()
 		if err != nil {
@@ -181,7 +181,7 @@ var _ = Describe("Service", func() {
 		hostHelpers.EXPECT().RemoveSriovResult().Return(nil)
 		hostHelpers.EXPECT().WriteSriovResult(&hosttypes.SriovResult{SyncStatus: consts.SyncStatusInProgress})
 
-		platformHelper.EXPECT().CreateOpenstackDevicesInfo().Return(nil)
+		platformHelper.EXPECT().CreateDevicesInfo().Return(nil)
 		platformHelper.EXPECT().DiscoverSriovDevicesVirtual().Return([]sriovnetworkv1.InterfaceExt{{
 			Name: "enp216s0f0np0",
 		}}, nil)
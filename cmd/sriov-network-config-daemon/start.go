@@ -46,6 +46,7 @@ import (
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/helper"
 	snolog "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/log"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/platforms"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
 )
 
@@ -81,13 +82,16 @@ var (
 	}
 
 	startOpts struct {
-		kubeconfig            string
-		nodeName              string
-		systemd               bool
-		disabledPlugins       stringList
-		parallelNicConfig     bool
-		manageSoftwareBridges bool
-		ovsSocketPath         string
+		kubeconfig              string
+		nodeName                string
+		systemd                 bool
+		hybrid                  bool
+		disabledPlugins         stringList
+		parallelNicConfig       bool
+		liveVfResizeDrivers     []string
+		genericVirtualProviders []string
+		manageSoftwareBridges   bool
+		ovsSocketPath           string
 	}
 
 	scheme = runtime.NewScheme()
@@ -98,8 +102,13 @@ func init() {
 	startCmd.PersistentFlags().StringVar(&startOpts.kubeconfig, "kubeconfig", "", "Kubeconfig file to access a remote cluster (testing only)")
 	startCmd.PersistentFlags().StringVar(&startOpts.nodeName, "node-name", "", "kubernetes node name daemon is managing")
 	startCmd.PersistentFlags().BoolVar(&startOpts.systemd, "use-systemd-service", false, "use config daemon in systemd mode")
+	startCmd.PersistentFlags().BoolVar(&startOpts.hybrid, "use-hybrid-mode", false, "apply the configuration live and persist it for the systemd service")
 	startCmd.PersistentFlags().VarP(&startOpts.disabledPlugins, "disable-plugins", "", "comma-separated list of plugins to disable")
 	startCmd.PersistentFlags().BoolVar(&startOpts.parallelNicConfig, "parallel-nic-config", false, "perform NIC configuration in parallel")
+	startCmd.PersistentFlags().StringSliceVar(&startOpts.liveVfResizeDrivers, "live-vf-resize-drivers", nil,
+		"comma-separated list of PF drivers that can change the number of VFs without resetting the existing VFs")
+	startCmd.PersistentFlags().StringSliceVar(&startOpts.genericVirtualProviders, "generic-virtual-providers", nil,
+		"comma-separated list of node provider ID schemes of the virtual machines handled by the generic virtual platform")
 	startCmd.PersistentFlags().BoolVar(&startOpts.manageSoftwareBridges, "manage-software-bridges", false, "enable management of software bridges")
 	startCmd.PersistentFlags().StringVar(&startOpts.ovsSocketPath, "ovs-socket-path", vars.OVSDBSocketPath, "path for OVSDB socket")
 
@@ -118,8 +127,14 @@ func configGlobalVariables() error {
 	if startOpts.systemd {
 		vars.UsingSystemdMode = true
 	}
+	vars.UsingHybridMode = startOpts.hybrid
+	if vars.UsingSystemdMode && vars.UsingHybridMode {
+		return fmt.Errorf("use-systemd-service and use-hybrid-mode flags are mutually exclusive")
+	}
 
 	vars.ParallelNicConfig = startOpts.parallelNicConfig
+	vars.LiveVfResizeDrivers = startOpts.liveVfResizeDrivers
+	vars.GenericVirtualProviders = startOpts.genericVirtualProviders
 	vars.ManageSoftwareBridges = startOpts.manageSoftwareBridges
 	vars.OVSDBSocketPath = startOpts.ovsSocketPath
 
@@ -262,11 +277,7 @@ func runStartCmd(cmd *cobra.Command, args []string) error {
 	}
 
 	// check for platform
-	for key, pType := range vars.PlatformsMap {
-		if strings.Contains(strings.ToLower(nodeInfo.Spec.ProviderID), strings.ToLower(key)) {
-			vars.PlatformType = pType
-		}
-	}
+	vars.PlatformType = utils.GetPlatformType(nodeInfo.Spec.ProviderID)
 	setupLog.Info("Running on", "platform", vars.PlatformType.String())
 
 	// Initial supported nic IDs
@@ -337,6 +348,12 @@ func runStartCmd(cmd *cobra.Command, args []string) error {
 		os.Exit(1)
 	}
 
+	// Reconcile on host changes like hot-plugged NICs without waiting for the next poll
+	if err = dm.SetupHostEventWatcher(mgr); err != nil {
+		setupLog.Error(err, "unable to setup host event watcher")
+		os.Exit(1)
+	}
+
 	// Setup reconcile loop with manager
 	if err = dm.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to setup daemon with manager for SriovNetworkNodeState")
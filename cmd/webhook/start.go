@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"reflect"
+	"strings"
 
 	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/cobra"
@@ -15,6 +17,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	snolog "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/log"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/webhook"
 )
 
@@ -145,6 +148,10 @@ func runStartCmd(cmd *cobra.Command, args []string) {
 		panic(err)
 	}
 
+	if providers := os.Getenv("GENERIC_VIRTUAL_PROVIDERS"); providers != "" {
+		vars.GenericVirtualProviders = strings.Split(providers, ",")
+	}
+
 	if err := webhook.RetriveSupportedNics(); err != nil {
 		setupLog.Error(err, "failed to retrieve supported NICs")
 		panic(err)
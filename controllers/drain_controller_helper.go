@@ -3,6 +3,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -101,8 +102,14 @@ func (dr *DrainReconcile) handleNodeDrainOrReboot(ctx context.Context,
 		}
 	}
 
+	// on a non-full drain evict only the pods using the resources of the reconfigured PFs
+	var resourceNames []string
+	if drainResources := nodeNetworkState.GetAnnotations()[constants.NodeStateDrainResourcesAnnotation]; drainResources != "" {
+		resourceNames = strings.Split(drainResources, ",")
+	}
+
 	// call the drain function that will also call drain to other platform providers like openshift
-	drained, err := dr.drainer.DrainNode(ctx, node, fullNodeDrain, singleNode)
+	drained, err := dr.drainer.DrainNode(ctx, node, fullNodeDrain, singleNode, resourceNames)
 	if err != nil {
 		reqLogger.Error(err, "error trying to drain the node")
 		dr.recorder.Event(nodeNetworkState,
@@ -221,8 +228,8 @@ func (dr *DrainReconcile) findNodePoolConfig(ctx context.Context, node *corev1.N
 	nodesInPools := map[string]interface{}{}
 
 	for _, npc := range npcl.Items {
-		// we skip hw offload objects
-		if npc.Spec.OvsHardwareOffloadConfig.Name != "" {
+		// we skip hw offload objects on OpenShift, they select the nodes of a MachineConfigPool
+		if npc.Spec.OvsHardwareOffloadConfig.Name != "" && vars.ClusterType == constants.ClusterTypeOpenshift {
 			continue
 		}
 
@@ -45,6 +45,9 @@ var _ = Describe("Drain Controller", Ordered, func() {
 		platformHelper.EXPECT().IsHypershift().Return(false).AnyTimes()
 		platformHelper.EXPECT().OpenshiftBeforeDrainNode(gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
 		platformHelper.EXPECT().OpenshiftAfterCompleteDrainNode(gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+		platformHelper.EXPECT().AcquireNode(gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+		platformHelper.EXPECT().ReleaseNode(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		platformHelper.EXPECT().RenewNode(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 
 		// we need a client that doesn't use the local cache for the objects
 		drainKClient, err := client.New(cfg, client.Options{
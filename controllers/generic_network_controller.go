@@ -36,6 +36,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	constants "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
 )
@@ -69,6 +70,8 @@ type genericNetworkReconciler struct {
 	client.Client
 	Scheme     *runtime.Scheme
 	controller networkController
+	// podReader reads the pods attached to the network from the cache with the indexes of utils.SetupPodIndexes
+	podReader client.Reader
 }
 
 func (r *genericNetworkReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -106,6 +109,17 @@ func (r *genericNetworkReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	} else {
 		// The object is being deleted
 		if sriovnetworkv1.StringInArray(sriovnetworkv1.NETATTDEFFINALIZERNAME, instanceFinalizers) {
+			// keep the NetworkAttachmentDefinition while running pods are attached to the network
+			if instance.GetAnnotations()[constants.ForceDeleteAnnotation] != "true" {
+				pods, err := r.getPodsUsingNetwork(ctx, instance)
+				if err != nil {
+					return reconcile.Result{}, err
+				}
+				if len(pods) > 0 {
+					reqLogger.Info("network is under deletion but it's still used, waiting for the pods to be removed", "pods", pods)
+					return reconcile.Result{RequeueAfter: constants.ResourceInUseRequeueTime}, nil
+				}
+			}
 			// our finalizer is present, so lets handle any external dependency
 			reqLogger.Info("delete NetworkAttachmentDefinition CR", "Namespace", instance.NetworkNamespace(), "Name", instance.GetName())
 			if err := r.deleteNetAttDef(ctx, instance); err != nil {
@@ -196,6 +210,8 @@ func (r *genericNetworkReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *genericNetworkReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.podReader = mgr.GetClient()
+
 	// Reconcile when the target namespace is created after the network object.
 	namespaceHandler := handler.Funcs{
 		CreateFunc: r.namespaceHandlerCreate,
@@ -235,6 +251,16 @@ func (r *genericNetworkReconciler) namespaceHandlerCreate(ctx context.Context, e
 	})
 }
 
+// getPodsUsingNetwork returns the running pods attached to the generated net-att-def CR
+func (r *genericNetworkReconciler) getPodsUsingNetwork(ctx context.Context, cr NetworkCRInstance) ([]string, error) {
+	namespace := cr.NetworkNamespace()
+	if namespace == "" {
+		namespace = cr.GetNamespace()
+	}
+	return utils.GetPodsUsingNetwork(ctx, r.podReader, cr.GetName(), namespace,
+		client.MatchingFields{utils.PodNetworkIndex: namespace + "/" + cr.GetName()})
+}
+
 // deleteNetAttDef deletes the generated net-att-def CR
 func (r *genericNetworkReconciler) deleteNetAttDef(ctx context.Context, cr NetworkCRInstance) error {
 	// Fetch the NetworkAttachmentDefinition instance
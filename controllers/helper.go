@@ -311,6 +311,59 @@ func updateDaemonsetNodeSelector(obj *uns.Unstructured, nodeSelector map[string]
 	return nil
 }
 
+// findNodeOvsHwOffloadConfig returns the OVS hardware offload configuration for the node,
+// the nodes are selected by the nodeSelector of the SriovNetworkPoolConfig.
+// On OpenShift nil is returned: the SriovNetworkPoolConfig controller renders a MachineConfig for the
+// MachineConfigPool named by OvsHardwareOffloadConfig.Name, which sets hw-offload each time ovs-vswitchd starts.
+// The pool is not selected by the nodeSelector, and a setting written by the config daemon would either
+// duplicate the MachineConfig or be reverted by it at the next restart of OVS.
+func findNodeOvsHwOffloadConfig(ctx context.Context, node *corev1.Node, c k8sclient.Client) (*sriovnetworkv1.OvsHardwareOffload, error) {
+	logger := log.FromContext(ctx)
+	if vars.ClusterType == constants.ClusterTypeOpenshift {
+		return nil, nil
+	}
+	npcl := &sriovnetworkv1.SriovNetworkPoolConfigList{}
+	if err := c.List(ctx, npcl); err != nil {
+		logger.Error(err, "failed to list sriovNetworkPoolConfig")
+		return nil, err
+	}
+	for _, npc := range npcl.Items {
+		hwOffloadConfig := npc.Spec.OvsHardwareOffloadConfig
+		if hwOffloadConfig.Name == "" || !npc.DeletionTimestamp.IsZero() {
+			continue
+		}
+		nodeSelector := npc.Spec.NodeSelector
+		if nodeSelector == nil {
+			nodeSelector = &metav1.LabelSelector{}
+		}
+		selector, err := metav1.LabelSelectorAsSelector(nodeSelector)
+		if err != nil {
+			logger.Error(err, "failed to create label selector from nodeSelector", "nodeSelector", nodeSelector)
+			return nil, err
+		}
+		if selector.Matches(labels.Set(node.Labels)) {
+			logger.V(2).Info("found OVS hardware offload config for the node", "pool", npc.Name)
+			return &sriovnetworkv1.OvsHardwareOffload{Enabled: true, TcPolicy: hwOffloadConfig.TcPolicy}, nil
+		}
+	}
+	return nil, nil
+}
+
+// ovsHwOffloadConfigForDeselectedNode returns the OVS hardware offload configuration to render for a node
+// that is not selected by a SriovNetworkPoolConfig with OVS hardware offload anymore.
+// The offload is disabled on the node only while the config daemon reports it enabled in the status,
+// the entry is dropped once the offload is reported disabled or not reported at all because OVS doesn't run.
+func ovsHwOffloadConfigForDeselectedNode(current *sriovnetworkv1.SriovNetworkNodeState) *sriovnetworkv1.OvsHardwareOffload {
+	if current.Spec.System.OvsHardwareOffload == nil {
+		return nil
+	}
+	status := current.Status.System.OvsHardwareOffload
+	if status == nil || !status.Enabled {
+		return nil
+	}
+	return &sriovnetworkv1.OvsHardwareOffload{Enabled: false}
+}
+
 func findNodePoolConfig(ctx context.Context, node *corev1.Node, c k8sclient.Client) (*sriovnetworkv1.SriovNetworkPoolConfig, []corev1.Node, error) {
 	logger := log.FromContext(ctx)
 	logger.Info("FindNodePoolConfig():")
@@ -326,8 +379,8 @@ func findNodePoolConfig(ctx context.Context, node *corev1.Node, c k8sclient.Clie
 	nodesInPools := map[string]interface{}{}
 
 	for _, npc := range npcl.Items {
-		// we skip hw offload objects
-		if npc.Spec.OvsHardwareOffloadConfig.Name != "" {
+		// we skip hw offload objects on OpenShift, they select the nodes of a MachineConfigPool
+		if npc.Spec.OvsHardwareOffloadConfig.Name != "" && vars.ClusterType == constants.ClusterTypeOpenshift {
 			continue
 		}
 
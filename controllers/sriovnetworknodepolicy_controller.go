@@ -58,6 +58,9 @@ type SriovNetworkNodePolicyReconciler struct {
 	client.Client
 	Scheme      *runtime.Scheme
 	FeatureGate featuregate.FeatureGate
+	// PodReader reads the pods from a cluster wide cache with the indexes of utils.SetupPodIndexes,
+	// the client of the manager is used if it's not set
+	PodReader client.Reader
 }
 
 //+kubebuilder:rbac:groups=sriovnetwork.openshift.io,resources=sriovnetworknodepolicies,verbs=get;list;watch;create;update;patch;delete
@@ -122,6 +125,12 @@ func (r *SriovNetworkNodePolicyReconciler) Reconcile(ctx context.Context, req ct
 		return reconcile.Result{}, err
 	}
 
+	// Keep the policies under deletion while their resources are used by pods
+	resourceInUse, err := r.syncResourceInUseFinalizers(ctx, policyList, nodeList)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
 	// Sort the policies with priority, higher priority ones is applied later
 	// We need to use the sort so we always get the policies in the same order
 	// That is needed so when we create the node Affinity for the sriov-device plugin
@@ -136,6 +145,11 @@ func (r *SriovNetworkNodePolicyReconciler) Reconcile(ctx context.Context, req ct
 		return reconcile.Result{}, err
 	}
 
+	if resourceInUse {
+		// check again the usage of the resources of the policies under deletion
+		return reconcile.Result{RequeueAfter: constants.ResourceInUseRequeueTime}, nil
+	}
+
 	// All was successful. Request that this be re-triggered after ResyncPeriod,
 	// so we can reconcile state again.
 	return reconcile.Result{RequeueAfter: constants.ResyncPeriod}, nil
@@ -143,6 +157,10 @@ func (r *SriovNetworkNodePolicyReconciler) Reconcile(ctx context.Context, req ct
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *SriovNetworkNodePolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.PodReader == nil {
+		r.PodReader = mgr.GetClient()
+	}
+
 	qHandler := func(q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
 		q.AddAfter(reconcile.Request{NamespacedName: types.NamespacedName{
 			Namespace: "",
@@ -210,6 +228,86 @@ func (r *SriovNetworkNodePolicyReconciler) SetupWithManager(mgr ctrl.Manager) er
 		Complete(r)
 }
 
+// syncResourceInUseFinalizers adds the finalizer to the policies and removes it from the policies under deletion
+// when no running pod uses their resource on the nodes where no other policy provides it.
+// The policies under deletion with a resource still in use are kept in the list so their VFs are not removed,
+// it returns true if there is any of them.
+func (r *SriovNetworkNodePolicyReconciler) syncResourceInUseFinalizers(ctx context.Context,
+	pl *sriovnetworkv1.SriovNetworkNodePolicyList, nl *corev1.NodeList) (bool, error) {
+	logger := log.Log.WithName("syncResourceInUseFinalizers")
+
+	remaining := []sriovnetworkv1.SriovNetworkNodePolicy{}
+	for _, policy := range pl.Items {
+		if policy.GetDeletionTimestamp().IsZero() {
+			remaining = append(remaining, policy)
+		}
+	}
+
+	resourceInUse := false
+	policies := []sriovnetworkv1.SriovNetworkNodePolicy{}
+	for i := range pl.Items {
+		policy := &pl.Items[i]
+		finalizers := policy.GetFinalizers()
+		if policy.GetDeletionTimestamp().IsZero() {
+			if !sriovnetworkv1.StringInArray(sriovnetworkv1.RESOURCEINUSEFINALIZERNAME, finalizers) {
+				// the finalizer is added with a patch to not race with the updates of the policy, a failure
+				// doesn't block the rendering of the other policies and is retried on the next reconcile
+				patch := client.MergeFrom(policy.DeepCopy())
+				policy.SetFinalizers(append(finalizers, sriovnetworkv1.RESOURCEINUSEFINALIZERNAME))
+				if err := r.Patch(ctx, policy, patch); err != nil {
+					if errors.IsInvalid(err) {
+						// the policy was stored before the CEL validation rules of the CRD were added and violates them,
+						// the API servers without validation ratcheting reject any update of the policy, the finalizer
+						// is not added so the policy can still be deleted
+						logger.Info("SriovNetworkNodePolicy violates the validation rules of the CRD, the resource in use finalizer is not added, "+
+							"fix the policy to protect its resource", "policy", policy.GetName(), "error", err.Error())
+					} else {
+						logger.Error(err, "failed to add finalizer to SriovNetworkNodePolicy, retrying on the next reconcile", "policy", policy.GetName())
+					}
+				}
+			}
+			policies = append(policies, *policy)
+			continue
+		}
+		if !sriovnetworkv1.StringInArray(sriovnetworkv1.RESOURCEINUSEFINALIZERNAME, finalizers) {
+			policies = append(policies, *policy)
+			continue
+		}
+
+		if policy.GetAnnotations()[constants.ForceDeleteAnnotation] != "true" {
+			usage, err := utils.GetResourceUsageOf(ctx, r.PodReader, policy.Spec.ResourceName)
+			if err != nil {
+				return false, fmt.Errorf("failed to get the usage of resource %s: %v", policy.Spec.ResourceName, err)
+			}
+			if pods := usage.RemovedResourcePods(policy, remaining, nl.Items); pods > 0 {
+				logger.Info("SriovNetworkNodePolicy is under deletion but its resource is still used, waiting for the pods to be removed",
+					"policy", policy.GetName(), "resource", policy.Spec.ResourceName, "pods", pods)
+				resourceInUse = true
+				policies = append(policies, *policy)
+				continue
+			}
+		}
+
+		// the finalizer is removed with a patch to not conflict with the updates of the policy,
+		// the policy is kept in the list until the removal succeeds on a next reconcile
+		logger.Info("removing finalizer from SriovNetworkNodePolicy", "policy", policy.GetName())
+		patch := client.MergeFrom(policy.DeepCopy())
+		newFinalizers, _ := sriovnetworkv1.RemoveString(sriovnetworkv1.RESOURCEINUSEFINALIZERNAME, finalizers)
+		policy.SetFinalizers(newFinalizers)
+		if err := r.Patch(ctx, policy, patch); err != nil {
+			if errors.IsInvalid(err) {
+				logger.Error(err, "failed to remove finalizer from SriovNetworkNodePolicy, the policy violates the validation rules "+
+					"of the CRD, fix the policy to complete its deletion", "policy", policy.GetName())
+			} else {
+				logger.Error(err, "failed to remove finalizer from SriovNetworkNodePolicy, retrying on the next reconcile", "policy", policy.GetName())
+			}
+			policies = append(policies, *policy)
+		}
+	}
+	pl.Items = policies
+	return resourceInUse, nil
+}
+
 func (r *SriovNetworkNodePolicyReconciler) syncDevicePluginConfigMap(ctx context.Context, dc *sriovnetworkv1.SriovOperatorConfig,
 	pl *sriovnetworkv1.SriovNetworkNodePolicyList, nl *corev1.NodeList) error {
 	logger := log.Log.WithName("syncDevicePluginConfigMap")
@@ -308,6 +406,14 @@ func (r *SriovNetworkNodePolicyReconciler) syncAllSriovNetworkNodeStates(ctx con
 		}
 		if netPoolConfig != nil {
 			ns.Spec.System.RdmaMode = netPoolConfig.Spec.RdmaMode
+			ns.Spec.System.KernelArgs = netPoolConfig.Spec.KernelArgs
+			ns.Spec.System.KernelModules = netPoolConfig.Spec.KernelModules
+			ns.Spec.System.VfioNoIommuMode = netPoolConfig.Spec.VfioNoIommuMode
+			ns.Spec.System.Hugepages = netPoolConfig.Spec.Hugepages
+		}
+		ns.Spec.System.OvsHardwareOffload, err = findNodeOvsHwOffloadConfig(ctx, &node, r.Client)
+		if err != nil {
+			logger.Error(err, "failed to get OVS hardware offload config for the current node")
 		}
 		j, _ := json.Marshal(ns)
 		logger.V(2).Info("SriovNetworkNodeState CR", "content", j)
@@ -443,6 +549,9 @@ func (r *SriovNetworkNodePolicyReconciler) syncSriovNetworkNodeState(ctx context
 		newVersion := found.DeepCopy()
 		newVersion.Spec = ns.Spec
 		newVersion.OwnerReferences = ns.OwnerReferences
+		if newVersion.Spec.System.OvsHardwareOffload == nil {
+			newVersion.Spec.System.OvsHardwareOffload = ovsHwOffloadConfigForDeselectedNode(found)
+		}
 
 		// Previous Policy Priority(ppp) records the priority of previous evaluated policy in node policy list.
 		// Since node policy list is already sorted with priority number, comparing current priority with ppp shall
@@ -571,8 +680,8 @@ func createDevicePluginResource(
 	if len(p.Spec.NicSelector.PfNames) > 0 {
 		netDeviceSelectors.PfNames = append(netDeviceSelectors.PfNames, p.Spec.NicSelector.PfNames...)
 	}
-	// vfio-pci device link type is not detectable
-	if p.Spec.DeviceType != constants.DeviceTypeVfioPci {
+	// link type of the devices bound to userspace drivers is not detectable
+	if !sriovnetworkv1.StringInArray(p.Spec.DeviceType, vars.DpdkDrivers) {
 		if p.Spec.LinkType != "" {
 			linkType := constants.LinkTypeEthernet
 			if strings.EqualFold(p.Spec.LinkType, constants.LinkTypeIB) {
@@ -585,7 +694,7 @@ func createDevicePluginResource(
 		netDeviceSelectors.RootDevices = append(netDeviceSelectors.RootDevices, p.Spec.NicSelector.RootDevices...)
 	}
 	// Removed driver constraint for "netdevice" DeviceType
-	if p.Spec.DeviceType == constants.DeviceTypeVfioPci {
+	if sriovnetworkv1.StringInArray(p.Spec.DeviceType, vars.DpdkDrivers) {
 		netDeviceSelectors.Drivers = append(netDeviceSelectors.Drivers, p.Spec.DeviceType)
 	}
 	// Enable the selection of devices using NetFilter
@@ -595,7 +704,7 @@ func createDevicePluginResource(
 			return nil, fmt.Errorf("node state %s doesn't contain interfaces data", nodeState.Name)
 		}
 		for _, intf := range nodeState.Status.Interfaces {
-			if sriovnetworkv1.NetFilterMatch(p.Spec.NicSelector.NetFilter, intf.NetFilter) {
+			if intf.MatchNetFilter(p.Spec.NicSelector.NetFilter) {
 				// Found a match add the Interfaces PciAddress
 				netDeviceSelectors.PciAddresses = sriovnetworkv1.UniqueAppend(netDeviceSelectors.PciAddresses, intf.PciAddress)
 			}
@@ -642,8 +751,8 @@ func updateDevicePluginResource(
 	if len(p.Spec.NicSelector.PfNames) > 0 {
 		netDeviceSelectors.PfNames = sriovnetworkv1.UniqueAppend(netDeviceSelectors.PfNames, p.Spec.NicSelector.PfNames...)
 	}
-	// vfio-pci device link type is not detectable
-	if p.Spec.DeviceType != constants.DeviceTypeVfioPci {
+	// link type of the devices bound to userspace drivers is not detectable
+	if !sriovnetworkv1.StringInArray(p.Spec.DeviceType, vars.DpdkDrivers) {
 		if p.Spec.LinkType != "" {
 			linkType := constants.LinkTypeEthernet
 			if strings.EqualFold(p.Spec.LinkType, constants.LinkTypeIB) {
@@ -658,14 +767,14 @@ func updateDevicePluginResource(
 		netDeviceSelectors.RootDevices = sriovnetworkv1.UniqueAppend(netDeviceSelectors.RootDevices, p.Spec.NicSelector.RootDevices...)
 	}
 	// Removed driver constraint for "netdevice" DeviceType
-	if p.Spec.DeviceType == constants.DeviceTypeVfioPci {
+	if sriovnetworkv1.StringInArray(p.Spec.DeviceType, vars.DpdkDrivers) {
 		netDeviceSelectors.Drivers = sriovnetworkv1.UniqueAppend(netDeviceSelectors.Drivers, p.Spec.DeviceType)
 	}
 	// Enable the selection of devices using NetFilter
 	if p.Spec.NicSelector.NetFilter != "" {
 		// Loop through interfaces status to find a match for NetworkID or NetworkTag
 		for _, intf := range nodeState.Status.Interfaces {
-			if sriovnetworkv1.NetFilterMatch(p.Spec.NicSelector.NetFilter, intf.NetFilter) {
+			if intf.MatchNetFilter(p.Spec.NicSelector.NetFilter) {
 				// Found a match add the Interfaces PciAddress
 				netDeviceSelectors.PciAddresses = sriovnetworkv1.UniqueAppend(netDeviceSelectors.PciAddresses, intf.PciAddress)
 			}
@@ -15,16 +15,19 @@ import (
 	dptypes "github.com/k8snetworkplumbingwg/sriov-network-device-plugin/pkg/types"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/featuregate"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
 )
 
@@ -84,6 +87,28 @@ func TestRenderDevicePluginConfigData(t *testing.T) {
 				},
 			},
 		},
+		{
+			tname: "testUioPciGenericDriver",
+			policy: sriovnetworkv1.SriovNetworkNodePolicy{
+				Spec: sriovnetworkv1.SriovNetworkNodePolicySpec{
+					ResourceName: "resourceName",
+					DeviceType:   consts.DeviceTypeUioPciGeneric,
+					LinkType:     consts.LinkTypeETH,
+				},
+			},
+			expResource: dptypes.ResourceConfList{
+				ResourceList: []dptypes.ResourceConfig{
+					{
+						ResourceName: "resourceName",
+						Selectors: mustMarshallSelector(t, &dptypes.NetDeviceSelectors{
+							DeviceSelectors: dptypes.DeviceSelectors{
+								Drivers: []string{consts.DeviceTypeUioPciGeneric},
+							},
+						}),
+					},
+				},
+			},
+		},
 		{
 			tname: "testExcludeTopology",
 			policy: sriovnetworkv1.SriovNetworkNodePolicy{
@@ -133,6 +158,181 @@ func TestRenderDevicePluginConfigData(t *testing.T) {
 	}
 }
 
+func TestSyncResourceInUseFinalizers(t *testing.T) {
+	g := NewWithT(t)
+	vars.ResourcePrefix = "openshift.io"
+
+	node := corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1", Labels: map[string]string{"sriov": "true"}}}
+	policy := &sriovnetworkv1.SriovNetworkNodePolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "p1",
+			Namespace:         vars.Namespace,
+			Finalizers:        []string{sriovnetworkv1.RESOURCEINUSEFINALIZERNAME},
+			DeletionTimestamp: &metav1.Time{Time: time.Now()},
+		},
+		Spec: sriovnetworkv1.SriovNetworkNodePolicySpec{
+			ResourceName: "p1",
+			NodeSelector: map[string]string{"sriov": "true"},
+		},
+	}
+	resources := corev1.ResourceList{"openshift.io/p1": resource.MustParse("1")}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default"},
+		Spec: corev1.PodSpec{
+			NodeName:   node.Name,
+			Containers: []corev1.Container{{Name: "test", Resources: corev1.ResourceRequirements{Requests: resources}}},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(sriovnetworkv1.AddToScheme(scheme))
+	utilruntime.Must(corev1.AddToScheme(scheme))
+	builder := fake.NewClientBuilder().WithScheme(scheme).WithObjects(policy, pod)
+	for name, indexerFunc := range utils.PodIndexers() {
+		builder = builder.WithIndex(&corev1.Pod{}, name, indexerFunc)
+	}
+	c := builder.Build()
+	r := &SriovNetworkNodePolicyReconciler{Client: c, PodReader: c}
+
+	// the policy is kept while its resource is used
+	pl := &sriovnetworkv1.SriovNetworkNodePolicyList{}
+	g.Expect(c.List(context.TODO(), pl)).To(Succeed())
+	inUse, err := r.syncResourceInUseFinalizers(context.TODO(), pl, &corev1.NodeList{Items: []corev1.Node{node}})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(inUse).To(BeTrue())
+	g.Expect(pl.Items).To(HaveLen(1))
+
+	// the finalizer is removed with a patch once the pod is deleted, the stale resource version doesn't conflict
+	g.Expect(c.Delete(context.TODO(), pod)).To(Succeed())
+	stale := pl.Items[0].DeepCopy()
+	updated := stale.DeepCopy()
+	updated.Annotations = map[string]string{"updated": "true"}
+	g.Expect(c.Update(context.TODO(), updated)).To(Succeed())
+	pl.Items = []sriovnetworkv1.SriovNetworkNodePolicy{*stale}
+	inUse, err = r.syncResourceInUseFinalizers(context.TODO(), pl, &corev1.NodeList{Items: []corev1.Node{node}})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(inUse).To(BeFalse())
+	g.Expect(pl.Items).To(BeEmpty())
+	err = c.Get(context.TODO(), k8sclient.ObjectKeyFromObject(policy), &sriovnetworkv1.SriovNetworkNodePolicy{})
+	g.Expect(errors.IsNotFound(err)).To(BeTrue())
+}
+
+func TestSyncResourceInUseFinalizersWithInvalidPolicy(t *testing.T) {
+	g := NewWithT(t)
+
+	policy := &sriovnetworkv1.SriovNetworkNodePolicy{ObjectMeta: metav1.ObjectMeta{Name: "p1", Namespace: vars.Namespace}}
+	scheme := runtime.NewScheme()
+	utilruntime.Must(sriovnetworkv1.AddToScheme(scheme))
+	// the API server rejects the updates of a stored policy which violates the validation rules of the CRD
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(policy).WithInterceptorFuncs(interceptor.Funcs{
+		Patch: func(ctx context.Context, client k8sclient.WithWatch, obj k8sclient.Object, patch k8sclient.Patch, opts ...k8sclient.PatchOption) error {
+			return errors.NewInvalid(sriovnetworkv1.GroupVersion.WithKind("SriovNetworkNodePolicy").GroupKind(), obj.GetName(), nil)
+		},
+	}).Build()
+	r := &SriovNetworkNodePolicyReconciler{Client: c, PodReader: c}
+
+	pl := &sriovnetworkv1.SriovNetworkNodePolicyList{}
+	g.Expect(c.List(context.TODO(), pl)).To(Succeed())
+	inUse, err := r.syncResourceInUseFinalizers(context.TODO(), pl, &corev1.NodeList{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(inUse).To(BeFalse())
+	g.Expect(pl.Items).To(HaveLen(1))
+	g.Expect(c.Get(context.TODO(), k8sclient.ObjectKeyFromObject(policy), policy)).To(Succeed())
+	g.Expect(policy.GetFinalizers()).To(BeEmpty())
+}
+
+func TestOvsHwOffloadConfigForDeselectedNode(t *testing.T) {
+	table := []struct {
+		tname    string
+		spec     *sriovnetworkv1.OvsHardwareOffload
+		status   *sriovnetworkv1.OvsHardwareOffload
+		expected *sriovnetworkv1.OvsHardwareOffload
+	}{
+		{
+			tname: "never configured",
+		},
+		{
+			tname:    "enabled on the node",
+			spec:     &sriovnetworkv1.OvsHardwareOffload{Enabled: true},
+			status:   &sriovnetworkv1.OvsHardwareOffload{Enabled: true},
+			expected: &sriovnetworkv1.OvsHardwareOffload{Enabled: false},
+		},
+		{
+			tname:    "disabling not applied yet",
+			spec:     &sriovnetworkv1.OvsHardwareOffload{Enabled: false},
+			status:   &sriovnetworkv1.OvsHardwareOffload{Enabled: true},
+			expected: &sriovnetworkv1.OvsHardwareOffload{Enabled: false},
+		},
+		{
+			tname: "status not reported",
+			spec:  &sriovnetworkv1.OvsHardwareOffload{Enabled: false},
+		},
+		{
+			tname: "status not reported after deselection",
+			spec:  &sriovnetworkv1.OvsHardwareOffload{Enabled: true},
+		},
+		{
+			tname:  "disabled on the node",
+			spec:   &sriovnetworkv1.OvsHardwareOffload{Enabled: false},
+			status: &sriovnetworkv1.OvsHardwareOffload{Enabled: false},
+		},
+	}
+	for _, tc := range table {
+		t.Run(tc.tname, func(t *testing.T) {
+			nodeState := &sriovnetworkv1.SriovNetworkNodeState{}
+			nodeState.Spec.System.OvsHardwareOffload = tc.spec
+			nodeState.Status.System.OvsHardwareOffload = tc.status
+			if diff := cmp.Diff(tc.expected, ovsHwOffloadConfigForDeselectedNode(nodeState)); diff != "" {
+				t.Errorf("unexpected OVS hardware offload config (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFindNodeOvsHwOffloadConfig(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(sriovnetworkv1.AddToScheme(scheme))
+	poolConfig := &sriovnetworkv1.SriovNetworkPoolConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "hw-offload", Namespace: "default"},
+		Spec: sriovnetworkv1.SriovNetworkPoolConfigSpec{
+			NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"hw-offload": "true"}},
+			OvsHardwareOffloadConfig: sriovnetworkv1.OvsHardwareOffloadConfig{
+				Name: "hw-offload", TcPolicy: "skip_sw"},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(poolConfig).Build()
+	selected := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "selected", Labels: map[string]string{"hw-offload": "true"}}}
+	other := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "other"}}
+
+	defer func(clusterType string) { vars.ClusterType = clusterType }(vars.ClusterType)
+	vars.ClusterType = consts.ClusterTypeKubernetes
+
+	conf, err := findNodeOvsHwOffloadConfig(context.Background(), selected, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(&sriovnetworkv1.OvsHardwareOffload{Enabled: true, TcPolicy: "skip_sw"}, conf); diff != "" {
+		t.Errorf("unexpected OVS hardware offload config (-want +got):\n%s", diff)
+	}
+	conf, err = findNodeOvsHwOffloadConfig(context.Background(), other, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conf != nil {
+		t.Errorf("expected no OVS hardware offload config for a node not selected by the pool, got %v", conf)
+	}
+
+	// on OpenShift the OVS hardware offload is configured with MachineConfigs
+	vars.ClusterType = consts.ClusterTypeOpenshift
+	conf, err = findNodeOvsHwOffloadConfig(context.Background(), selected, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conf != nil {
+		t.Errorf("expected no OVS hardware offload config on OpenShift, got %v", conf)
+	}
+}
+
 var _ = Describe("SriovnetworkNodePolicy controller", Ordered, func() {
 	var cancel context.CancelFunc
 	var ctx context.Context
@@ -186,6 +386,12 @@ var _ = Describe("SriovnetworkNodePolicy controller", Ordered, func() {
 
 		err = k8sClient.DeleteAllOf(context.Background(), &sriovnetworkv1.SriovNetworkNodePolicy{}, k8sclient.InNamespace(vars.Namespace), k8sclient.GracePeriodSeconds(0))
 		Expect(err).ToNot(HaveOccurred())
+		// wait for the controller to remove the resource in use finalizer
+		Eventually(func(g Gomega) {
+			policyList := &sriovnetworkv1.SriovNetworkNodePolicyList{}
+			g.Expect(k8sClient.List(context.Background(), policyList, k8sclient.InNamespace(vars.Namespace))).To(Succeed())
+			g.Expect(policyList.Items).To(BeEmpty())
+		}, time.Minute, time.Second).Should(Succeed())
 
 		err = k8sClient.DeleteAllOf(context.Background(), &sriovnetworkv1.SriovNetworkNodeState{}, k8sclient.InNamespace(vars.Namespace), k8sclient.GracePeriodSeconds(0))
 		Expect(err).ToNot(HaveOccurred())
@@ -421,6 +627,65 @@ var _ = Describe("SriovnetworkNodePolicy controller", Ordered, func() {
 		})
 	})
 
+	Context("resource in use", func() {
+		It("should keep the policy under deletion while its resource is used by a pod", func() {
+			DeferCleanup(func(prefix string) { vars.ResourcePrefix = prefix }, vars.ResourcePrefix)
+			vars.ResourcePrefix = "openshift.io"
+
+			node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+				Name: "node0",
+				Labels: map[string]string{"kubernetes.io/os": "linux",
+					"node-role.kubernetes.io/worker": ""},
+			}}
+			Expect(k8sClient.Create(ctx, node)).To(Succeed())
+
+			somePolicy := &sriovnetworkv1.SriovNetworkNodePolicy{}
+			somePolicy.SetNamespace(testNamespace)
+			somePolicy.SetName("some-policy")
+			somePolicy.Spec = sriovnetworkv1.SriovNetworkNodePolicySpec{
+				NumVfs:       5,
+				ResourceName: "some_resource",
+				NodeSelector: map[string]string{"node-role.kubernetes.io/worker": ""},
+				NicSelector:  sriovnetworkv1.SriovNetworkNicSelector{Vendor: "8086"},
+				Priority:     20,
+			}
+			Expect(k8sClient.Create(ctx, somePolicy)).To(Succeed())
+
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, k8sclient.ObjectKeyFromObject(somePolicy), somePolicy)).To(Succeed())
+				g.Expect(somePolicy.GetFinalizers()).To(ContainElement(sriovnetworkv1.RESOURCEINUSEFINALIZERNAME))
+			}, time.Minute, time.Second).Should(Succeed())
+
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-pod", Namespace: "default"},
+				Spec: corev1.PodSpec{
+					NodeName: node.Name,
+					Containers: []corev1.Container{{
+						Name:  "test",
+						Image: "test",
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{"openshift.io/some_resource": resource.MustParse("1")},
+							Limits:   corev1.ResourceList{"openshift.io/some_resource": resource.MustParse("1")},
+						},
+					}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, pod)).To(Succeed())
+
+			Expect(k8sClient.Delete(ctx, somePolicy)).To(Succeed())
+			Consistently(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, k8sclient.ObjectKeyFromObject(somePolicy), somePolicy)).To(Succeed())
+				g.Expect(somePolicy.GetDeletionTimestamp().IsZero()).To(BeFalse())
+			}, 5*time.Second, time.Second).Should(Succeed())
+
+			Expect(k8sClient.Delete(ctx, pod, k8sclient.GracePeriodSeconds(0))).To(Succeed())
+			Eventually(func(g Gomega) {
+				err := k8sClient.Get(ctx, k8sclient.ObjectKeyFromObject(somePolicy), somePolicy)
+				g.Expect(errors.IsNotFound(err)).To(BeTrue())
+			}, time.Minute, time.Second).Should(Succeed())
+		})
+	})
+
 	Context("RdmaMode", func() {
 		BeforeEach(func() {
 			Expect(
@@ -468,7 +733,9 @@ var _ = Describe("SriovnetworkNodePolicy controller", Ordered, func() {
 						"test": "",
 					},
 				},
-				RdmaMode: "exclusive",
+				RdmaMode:      "exclusive",
+				KernelArgs:    []string{"hugepages=16"},
+				KernelModules: []sriovnetworkv1.KernelModule{{Name: "mlx5_core", Params: []string{"prof_sel=2"}}},
 			}
 			Expect(k8sClient.Create(ctx, poolConfig)).To(Succeed())
 
@@ -476,6 +743,8 @@ var _ = Describe("SriovnetworkNodePolicy controller", Ordered, func() {
 				err := k8sClient.Get(context.Background(), k8sclient.ObjectKey{Name: node.Name, Namespace: testNamespace}, nodeState)
 				g.Expect(err).ToNot(HaveOccurred())
 				g.Expect(nodeState.Spec.System.RdmaMode).To(Equal("exclusive"))
+				g.Expect(nodeState.Spec.System.KernelArgs).To(Equal([]string{"hugepages=16"}))
+				g.Expect(nodeState.Spec.System.KernelModules).To(Equal(poolConfig.Spec.KernelModules))
 			}).WithPolling(time.Second).WithTimeout(time.Minute).Should(Succeed())
 
 		})
@@ -543,3 +812,112 @@ var _ = Describe("SriovNetworkNodePolicyReconciler", Ordered, func() {
 		})
 	})
 })
+
+var _ = Describe("SriovNetworkNodePolicy CRD validation", func() {
+	newPolicySpec := func(mutate func(*sriovnetworkv1.SriovNetworkNodePolicySpec)) sriovnetworkv1.SriovNetworkNodePolicySpec {
+		spec := sriovnetworkv1.SriovNetworkNodePolicySpec{
+			ResourceName: "p0",
+			NodeSelector: map[string]string{"feature.node.kubernetes.io/network-sriov.capable": "true"},
+			NumVfs:       8,
+			DeviceType:   consts.DeviceTypeNetDevice,
+			NicSelector:  sriovnetworkv1.SriovNetworkNicSelector{PfNames: []string{"ens803f1#0-3"}},
+		}
+		mutate(&spec)
+		return spec
+	}
+
+	DescribeTable("should validate the policy with the CEL rules",
+		func(spec sriovnetworkv1.SriovNetworkNodePolicySpec, expectedErr string) {
+			policy := &sriovnetworkv1.SriovNetworkNodePolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "cel-policy", Namespace: testNamespace},
+				Spec:       spec,
+			}
+			err := k8sClient.Create(context.Background(), policy, k8sclient.DryRunAll)
+			if expectedErr == "" {
+				Expect(err).ToNot(HaveOccurred())
+				return
+			}
+			Expect(err).To(HaveOccurred())
+			Expect(errors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring(expectedErr))
+		},
+		Entry("valid policy", newPolicySpec(func(s *sriovnetworkv1.SriovNetworkNodePolicySpec) {}), ""),
+		Entry("invalid resource name", newPolicySpec(func(s *sriovnetworkv1.SriovNetworkNodePolicySpec) {
+			s.ResourceName = "p-0"
+		}), "spec.resourceName"),
+		Entry("empty nicSelector", newPolicySpec(func(s *sriovnetworkv1.SriovNetworkNodePolicySpec) {
+			s.NicSelector = sriovnetworkv1.SriovNetworkNicSelector{}
+		}), "at least one of these parameters (vendor, deviceID, pfNames, rootDevices or netFilter) has to be defined in nicSelector"),
+		Entry("invalid VF range syntax", newPolicySpec(func(s *sriovnetworkv1.SriovNetworkNodePolicySpec) {
+			s.NicSelector.PfNames = []string{"ens803f1#0_3"}
+		}), "spec.nicSelector.pfNames[0]"),
+		Entry("VF range end smaller than start", newPolicySpec(func(s *sriovnetworkv1.SriovNetworkNodePolicySpec) {
+			s.NicSelector.PfNames = []string{"ens803f1#3-0"}
+		}), "the end of the VF index range in 'nicSelector.pfNames' shall not be smaller than the start"),
+		Entry("VF range end exceeding numVfs", newPolicySpec(func(s *sriovnetworkv1.SriovNetworkNodePolicySpec) {
+			s.NicSelector.PfNames = []string{"ens803f1#0-8"}
+		}), "the end of the VF index range in 'nicSelector.pfNames' exceeds the maximum VF index"),
+		Entry("isRdma with vfio-pci", newPolicySpec(func(s *sriovnetworkv1.SriovNetworkNodePolicySpec) {
+			s.DeviceType = consts.DeviceTypeVfioPci
+			s.IsRdma = true
+		}), "'isRdma: true' can be used only with 'deviceType: netdevice'"),
+		Entry("isRdma with uio_pci_generic", newPolicySpec(func(s *sriovnetworkv1.SriovNetworkNodePolicySpec) {
+			s.DeviceType = consts.DeviceTypeUioPciGeneric
+			s.IsRdma = true
+		}), "'isRdma: true' can be used only with 'deviceType: netdevice'"),
+		Entry("switchdev with infiniband link", newPolicySpec(func(s *sriovnetworkv1.SriovNetworkNodePolicySpec) {
+			s.LinkType = "ib"
+			s.EswitchMode = sriovnetworkv1.ESwithModeSwitchDev
+		}), "'eSwitchMode: switchdev' can be used only with ethernet links"),
+		Entry("vdpa with vfio-pci", newPolicySpec(func(s *sriovnetworkv1.SriovNetworkNodePolicySpec) {
+			s.DeviceType = consts.DeviceTypeVfioPci
+			s.VdpaType = consts.VdpaTypeVirtio
+			s.EswitchMode = sriovnetworkv1.ESwithModeSwitchDev
+		}), "'vdpaType' can be used only with 'deviceType: netdevice'"),
+		Entry("vdpa without switchdev", newPolicySpec(func(s *sriovnetworkv1.SriovNetworkNodePolicySpec) {
+			s.VdpaType = consts.VdpaTypeVhost
+		}), "vdpa requires the device to be configured in switchdev mode"),
+		Entry("bridge without switchdev", newPolicySpec(func(s *sriovnetworkv1.SriovNetworkNodePolicySpec) {
+			s.Bridge = sriovnetworkv1.Bridge{OVS: &sriovnetworkv1.OVSConfig{}}
+		}), "software bridge management requires the device to be configured in switchdev mode"),
+		Entry("bridge with externally managed device", newPolicySpec(func(s *sriovnetworkv1.SriovNetworkNodePolicySpec) {
+			s.Bridge = sriovnetworkv1.Bridge{OVS: &sriovnetworkv1.OVSConfig{}}
+			s.EswitchMode = sriovnetworkv1.ESwithModeSwitchDev
+			s.ExternallyManaged = true
+		}), "software bridge management can't be used when the device externally managed"),
+		Entry("OVS port bond without bridge name", newPolicySpec(func(s *sriovnetworkv1.SriovNetworkNodePolicySpec) {
+			s.Bridge = sriovnetworkv1.Bridge{OVS: &sriovnetworkv1.OVSConfig{PortBond: &sriovnetworkv1.OVSPortBondConfig{Name: "bond-shared"}}}
+			s.EswitchMode = sriovnetworkv1.ESwithModeSwitchDev
+		}), "'bridge.ovs.portBond' can be used only with 'bridge.ovs.bridgeName'"),
+		Entry("OVS bond with bridge name", newPolicySpec(func(s *sriovnetworkv1.SriovNetworkNodePolicySpec) {
+			s.Bridge = sriovnetworkv1.Bridge{OVS: &sriovnetworkv1.OVSConfig{BridgeName: "br-shared", Bond: &sriovnetworkv1.OVSBondConfig{Name: "bond0"}}}
+			s.EswitchMode = sriovnetworkv1.ESwithModeSwitchDev
+		}), "'bridge.ovs.bond' can't be used with 'bridge.ovs.bridgeName'"),
+		Entry("representor trunks with access VLAN mode", newPolicySpec(func(s *sriovnetworkv1.SriovNetworkNodePolicySpec) {
+			s.Bridge = sriovnetworkv1.Bridge{OVS: &sriovnetworkv1.OVSConfig{
+				Representors: &sriovnetworkv1.OVSRepresentorPortConfig{Trunks: []int{100}, VlanMode: "access"}}}
+			s.EswitchMode = sriovnetworkv1.ESwithModeSwitchDev
+		}), "'bridge.ovs.representors.trunks' can't be used with the 'access' VLAN mode"),
+		Entry("OVS and Linux bridges together", newPolicySpec(func(s *sriovnetworkv1.SriovNetworkNodePolicySpec) {
+			s.Bridge = sriovnetworkv1.Bridge{OVS: &sriovnetworkv1.OVSConfig{}, Linux: &sriovnetworkv1.LinuxBridgeConfig{}}
+			s.EswitchMode = sriovnetworkv1.ESwithModeSwitchDev
+		}), "'bridge.ovs' and 'bridge.linux' can't be used together"),
+		Entry("Linux bridge uplink VLANs without VLAN filtering", newPolicySpec(func(s *sriovnetworkv1.SriovNetworkNodePolicySpec) {
+			s.Bridge = sriovnetworkv1.Bridge{Linux: &sriovnetworkv1.LinuxBridgeConfig{Uplink: sriovnetworkv1.LinuxUplinkConfig{Vlans: []int{100}}}}
+			s.EswitchMode = sriovnetworkv1.ESwithModeSwitchDev
+		}), "'bridge.linux.uplink.vlans' requires 'bridge.linux.bridge.vlanFiltering'"),
+		Entry("valid VF name template", newPolicySpec(func(s *sriovnetworkv1.SriovNetworkNodePolicySpec) {
+			s.VfNameTemplate = "{pfName}v{vfID}"
+		}), ""),
+		Entry("VF name template without VF index", newPolicySpec(func(s *sriovnetworkv1.SriovNetworkNodePolicySpec) {
+			s.VfNameTemplate = "{pfName}v"
+		}), "'vfNameTemplate' must contain the {vfID} placeholder"),
+		Entry("VF name template with unknown placeholder", newPolicySpec(func(s *sriovnetworkv1.SriovNetworkNodePolicySpec) {
+			s.VfNameTemplate = "{pfName}v{vfID}{vfId}"
+		}), "'vfNameTemplate' contains an unknown placeholder"),
+		Entry("VF name template with switchdev", newPolicySpec(func(s *sriovnetworkv1.SriovNetworkNodePolicySpec) {
+			s.VfNameTemplate = "{pfName}v{vfID}"
+			s.EswitchMode = sriovnetworkv1.ESwithModeSwitchDev
+		}), "'vfNameTemplate' can be used only with 'eSwitchMode: legacy'"),
+	)
+})
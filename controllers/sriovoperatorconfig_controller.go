@@ -197,12 +197,16 @@ func (r *SriovOperatorConfigReconciler) syncConfigDaemonSet(ctx context.Context,
 	data.Data["ClusterType"] = vars.ClusterType
 	data.Data["DevMode"] = os.Getenv("DEV_MODE")
 	data.Data["ImagePullSecrets"] = GetImagePullSecrets()
-	if dc.Spec.ConfigurationMode == sriovnetworkv1.SystemdConfigurationMode {
+	if dc.Spec.ConfigurationMode == sriovnetworkv1.SystemdConfigurationMode ||
+		dc.Spec.ConfigurationMode == sriovnetworkv1.HybridConfigurationMode {
 		data.Data["UsedSystemdMode"] = true
 	} else {
 		data.Data["UsedSystemdMode"] = false
 	}
+	data.Data["UsedHybridMode"] = dc.Spec.ConfigurationMode == sriovnetworkv1.HybridConfigurationMode
 	data.Data["ParallelNicConfig"] = r.FeatureGate.IsEnabled(consts.ParallelNicConfigFeatureGate)
+	data.Data["LiveVfResizeDrivers"] = os.Getenv("LIVE_VF_RESIZE_DRIVERS")
+	data.Data["GenericVirtualProviders"] = os.Getenv("GENERIC_VIRTUAL_PROVIDERS")
 	data.Data["ManageSoftwareBridges"] = r.FeatureGate.IsEnabled(consts.ManageSoftwareBridgesFeatureGate)
 
 	envCniBinPath := os.Getenv("SRIOV_CNI_BIN_PATH")
@@ -304,6 +308,7 @@ func (r *SriovOperatorConfigReconciler) syncWebhookObjs(ctx context.Context, dc
 		data.Data["ReleaseVersion"] = os.Getenv("RELEASEVERSION")
 		data.Data["ClusterType"] = vars.ClusterType
 		data.Data["DevMode"] = os.Getenv("DEV_MODE")
+		data.Data["GenericVirtualProviders"] = os.Getenv("GENERIC_VIRTUAL_PROVIDERS")
 		data.Data["ImagePullSecrets"] = GetImagePullSecrets()
 		data.Data["CertManagerEnabled"] = strings.ToLower(os.Getenv("ADMISSION_CONTROLLERS_CERTIFICATES_CERT_MANAGER_ENABLED")) == trueString
 		data.Data["OperatorWebhookSecretName"] = os.Getenv("ADMISSION_CONTROLLERS_CERTIFICATES_OPERATOR_SECRET_NAME")
@@ -409,7 +414,8 @@ func (r *SriovOperatorConfigReconciler) syncK8sResource(ctx context.Context, cr
 func (r *SriovOperatorConfigReconciler) syncOpenShiftSystemdService(ctx context.Context, cr *sriovnetworkv1.SriovOperatorConfig) error {
 	logger := log.Log.WithName("syncSystemdService")
 
-	if cr.Spec.ConfigurationMode != sriovnetworkv1.SystemdConfigurationMode {
+	if cr.Spec.ConfigurationMode != sriovnetworkv1.SystemdConfigurationMode &&
+		cr.Spec.ConfigurationMode != sriovnetworkv1.HybridConfigurationMode {
 		obj := &machinev1.MachineConfig{}
 		// use uncached api reader to get machineconfig to reduce memory footprint
 		err := r.UncachedAPIReader.Get(ctx, types.NamespacedName{Name: consts.SystemdServiceOcpMachineConfigName}, obj)
@@ -47,6 +47,7 @@ import (
 	//+kubebuilder:scaffold:imports
 	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	snolog "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/log"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/test/util"
 )
@@ -85,6 +86,10 @@ func setupK8sManagerForTest() (manager.Manager, error) {
 		return []string{o.(*sriovnetworkv1.OVSNetwork).Spec.NetworkNamespace}
 	})
 
+	if err := utils.SetupPodIndexes(context.Background(), k8sManager.GetCache()); err != nil {
+		return nil, err
+	}
+
 	return k8sManager, nil
 }
 
@@ -142,6 +142,12 @@ func main() {
 		os.Exit(1)
 	}
 
+	// the pods are read from the cache of the global manager by the network and the policy controllers
+	if err := utils.SetupPodIndexes(context.Background(), mgrGlobal.GetCache()); err != nil {
+		setupLog.Error(err, "unable to create pod index fields for cache")
+		os.Exit(1)
+	}
+
 	if err := initNicIDMap(); err != nil {
 		setupLog.Error(err, "unable to init NicIdMap")
 		os.Exit(1)
@@ -184,6 +190,7 @@ func main() {
 		Client:      mgr.GetClient(),
 		Scheme:      mgr.GetScheme(),
 		FeatureGate: featureGate,
+		PodReader:   mgrGlobal.GetClient(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "SriovNetworkNodePolicy")
 		os.Exit(1)
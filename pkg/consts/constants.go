@@ -17,6 +17,12 @@ const (
 	ResyncPeriod               = 5 * time.Minute
 	DaemonRequeueTime          = 30 * time.Second
 	DrainControllerRequeueTime = 5 * time.Second
+	// ResourceInUseRequeueTime is the time to wait before checking again the usage of a resource under deletion
+	ResourceInUseRequeueTime = 30 * time.Second
+	// DaemonEventsRequeueTime is used instead of DaemonRequeueTime when the host events trigger the reconcile
+	DaemonEventsRequeueTime = 5 * time.Minute
+	// HostEventsBatchTime is the time to wait for after a host event to batch the following ones in one reconcile
+	HostEventsBatchTime = 2 * time.Second
 
 	DefaultConfigName                  = "default"
 	ConfigDaemonPath                   = "./bindata/manifests/daemon"
@@ -51,10 +57,12 @@ const (
 
 	UninitializedNodeGUID = "0000:0000:0000:0000"
 
-	DeviceTypeVfioPci   = "vfio-pci"
-	DeviceTypeNetDevice = "netdevice"
-	VdpaTypeVirtio      = "virtio"
-	VdpaTypeVhost       = "vhost"
+	DeviceTypeVfioPci       = "vfio-pci"
+	DeviceTypeNetDevice     = "netdevice"
+	DeviceTypeUioPciGeneric = "uio_pci_generic"
+	DeviceTypeIgbUio        = "igb_uio"
+	VdpaTypeVirtio          = "virtio"
+	VdpaTypeVhost           = "vhost"
 
 	RdmaSubsystemModeShared    = "shared"
 	RdmaSubsystemModeExclusive = "exclusive"
@@ -67,11 +75,44 @@ const (
 	SriovSwitchDevConfPath     = SriovConfBasePath + "/sriov_config.json"
 	SriovHostSwitchDevConfPath = Host + SriovSwitchDevConfPath
 	ManagedOVSBridgesPath      = SriovConfBasePath + "/managed-ovs-bridges.json"
+	KernelArgsStatePath        = SriovConfBasePath + "/kernel-args-state.json"
+	ManagedKernelConfigPath    = SriovConfBasePath + "/managed-kernel-config.json"
 
 	MachineConfigPoolPausedAnnotation       = "sriovnetwork.openshift.io/state"
 	MachineConfigPoolPausedAnnotationIdle   = "Idle"
 	MachineConfigPoolPausedAnnotationPaused = "Paused"
 
+	// NodeDrainAdvisoryAnnotation is set on the nodes of a Hypershift hosted cluster while the operator drains them.
+	// It's advisory only, nothing in Hypershift honors it, it lets the cluster administrator or an external
+	// automation see that the node should not be upgraded yet.
+	NodeDrainAdvisoryAnnotation         = "sriovnetwork.openshift.io/drain-advisory"
+	NodeDrainAdvisoryAnnotationIdle     = "Idle"
+	NodeDrainAdvisoryAnnotationDraining = "Draining"
+
+	// NodeLifecycleLeasePrefix is the prefix of the coordination.k8s.io Lease taken by the operator
+	// in its namespace while it drains and reboots the node, the name of the node is appended
+	NodeLifecycleLeasePrefix = "node-lifecycle-"
+	// NodeLifecycleLeaseHolder is the holder identity of the node lifecycle lease taken by the operator
+	NodeLifecycleLeaseHolder = "sriov-network-operator"
+
+	// kured lock annotation on the kured DaemonSet and the node annotation set by kured during the reboot
+	KuredNodeLockAnnotation         = "weave.works/kured-node-lock"
+	KuredRebootInProgressAnnotation = "weave.works/kured-reboot-in-progress"
+	KuredDefaultNamespace           = "kube-system"
+	KuredDefaultDaemonSet           = "kured"
+
+	// Cluster API annotations of the node pointing to its Machine
+	ClusterAPIMachineAnnotation          = "cluster.x-k8s.io/machine"
+	ClusterAPIClusterNamespaceAnnotation = "cluster.x-k8s.io/cluster-namespace"
+	// ClusterAPIPreDrainHookAnnotation is set on the Machine while the operator drains the node,
+	// Cluster API doesn't drain the Machine until the hook is removed
+	ClusterAPIPreDrainHookAnnotation = "pre-drain.delete.hook.machine.cluster.x-k8s.io/sriov-network-operator"
+
+	// ForceDeleteAnnotation allows to delete a SriovNetworkNodePolicy or a network object while pods still use it
+	ForceDeleteAnnotation = "sriovnetwork.openshift.io/force-delete"
+	// PodNetworksAnnotation contains the networks the pod is attached to
+	PodNetworksAnnotation = "k8s.v1.cni.cncf.io/networks"
+
 	SriovDevicePluginLabel         = "sriovnetwork.openshift.io/device-plugin"
 	SriovDevicePluginLabelEnabled  = "Enabled"
 	SriovDevicePluginLabelDisabled = "Disabled"
@@ -84,6 +125,10 @@ const (
 	RebootRequired                  = "Reboot_Required"
 	Draining                        = "Draining"
 	DrainComplete                   = "DrainComplete"
+	// NodeStateDrainResourcesAnnotation contains the comma-separated list of the resources exposed from the PFs
+	// that are reconfigured, the drain controller evicts only the pods using them.
+	// All the pods using SR-IOV devices are evicted if the annotation is empty.
+	NodeStateDrainResourcesAnnotation = "sriovnetwork.openshift.io/drain-resources"
 
 	SyncStatusSucceeded  = "Succeeded"
 	SyncStatusFailed     = "Failed"
@@ -114,6 +159,11 @@ const (
 	SysBusPciDriversProbe = SysBus + "/pci/drivers_probe"
 	SysClassNet           = "/sys/class/net"
 	ProcKernelCmdLine     = "/proc/cmdline"
+	ProcKernelBootID      = "/proc/sys/kernel/random/boot_id"
+	ProcKernelOSRelease   = "/proc/sys/kernel/osrelease"
+	SysModule             = "/sys/module"
+	SysDevicesSystemNode  = "/sys/devices/system/node"
+	ModprobeConfigFolder  = "/etc/modprobe.d"
 	NetClass              = 0x02
 	NumVfsFile            = "sriov_numvfs"
 	BusPci                = "pci"
@@ -125,6 +175,13 @@ const (
 	HostUdevRulesFolder = Host + UdevRulesFolder
 	UdevDisableNM       = "/bindata/scripts/udev-find-sriov-pf.sh"
 	UdevRepName         = "/bindata/scripts/switchdev-vf-link-name.sh"
+	UdevVfName          = "/bindata/scripts/vf-link-name.sh"
+
+	// the udev property set on the VFs of the PFs configured by the operator, contains the PCI address of the PF
+	UdevPropertySriovPF = "SRIOV_NETWORK_OPERATOR_PF"
+
+	SystemdNetworkdStateFile = "/run/systemd/netif/state"
+	SystemdNetworkConfDir    = "/etc/systemd/network"
 	// nolint:goconst
 	PFNameUdevRule = `SUBSYSTEM=="net", ACTION=="add", DRIVERS=="?*", KERNELS=="%s", NAME="%s"`
 	// nolint:goconst
@@ -139,12 +196,24 @@ const (
 		`ATTR{phys_port_name}=="pf%svf*", ` +
 		`IMPORT{program}="/etc/udev/switchdev-vf-link-name.sh $attr{phys_port_name}", ` +
 		`NAME="%s_$env{NUMBER}"`
+	// nolint:goconst
+	VfNameUdevRule = `SUBSYSTEM=="net", ` +
+		`ACTION=="add", ` +
+		`DRIVERS=="?*", ` +
+		`IMPORT{program}="/etc/udev/vf-link-name.sh $kernel", ` +
+		`ENV{SRIOV_VF_PF}=="%s", ` +
+		`ENV{SRIOV_VF_ID}=="?*", ` +
+		`NAME="%s"`
+	// UdevVfIDEnv is the udev environment variable set to the index of the VF by the VF name udev rule
+	UdevVfIDEnv = "$env{SRIOV_VF_ID}"
 
 	KernelArgPciRealloc    = "pci=realloc"
 	KernelArgIntelIommu    = "intel_iommu=on"
 	KernelArgIommuPt       = "iommu=pt"
 	KernelArgRdmaShared    = "ib_core.netns_mode=1"
 	KernelArgRdmaExclusive = "ib_core.netns_mode=0"
+	KernelArgHugepagesSize = "hugepagesz="
+	KernelArgHugepages     = "hugepages="
 
 	// Systemd consts
 	SriovSystemdConfigPath        = SriovConfBasePath + "/sriov-interface-config.yaml"
@@ -173,6 +242,10 @@ const (
 	// MellanoxFirmwareResetFeatureGate: enables the firmware reset via mstfwreset before a reboot
 	MellanoxFirmwareResetFeatureGate = "mellanoxFirmwareReset"
 
+	// KexecRebootFeatureGate: restart the node with kexec into the default kernel with the updated
+	// kernel arguments instead of doing a full reboot that goes through the firmware
+	KexecRebootFeatureGate = "kexecReboot"
+
 	// The path to the file on the host filesystem that contains the IB GUID distribution for IB VFs
 	InfinibandGUIDConfigFilePath = SriovConfBasePath + "/infiniband/guids"
 )
@@ -182,6 +255,8 @@ const (
 	Baremetal PlatformTypes = iota
 	// VirtualOpenStack platform
 	VirtualOpenStack
+	// VirtualGeneric platform, virtual machine with passthrough VFs
+	VirtualGeneric
 )
 
 func (e PlatformTypes) String() string {
@@ -190,6 +265,8 @@ func (e PlatformTypes) String() string {
 		return "Baremetal"
 	case VirtualOpenStack:
 		return "Virtual/Openstack"
+	case VirtualGeneric:
+		return "Virtual/Generic"
 	default:
 		return fmt.Sprintf("%d", int(e))
 	}
@@ -3,6 +3,8 @@ package daemon
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -12,8 +14,10 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
@@ -26,6 +30,8 @@ import (
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
 )
 
+const kexecScriptPath = "bindata/scripts/kexec-load.sh"
+
 // NodeReconciler struct holds various components necessary for reconciling an SR-IOV node.
 // It includes a Kubernetes client, SR-IOV client, and other utility interfaces.
 // The struct is designed to manage the lifecycle of an SR-IOV devices on a given node.
@@ -45,6 +51,9 @@ type NodeReconciler struct {
 
 	loadedPlugins         map[string]plugin.VendorPlugin
 	lastAppliedGeneration int64
+
+	// requests a reconcile on host changes, nil if the host changes are only detected by polling
+	hostEventWatcher *HostEventWatcher
 }
 
 // New creates a new instance of NodeReconciler.
@@ -75,16 +84,23 @@ func (dn *NodeReconciler) Init() error {
 	var err error
 
 	if !vars.UsingSystemdMode {
-		funcLog.V(0).Info("daemon running in daemon mode")
+		if vars.UsingHybridMode {
+			funcLog.V(0).Info("daemon running in hybrid mode")
+		} else {
+			funcLog.V(0).Info("daemon running in daemon mode")
+		}
 		_, err = dn.HostHelpers.CheckRDMAEnabled()
 		if err != nil {
 			funcLog.Error(err, "warning, failed to check RDMA state")
 		}
 		dn.HostHelpers.TryEnableTun()
 		dn.HostHelpers.TryEnableVhostNet()
-		err = dn.HostHelpers.CleanSriovFilesFromHost(vars.ClusterType == consts.ClusterTypeOpenshift)
-		if err != nil {
-			funcLog.Error(err, "failed to remove all the systemd sriov files")
+		// in hybrid mode the systemd service restores the configuration on boot
+		if !vars.UsingHybridMode {
+			err = dn.HostHelpers.CleanSriovFilesFromHost(vars.ClusterType == consts.ClusterTypeOpenshift)
+			if err != nil {
+				funcLog.Error(err, "failed to remove all the systemd sriov files")
+			}
 		}
 	} else {
 		funcLog.V(0).Info("Run(): daemon running in systemd mode")
@@ -96,21 +112,24 @@ func (dn *NodeReconciler) Init() error {
 	if err := dn.HostHelpers.PrepareVFRepUdevRule(); err != nil {
 		funcLog.Error(err, "failed to prepare udev files to rename VF representors for requested VFs")
 	}
+	if err := dn.HostHelpers.PrepareVFNameUdevRule(); err != nil {
+		funcLog.Error(err, "failed to prepare udev files to rename VFs for requested PFs")
+	}
 
-	// init openstack info
-	if vars.PlatformType == consts.VirtualOpenStack {
+	// init virtual platform devices info
+	if vars.PlatformType != consts.Baremetal {
 		ns, err := dn.HostHelpers.GetCheckPointNodeState()
 		if err != nil {
 			return err
 		}
 
 		if ns == nil {
-			err = dn.platformHelpers.CreateOpenstackDevicesInfo()
+			err = dn.platformHelpers.CreateDevicesInfo()
 			if err != nil {
 				return err
 			}
 		} else {
-			dn.platformHelpers.CreateOpenstackDevicesInfoFromNodeStatus(ns)
+			dn.platformHelpers.CreateDevicesInfoFromNodeStatus(ns)
 		}
 	}
 
@@ -219,7 +238,7 @@ func (dn *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 				}
 			}
 
-			return ctrl.Result{RequeueAfter: consts.DaemonRequeueTime}, nil
+			return ctrl.Result{RequeueAfter: dn.requeueTime()}, nil
 		}
 	}
 
@@ -230,8 +249,11 @@ func (dn *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		return ctrl.Result{}, err
 	}
 
-	reqReboot, reqDrain, err := dn.checkOnNodeStateChange(desiredNodeState)
+	reqReboot, reqDrain, reqFullDrain, err := dn.checkOnNodeStateChange(desiredNodeState)
 	if err != nil {
+		if updateErr := dn.updateSyncState(ctx, desiredNodeState, consts.SyncStatusFailed, err.Error()); updateErr != nil {
+			reqLogger.Error(updateErr, "failed to update nodeState status")
+		}
 		return ctrl.Result{}, err
 	}
 
@@ -247,6 +269,13 @@ func (dn *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		reqDrain = reqDrain || systemdConfModified || !sriovResultExists
 		// require reboot if drain needed for systemd mode
 		reqReboot = reqReboot || reqDrain
+	} else if vars.UsingHybridMode {
+		// In hybrid mode the configuration is applied live like in daemon mode,
+		// the file is only used by the systemd service to restore the configuration on boot
+		if _, err := dn.writeSystemdConfigFile(desiredNodeState); err != nil {
+			reqLogger.Error(err, "failed to write systemd config file")
+			return ctrl.Result{}, err
+		}
 	}
 
 	reqLogger.V(0).Info("aggregated daemon node state requirement",
@@ -255,7 +284,7 @@ func (dn *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 	// handle drain only if the plugins request drain, or we are already in a draining request state
 	if reqDrain ||
 		!utils.ObjectHasAnnotation(desiredNodeState, consts.NodeStateDrainAnnotationCurrent, consts.DrainIdle) {
-		drainInProcess, err := dn.handleDrain(ctx, desiredNodeState, reqReboot)
+		drainInProcess, err := dn.handleDrain(ctx, desiredNodeState, reqReboot, reqFullDrain)
 		if err != nil {
 			reqLogger.Error(err, "failed to handle drain")
 			return ctrl.Result{}, err
@@ -280,18 +309,21 @@ func (dn *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 
 // checkOnNodeStateChange checks the state change required for the node based on the desired SriovNetworkNodeState.
 // The function iterates over all loaded plugins and calls their OnNodeStateChange method with the desired state.
-// It returns two boolean values indicating whether a reboot or drain operation is required.
-func (dn *NodeReconciler) checkOnNodeStateChange(desiredNodeState *sriovnetworkv1.SriovNetworkNodeState) (bool, bool, error) {
+// It returns three boolean values indicating whether a reboot or drain operation is required,
+// and whether a plugin other than the generic plugin requested the drain, in which case the drain
+// can't be narrowed to the pods using the resources of the reconfigured PFs.
+func (dn *NodeReconciler) checkOnNodeStateChange(desiredNodeState *sriovnetworkv1.SriovNetworkNodeState) (bool, bool, bool, error) {
 	funcLog := log.Log.WithName("checkOnNodeStateChange")
 	reqReboot := false
 	reqDrain := false
+	reqFullDrain := false
 
 	// check if any of the plugins required to drain or reboot the node
 	for k, p := range dn.loadedPlugins {
 		d, r, err := p.OnNodeStateChange(desiredNodeState)
 		if err != nil {
 			funcLog.Error(err, "OnNodeStateChange plugin error", "plugin-name", k)
-			return false, false, err
+			return false, false, false, err
 		}
 		funcLog.V(0).Info("OnNodeStateChange result",
 			"plugin", k,
@@ -299,9 +331,10 @@ func (dn *NodeReconciler) checkOnNodeStateChange(desiredNodeState *sriovnetworkv
 			"reboot-required", r)
 		reqDrain = reqDrain || d
 		reqReboot = reqReboot || r
+		reqFullDrain = reqFullDrain || (d && k != GenericPluginName)
 	}
 
-	return reqReboot, reqDrain, nil
+	return reqReboot, reqDrain, reqFullDrain, nil
 }
 
 // checkSystemdStatus Checks the status of systemd services on the host node.
@@ -431,7 +464,16 @@ func (dn *NodeReconciler) apply(ctx context.Context, desiredNodeState *sriovnetw
 
 	// update the lastAppliedGeneration
 	dn.lastAppliedGeneration = desiredNodeState.Generation
-	return ctrl.Result{RequeueAfter: consts.DaemonRequeueTime}, nil
+	return ctrl.Result{RequeueAfter: dn.requeueTime()}, nil
+}
+
+// requeueTime returns the time after which the host is checked again for changes,
+// the interval is longer if the host changes trigger a reconcile.
+func (dn *NodeReconciler) requeueTime() time.Duration {
+	if dn.hostEventWatcher != nil && dn.hostEventWatcher.IsRunning() {
+		return consts.DaemonEventsRequeueTime
+	}
+	return consts.DaemonRequeueTime
 }
 
 // checkHostStateDrift returns true if the node state drifted from the nodeState policy
@@ -511,8 +553,9 @@ func (dn *NodeReconciler) writeSystemdConfigFile(desiredNodeState *sriovnetworkv
 }
 
 // handleDrain: adds the right annotation to the node and nodeState object
+// the drain is narrowed to the resources of the reconfigured PFs unless a reboot or a full drain is requested
 // returns true if we need to finish the reconcile loop and wait for a new object
-func (dn *NodeReconciler) handleDrain(ctx context.Context, desiredNodeState *sriovnetworkv1.SriovNetworkNodeState, reqReboot bool) (bool, error) {
+func (dn *NodeReconciler) handleDrain(ctx context.Context, desiredNodeState *sriovnetworkv1.SriovNetworkNodeState, reqReboot, reqFullDrain bool) (bool, error) {
 	funcLog := log.Log.WithName("handleDrain")
 	// done with the drain we can continue with the configuration
 	if utils.ObjectHasAnnotation(desiredNodeState, consts.NodeStateDrainAnnotationCurrent, consts.DrainComplete) {
@@ -534,12 +577,78 @@ func (dn *NodeReconciler) handleDrain(ctx context.Context, desiredNodeState *sri
 
 	// annotate both node and node state with drain or reboot
 	annotation := consts.DrainRequired
+	var drainResources []string
 	if reqReboot {
 		annotation = consts.RebootRequired
+	} else if !reqFullDrain {
+		drainResources = dn.getDrainResources(desiredNodeState)
+	}
+	// the resources must be set before the drain is requested, an empty value clears the list of the previous drain
+	funcLog.Info("apply drain resources annotation for nodeState", "resources", drainResources)
+	if err := utils.AnnotateObject(ctx, desiredNodeState, consts.NodeStateDrainResourcesAnnotation,
+		strings.Join(drainResources, ","), dn.client); err != nil {
+		funcLog.Error(err, "Failed to annotate nodeState with the drain resources")
+		return false, err
 	}
 	return true, dn.annotate(ctx, desiredNodeState, annotation)
 }
 
+// getDrainResources returns the names of the resources exposed from the VF groups of the PFs that need to be
+// reconfigured by the generic plugin, including the groups applied on the PFs before the change.
+// Returns nil if the change can't be narrowed to the PFs and all the pods using SR-IOV devices must be drained.
+func (dn *NodeReconciler) getDrainResources(desiredNodeState *sriovnetworkv1.SriovNetworkNodeState) []string {
+	funcLog := log.Log.WithName("getDrainResources")
+	if vars.ManageSoftwareBridges &&
+		sriovnetworkv1.NeedToUpdateBridges(&desiredNodeState.Spec.Bridges, &desiredNodeState.Status.Bridges) {
+		return nil
+	}
+	// restarting ovs-vswitchd affects all the pods attached to the OVS bridges
+	if sriovnetworkv1.NeedToUpdateOVSHwOffload(desiredNodeState.Spec.System.OvsHardwareOffload,
+		desiredNodeState.Status.System.OvsHardwareOffload) {
+		return nil
+	}
+
+	resources := []string{}
+	for i := range desiredNodeState.Status.Interfaces {
+		ifaceStatus := &desiredNodeState.Status.Interfaces[i]
+		var iface *sriovnetworkv1.Interface
+		for j := range desiredNodeState.Spec.Interfaces {
+			if desiredNodeState.Spec.Interfaces[j].PciAddress == ifaceStatus.PciAddress {
+				iface = &desiredNodeState.Spec.Interfaces[j]
+				break
+			}
+		}
+		if iface != nil {
+			if !sriovnetworkv1.NeedToUpdateSriov(iface, ifaceStatus) {
+				continue
+			}
+			for _, group := range iface.VfGroups {
+				resources = sriovnetworkv1.UniqueAppend(resources, group.ResourceName)
+			}
+		} else if ifaceStatus.NumVfs == 0 {
+			continue
+		}
+
+		pfStatus, exist, err := dn.HostHelpers.LoadPfsStatus(ifaceStatus.PciAddress)
+		if err != nil {
+			funcLog.Error(err, "failed to load the applied configuration of the PF, drain all the SR-IOV pods",
+				"address", ifaceStatus.PciAddress)
+			return nil
+		}
+		if !exist {
+			continue
+		}
+		for _, group := range pfStatus.VfGroups {
+			resources = sriovnetworkv1.UniqueAppend(resources, group.ResourceName)
+		}
+	}
+	if len(resources) == 0 {
+		return nil
+	}
+	sort.Strings(resources)
+	return resources
+}
+
 // restartDevicePluginPod restarts the device plugin pod on the specified node.
 //
 // The function checks if the pod exists, deletes it if found, and waits for it to be deleted successfully.
@@ -602,9 +711,16 @@ func (dn *NodeReconciler) restartDevicePluginPod(ctx context.Context) error {
 }
 
 // rebootNode Reboots the node by executing a systemd-run command
+// if the kexecReboot feature gate is enabled the node restarts using kexec when the host supports it
 func (dn *NodeReconciler) rebootNode() error {
 	funcLog := log.Log.WithName("rebootNode")
 	funcLog.Info("trigger node reboot")
+	rebootCmd := "reboot"
+	if vars.FeatureGate.IsEnabled(consts.KexecRebootFeatureGate) && dn.loadKexecKernel() {
+		// fallback to a regular reboot if systemd fails to execute the loaded kernel
+		rebootCmd = "systemctl kexec || reboot"
+	}
+
 	exit, err := dn.HostHelpers.Chroot(consts.Host)
 	if err != nil {
 		funcLog.Error(err, "chroot command failed")
@@ -619,7 +735,7 @@ func (dn *NodeReconciler) rebootNode() error {
 	// if kubelet failed to shutdown - that way the machine will still eventually reboot
 	// as systemd will time out the stop invocation.
 	stdOut, StdErr, err := dn.HostHelpers.RunCommand("systemd-run", "--unit", "sriov-network-config-daemon-reboot",
-		"--description", "sriov-network-config-daemon reboot node", "/bin/sh", "-c", "systemctl stop kubelet.service; reboot")
+		"--description", "sriov-network-config-daemon reboot node", "/bin/sh", "-c", "systemctl stop kubelet.service; "+rebootCmd)
 
 	if err != nil {
 		funcLog.Error(err, "failed to reboot node", "stdOut", stdOut, "StdErr", StdErr)
@@ -628,6 +744,23 @@ func (dn *NodeReconciler) rebootNode() error {
 	return nil
 }
 
+// loadKexecKernel loads the default kernel of the host bootloader with its configured command line
+// into kexec. Returns false if the host doesn't support kexec and the node should do a full reboot.
+func (dn *NodeReconciler) loadKexecKernel() bool {
+	funcLog := log.Log.WithName("loadKexecKernel")
+	stdOut, stdErr, err := dn.HostHelpers.RunCommand("/bin/sh", kexecScriptPath)
+	if err != nil {
+		if utils.IsCommandNotFound(err) {
+			funcLog.Info("kexec is not supported on the host, fallback to a full reboot")
+			return false
+		}
+		funcLog.Error(err, "failed to load kernel with kexec, fallback to a full reboot", "stdOut", stdOut, "stdErr", stdErr)
+		return false
+	}
+	funcLog.Info("kernel loaded with kexec")
+	return true
+}
+
 // prepareNMUdevRule prepares/validate the status of the config-daemon custom udev rules needed to control
 // the virtual functions by the operator only.
 func (dn *NodeReconciler) prepareNMUdevRule() error {
@@ -696,11 +829,21 @@ func (dn *NodeReconciler) annotate(
 
 // SetupWithManager sets up the controller with the Manager.
 func (dn *NodeReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&sriovnetworkv1.SriovNetworkNodeState{}).
 		WithEventFilter(predicate.Or(predicate.AnnotationChangedPredicate{}, predicate.GenerationChangedPredicate{})).
-		WithOptions(controller.Options{MaxConcurrentReconciles: 1}).
-		Complete(dn)
+		WithOptions(controller.Options{MaxConcurrentReconciles: 1})
+	if dn.hostEventWatcher != nil {
+		b = b.WatchesRawSource(source.Channel(dn.hostEventWatcher.Events(), &handler.EnqueueRequestForObject{}))
+	}
+	return b.Complete(dn)
+}
+
+// SetupHostEventWatcher adds a HostEventWatcher to the manager to reconcile the nodeState as soon as the host changes.
+// Must be called before SetupWithManager.
+func (dn *NodeReconciler) SetupHostEventWatcher(mgr ctrl.Manager) error {
+	dn.hostEventWatcher = NewHostEventWatcher()
+	return mgr.Add(dn.hostEventWatcher)
 }
 
 // -------------------------------------
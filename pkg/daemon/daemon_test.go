@@ -25,6 +25,7 @@ import (
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/featuregate"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/helper"
 	mock_helper "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/helper/mock"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/store"
 	hostTypes "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/types"
 	snolog "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/log"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/platforms"
@@ -114,6 +115,7 @@ var _ = Describe("Daemon Controller", Ordered, func() {
 		hostHelper.EXPECT().TryEnableVhostNet()
 		hostHelper.EXPECT().PrepareNMUdevRule([]string{}).Return(nil)
 		hostHelper.EXPECT().PrepareVFRepUdevRule().Return(nil)
+		hostHelper.EXPECT().PrepareVFNameUdevRule().Return(nil)
 		hostHelper.EXPECT().WriteCheckpointFile(gomock.Any()).Return(nil)
 
 		// general
@@ -130,6 +132,8 @@ var _ = Describe("Daemon Controller", Ordered, func() {
 
 		hostHelper.EXPECT().ClearPCIAddressFolder().Return(nil).AnyTimes()
 		hostHelper.EXPECT().DiscoverRDMASubsystem().Return("shared", nil).AnyTimes()
+		hostHelper.EXPECT().GetHugepages().Return(nil, nil).AnyTimes()
+		hostHelper.EXPECT().IsSriovNumVfsResizeSupported(gomock.Any()).Return(false).AnyTimes()
 		hostHelper.EXPECT().GetCurrentKernelArgs().Return("", nil).AnyTimes()
 		hostHelper.EXPECT().IsKernelArgsSet("", constants.KernelArgPciRealloc).Return(true).AnyTimes()
 		hostHelper.EXPECT().IsKernelArgsSet("", constants.KernelArgIntelIommu).Return(true).AnyTimes()
@@ -137,6 +141,9 @@ var _ = Describe("Daemon Controller", Ordered, func() {
 		hostHelper.EXPECT().IsKernelArgsSet("", constants.KernelArgRdmaExclusive).Return(false).AnyTimes()
 		hostHelper.EXPECT().IsKernelArgsSet("", constants.KernelArgRdmaShared).Return(false).AnyTimes()
 		hostHelper.EXPECT().SetRDMASubsystem("").Return(nil).AnyTimes()
+		hostHelper.EXPECT().LoadKernelArgsState().Return(nil, false, nil).AnyTimes()
+		hostHelper.EXPECT().LoadManagedKernelConfig().Return(&store.ManagedKernelConfig{}, nil).AnyTimes()
+		hostHelper.EXPECT().SaveManagedKernelConfig(gomock.Any()).Return(nil).AnyTimes()
 
 		hostHelper.EXPECT().ConfigSriovInterfaces(gomock.Any(), gomock.Any(), gomock.Any(), false).Return(nil).AnyTimes()
 
@@ -0,0 +1,208 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
+)
+
+const (
+	// netlink multicast group of the uevents sent by the kernel
+	ueventKernelGroup = 1
+	ueventBufferSize  = 64 * 1024
+)
+
+// HostEventWatcher listens to the netlink link updates and to the kernel uevents of the PCI and network devices.
+// It requests a reconcile of the nodeState of the node when the host changes, e.g. a PF link goes down,
+// VFs are created, a driver is bound or unbound, or a NIC is hot-plugged.
+type HostEventWatcher struct {
+	events  chan event.GenericEvent
+	running atomic.Bool
+}
+
+// NewHostEventWatcher creates a new HostEventWatcher
+func NewHostEventWatcher() *HostEventWatcher {
+	return &HostEventWatcher{events: make(chan event.GenericEvent, 1)}
+}
+
+// Events returns the channel the reconcile requests are sent to
+func (w *HostEventWatcher) Events() <-chan event.GenericEvent {
+	return w.events
+}
+
+// IsRunning returns true if the watcher receives the host events
+func (w *HostEventWatcher) IsRunning() bool {
+	return w.running.Load()
+}
+
+// Start implements the manager.Runnable interface, it watches the host events until the context is done.
+// The error to subscribe to the events is not returned to keep the manager running,
+// the daemon keeps detecting the host changes by polling.
+func (w *HostEventWatcher) Start(ctx context.Context) error {
+	funcLog := log.Log.WithName("HostEventWatcher")
+
+	done := make(chan struct{})
+	defer close(done)
+	linkUpdates := make(chan netlink.LinkUpdate, 100)
+	err := netlink.LinkSubscribeWithOptions(linkUpdates, done, netlink.LinkSubscribeOptions{
+		ErrorCallback: func(err error) { funcLog.Error(err, "link updates subscription error") },
+	})
+	if err != nil {
+		funcLog.Error(err, "failed to subscribe to link updates, host changes are detected by polling")
+		return nil
+	}
+
+	ueventFd, err := openUeventSocket()
+	if err != nil {
+		funcLog.Error(err, "failed to subscribe to kernel uevents, host changes are detected by polling")
+		return nil
+	}
+	uevents := make(chan string, 100)
+	go readUevents(ctx, ueventFd, uevents)
+
+	funcLog.Info("watching host events")
+	w.running.Store(true)
+	defer w.running.Store(false)
+
+	// the events are batched, the reconcile is requested once after the first event of a burst
+	pending := false
+	batch := time.NewTimer(consts.HostEventsBatchTime)
+	batch.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case update, ok := <-linkUpdates:
+			if !ok {
+				funcLog.Info("link updates subscription closed, host changes are detected by polling")
+				return nil
+			}
+			if !isRelevantLinkUpdate(update) {
+				continue
+			}
+			funcLog.V(2).Info("link update", "link", update.Attrs().Name, "oper-state", update.Attrs().OperState)
+			if !pending {
+				pending = true
+				batch.Reset(consts.HostEventsBatchTime)
+			}
+		case uevent, ok := <-uevents:
+			if !ok {
+				funcLog.Info("kernel uevents subscription closed, host changes are detected by polling")
+				return nil
+			}
+			funcLog.V(2).Info("kernel uevent", "event", uevent)
+			if !pending {
+				pending = true
+				batch.Reset(consts.HostEventsBatchTime)
+			}
+		case <-batch.C:
+			pending = false
+			funcLog.V(2).Info("host changed, request nodeState reconcile")
+			w.requestReconcile()
+		}
+	}
+}
+
+// requestReconcile sends a reconcile request for the nodeState of the node,
+// the request is dropped if one is already pending
+func (w *HostEventWatcher) requestReconcile() {
+	select {
+	case w.events <- event.GenericEvent{Object: &sriovnetworkv1.SriovNetworkNodeState{
+		ObjectMeta: metav1.ObjectMeta{Name: vars.NodeName, Namespace: vars.Namespace}}}:
+	default:
+	}
+}
+
+// isRelevantLinkUpdate returns true for the updates of the links of the network devices,
+// the software links like bridges or veth are ignored
+func isRelevantLinkUpdate(update netlink.LinkUpdate) bool {
+	return update.Link != nil && update.Link.Type() == "device"
+}
+
+// openUeventSocket opens a netlink socket subscribed to the uevents sent by the kernel
+func openUeventSocket() (int, error) {
+	fd, err := unix.Socket(unix.AF_NETLINK, unix.SOCK_RAW|unix.SOCK_CLOEXEC, unix.NETLINK_KOBJECT_UEVENT)
+	if err != nil {
+		return -1, err
+	}
+	// the timeout allows to check if the watcher was stopped
+	if err := unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &unix.Timeval{Sec: 1}); err != nil {
+		unix.Close(fd)
+		return -1, err
+	}
+	if err := unix.Bind(fd, &unix.SockaddrNetlink{Family: unix.AF_NETLINK, Groups: ueventKernelGroup}); err != nil {
+		unix.Close(fd)
+		return -1, err
+	}
+	return fd, nil
+}
+
+// readUevents reads the uevents from the socket and sends the relevant ones to the channel
+// until the context is done, the socket and the channel are closed on exit
+func readUevents(ctx context.Context, fd int, uevents chan<- string) {
+	defer close(uevents)
+	defer unix.Close(fd)
+	buf := make([]byte, ueventBufferSize)
+	for ctx.Err() == nil {
+		n, _, err := unix.Recvfrom(fd, buf, 0)
+		if err != nil {
+			if errors.Is(err, unix.EAGAIN) || errors.Is(err, unix.EINTR) || errors.Is(err, unix.ENOBUFS) {
+				continue
+			}
+			log.Log.Error(err, "readUevents(): failed to read kernel uevent")
+			return
+		}
+		action, subsystem, devPath := parseUevent(buf[:n])
+		if isRelevantUevent(action, subsystem) {
+			select {
+			case uevents <- action + "@" + devPath:
+			default:
+			}
+		}
+	}
+}
+
+// parseUevent returns the action, the subsystem and the device path of a kernel uevent.
+// The uevent is formatted as "action@devpath" followed by the KEY=VALUE properties, all NUL separated.
+func parseUevent(msg []byte) (string, string, string) {
+	var action, subsystem, devPath string
+	for _, field := range bytes.Split(msg, []byte{0}) {
+		key, value, found := bytes.Cut(field, []byte("="))
+		if !found {
+			continue
+		}
+		switch string(key) {
+		case "ACTION":
+			action = string(value)
+		case "SUBSYSTEM":
+			subsystem = string(value)
+		case "DEVPATH":
+			devPath = string(value)
+		}
+	}
+	return action, subsystem, devPath
+}
+
+// isRelevantUevent returns true for the PCI devices added, removed, bound or unbound from a driver,
+// and for the network devices added, removed or renamed
+func isRelevantUevent(action, subsystem string) bool {
+	switch subsystem {
+	case "pci":
+		return action == "add" || action == "remove" || action == "bind" || action == "unbind"
+	case "net":
+		return action == "add" || action == "remove" || action == "move"
+	}
+	return false
+}
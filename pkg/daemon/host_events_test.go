@@ -0,0 +1,59 @@
+package daemon
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vishvananda/netlink"
+)
+
+var _ = Describe("host events", func() {
+	Context("parseUevent", func() {
+		It("should return the action, subsystem and devpath of the uevent", func() {
+			msg := strings.Join([]string{
+				"bind@/devices/pci0000:00/0000:00:02.0/0000:01:00.2",
+				"ACTION=bind",
+				"DEVPATH=/devices/pci0000:00/0000:00:02.0/0000:01:00.2",
+				"SUBSYSTEM=pci",
+				"DRIVER=iavf",
+				"PCI_SLOT_NAME=0000:01:00.2",
+				"SEQNUM=4242",
+			}, "\x00")
+			action, subsystem, devPath := parseUevent([]byte(msg))
+			Expect(action).To(Equal("bind"))
+			Expect(subsystem).To(Equal("pci"))
+			Expect(devPath).To(Equal("/devices/pci0000:00/0000:00:02.0/0000:01:00.2"))
+		})
+		It("should return empty values for a malformed uevent", func() {
+			action, subsystem, devPath := parseUevent([]byte("garbage"))
+			Expect(action).To(BeEmpty())
+			Expect(subsystem).To(BeEmpty())
+			Expect(devPath).To(BeEmpty())
+		})
+	})
+	Context("isRelevantUevent", func() {
+		DescribeTable("should filter the uevents",
+			func(action, subsystem string, expected bool) {
+				Expect(isRelevantUevent(action, subsystem)).To(Equal(expected))
+			},
+			Entry("pci add", "add", "pci", true),
+			Entry("pci remove", "remove", "pci", true),
+			Entry("pci bind", "bind", "pci", true),
+			Entry("pci unbind", "unbind", "pci", true),
+			Entry("pci change", "change", "pci", false),
+			Entry("net add", "add", "net", true),
+			Entry("net rename", "move", "net", true),
+			Entry("net change", "change", "net", false),
+			Entry("block add", "add", "block", false),
+		)
+	})
+	Context("isRelevantLinkUpdate", func() {
+		It("should ignore software links", func() {
+			Expect(isRelevantLinkUpdate(netlink.LinkUpdate{Link: &netlink.Device{}})).To(BeTrue())
+			Expect(isRelevantLinkUpdate(netlink.LinkUpdate{Link: &netlink.Bridge{}})).To(BeFalse())
+			Expect(isRelevantLinkUpdate(netlink.LinkUpdate{Link: &netlink.Veth{}})).To(BeFalse())
+			Expect(isRelevantLinkUpdate(netlink.LinkUpdate{})).To(BeFalse())
+		})
+	})
+})
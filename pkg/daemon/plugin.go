@@ -34,7 +34,7 @@ func loadPlugins(ns *sriovnetworkv1.SriovNetworkNodeState, helpers helper.HostHe
 	log.Log.Info("loadPlugins(): loading plugins")
 	loadedPlugins := map[string]plugin.VendorPlugin{}
 
-	if vars.PlatformType == consts.VirtualOpenStack {
+	if vars.PlatformType != consts.Baremetal {
 		virtualPlugin, err := VirtualPlugin(helpers)
 		if err != nil {
 			log.Log.Error(err, "loadPlugins(): failed to load the virtual plugin")
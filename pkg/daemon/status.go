@@ -103,7 +103,7 @@ func (dn *NodeReconciler) updateStatusFromHost(nodeState *sriovnetworkv1.SriovNe
 	var bridges sriovnetworkv1.Bridges
 	var err error
 
-	if vars.PlatformType == consts.VirtualOpenStack {
+	if vars.PlatformType != consts.Baremetal {
 		ifaces, err = dn.platformHelpers.DiscoverSriovDevicesVirtual()
 		if err != nil {
 			return err
@@ -123,7 +123,17 @@ func (dn *NodeReconciler) updateStatusFromHost(nodeState *sriovnetworkv1.SriovNe
 
 	nodeState.Status.Interfaces = ifaces
 	nodeState.Status.Bridges = bridges
+	nodeState.Status.System.OvsHardwareOffload, err = dn.HostHelpers.GetOVSHwOffload()
+	if err != nil {
+		// OVS is not installed on all the nodes, the settings are reported only if OVSDB is available
+		log.Log.V(2).Info("updateStatusFromHost(): failed to get OVS hardware offload settings", "error", err)
+		nodeState.Status.System.OvsHardwareOffload = nil
+	}
 	nodeState.Status.System.RdmaMode, err = dn.HostHelpers.DiscoverRDMASubsystem()
+	if err != nil {
+		return err
+	}
+	nodeState.Status.Hugepages, err = dn.HostHelpers.GetHugepages()
 	return err
 }
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -33,13 +34,17 @@ func (w writer) Write(p []byte) (n int, err error) {
 }
 
 type DrainInterface interface {
-	DrainNode(context.Context, *corev1.Node, bool, bool) (bool, error)
+	DrainNode(context.Context, *corev1.Node, bool, bool, []string) (bool, error)
 	CompleteDrainNode(context.Context, *corev1.Node) (bool, error)
 }
 
 type Drainer struct {
 	kubeClient      kubernetes.Interface
 	platformHelpers platforms.Interface
+
+	// acquiredNodes holds the nodes acquired from the node lifecycle coordinator
+	// that were not released yet, so they are not acquired again on a re queue
+	acquiredNodes sync.Map
 }
 
 func NewDrainer(platformHelpers platforms.Interface) (DrainInterface, error) {
@@ -56,8 +61,10 @@ func NewDrainer(platformHelpers platforms.Interface) (DrainInterface, error) {
 
 // DrainNode the function cordon a node and drain pods from it
 // if fullNodeDrain true all the pods on the system will get drained
+// if resourceNames is not empty only the pods using these resources are drained on a non-full drain
 // for openshift system we also pause the machine config pool this machine is part of it
-func (d *Drainer) DrainNode(ctx context.Context, node *corev1.Node, fullNodeDrain, singleNode bool) (bool, error) {
+// the node is acquired from the node lifecycle coordinator once before it's drained and renewed on each re queue
+func (d *Drainer) DrainNode(ctx context.Context, node *corev1.Node, fullNodeDrain, singleNode bool, resourceNames []string) (bool, error) {
 	reqLogger := ctx.Value("logger").(logr.Logger).WithName("drainNode")
 	reqLogger.Info("Node drain requested")
 
@@ -72,12 +79,33 @@ func (d *Drainer) DrainNode(ctx context.Context, node *corev1.Node, fullNodeDrai
 		return false, nil
 	}
 
+	// make sure no other node lifecycle manager drains or reboots the node at the same time
+	if _, acquired := d.acquiredNodes.Load(node.Name); acquired {
+		// keep holding the node while the drain is re queued
+		if err := d.platformHelpers.RenewNode(ctx, node); err != nil {
+			reqLogger.Error(err, "error renewing the node in the node lifecycle coordinator")
+			return false, err
+		}
+	} else {
+		acquired, err := d.platformHelpers.AcquireNode(ctx, node)
+		if err != nil {
+			reqLogger.Error(err, "error acquiring the node from the node lifecycle coordinator")
+			return false, err
+		}
+
+		if !acquired {
+			reqLogger.Info("node is used by another node lifecycle manager re queue the node request")
+			return false, nil
+		}
+		d.acquiredNodes.Store(node.Name, struct{}{})
+	}
+
 	// Check if we are on a single node, and we require a reboot/full-drain we just return
 	if fullNodeDrain && singleNode {
 		return true, nil
 	}
 
-	drainHelper := createDrainHelper(d.kubeClient, ctx, fullNodeDrain)
+	drainHelper := createDrainHelper(d.kubeClient, ctx, fullNodeDrain, resourceNames)
 	backoff := wait.Backoff{
 		Steps:    3,
 		Duration: 2 * time.Second,
@@ -116,12 +144,13 @@ func (d *Drainer) DrainNode(ctx context.Context, node *corev1.Node, fullNodeDrai
 // CompleteDrainNode run un-cordon for the requested node
 // for openshift system we also remove the pause from the machine config pool this node is part of
 // only if we are the last draining node on that pool
+// the node is released in the node lifecycle coordinator at the end
 func (d *Drainer) CompleteDrainNode(ctx context.Context, node *corev1.Node) (bool, error) {
 	logger := ctx.Value("logger").(logr.Logger).WithName("CompleteDrainNode")
 
 	// Create drain helper object
 	// full drain is not important here
-	drainHelper := createDrainHelper(d.kubeClient, ctx, false)
+	drainHelper := createDrainHelper(d.kubeClient, ctx, false, nil)
 
 	// run the un cordon function on the node
 	if err := drain.RunCordonOrUncordon(drainHelper, node, false); err != nil {
@@ -137,14 +166,24 @@ func (d *Drainer) CompleteDrainNode(ctx context.Context, node *corev1.Node) (boo
 		return false, err
 	}
 
+	// release the node for the other node lifecycle managers
+	if completed {
+		if err := d.platformHelpers.ReleaseNode(ctx, node); err != nil {
+			logger.Error(err, "failed to release the node in the node lifecycle coordinator")
+			return false, err
+		}
+		d.acquiredNodes.Delete(node.Name)
+	}
+
 	logger.V(2).Info("CompleteDrainNode:()", "drainCompleted", completed)
 	return completed, nil
 }
 
 // createDrainHelper function to create a drain helper
-// if fullDrain is false we only remove pods that have the resourcePrefix
+// if fullDrain is false we only remove pods that have the resourcePrefix,
+// or only the pods using the resources from resourceNames if the list is not empty
 // if not we remove all the pods in the node
-func createDrainHelper(kubeClient kubernetes.Interface, ctx context.Context, fullDrain bool) *drain.Helper {
+func createDrainHelper(kubeClient kubernetes.Interface, ctx context.Context, fullDrain bool, resourceNames []string) *drain.Helper {
 	logger := ctx.Value("logger").(logr.Logger).WithName("createDrainHelper")
 
 	drainer := &drain.Helper{
@@ -180,7 +219,7 @@ func createDrainHelper(kubeClient kubernetes.Interface, ctx context.Context, ful
 			for _, c := range p.Spec.Containers {
 				if c.Resources.Requests != nil {
 					for r := range c.Resources.Requests {
-						if strings.HasPrefix(r.String(), vars.ResourcePrefix) {
+						if isSriovResource(r.String(), resourceNames) {
 							return drain.PodDeleteStatus{
 								Delete:  true,
 								Reason:  "pod contain SR-IOV device",
@@ -198,3 +237,20 @@ func createDrainHelper(kubeClient kubernetes.Interface, ctx context.Context, ful
 
 	return drainer
 }
+
+// isSriovResource returns true if the resource is exposed by the SR-IOV device plugin,
+// if resourceNames is not empty the resource must also be one of them
+func isSriovResource(resource string, resourceNames []string) bool {
+	if !strings.HasPrefix(resource, vars.ResourcePrefix) {
+		return false
+	}
+	if len(resourceNames) == 0 {
+		return true
+	}
+	for _, name := range resourceNames {
+		if resource == vars.ResourcePrefix+"/"+name {
+			return true
+		}
+	}
+	return false
+}
@@ -90,7 +90,7 @@ var _ = Describe("Drainer", Ordered, func() {
 			n, _ := createNode("node0")
 			platformHelper.EXPECT().OpenshiftBeforeDrainNode(ctx, n).Return(false, fmt.Errorf("failed"))
 
-			completed, err := drn.DrainNode(ctx, n, false, false)
+			completed, err := drn.DrainNode(ctx, n, false, false, nil)
 			Expect(err).To(HaveOccurred())
 			Expect(completed).To(BeFalse())
 		})
@@ -99,11 +99,47 @@ var _ = Describe("Drainer", Ordered, func() {
 			n, _ := createNode("node0")
 			platformHelper.EXPECT().OpenshiftBeforeDrainNode(ctx, n).Return(false, nil)
 
-			completed, err := drn.DrainNode(ctx, n, false, false)
+			completed, err := drn.DrainNode(ctx, n, false, false, nil)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(completed).To(BeFalse())
 		})
 
+		It("should return not completed if another node lifecycle manager uses the node", func() {
+			n, _ := createNode("node0")
+			platformHelper.EXPECT().OpenshiftBeforeDrainNode(ctx, n).Return(true, nil)
+			platformHelper.EXPECT().AcquireNode(ctx, n).Return(false, nil)
+
+			completed, err := drn.DrainNode(ctx, n, false, false, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(completed).To(BeFalse())
+		})
+
+		It("should acquire the node only once until it's released and renew it on re queue", func() {
+			n, _ := createNode("node0")
+			n.Spec.Unschedulable = true
+			platformHelper.EXPECT().OpenshiftBeforeDrainNode(ctx, n).Return(true, nil).Times(3)
+			platformHelper.EXPECT().AcquireNode(ctx, n).Return(true, nil).Times(2)
+			platformHelper.EXPECT().RenewNode(ctx, n).Return(nil)
+			platformHelper.EXPECT().OpenshiftAfterCompleteDrainNode(ctx, n).Return(true, nil)
+			platformHelper.EXPECT().ReleaseNode(ctx, n).Return(nil)
+
+			completed, err := drn.DrainNode(ctx, n, true, true, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(completed).To(BeTrue())
+
+			completed, err = drn.DrainNode(ctx, n, true, true, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(completed).To(BeTrue())
+
+			completed, err = drn.CompleteDrainNode(ctx, n)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(completed).To(BeTrue())
+
+			completed, err = drn.DrainNode(ctx, n, true, true, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(completed).To(BeTrue())
+		})
+
 		It("should return error if not able to cordon the node", func() {
 			n, _ := createNode("node0")
 			nCopy := n.DeepCopy()
@@ -115,14 +151,16 @@ var _ = Describe("Drainer", Ordered, func() {
 			}()
 
 			platformHelper.EXPECT().OpenshiftBeforeDrainNode(ctx, nCopy).Return(true, nil)
+			platformHelper.EXPECT().AcquireNode(ctx, nCopy).Return(true, nil)
 
-			_, err := drn.DrainNode(ctx, nCopy, false, false)
+			_, err := drn.DrainNode(ctx, nCopy, false, false, nil)
 			Expect(err).To(HaveOccurred())
 		})
 
 		It("should return error if not able to drain the node", func() {
 			n, _ := createNode("node0")
 			platformHelper.EXPECT().OpenshiftBeforeDrainNode(ctx, n).Return(true, nil)
+			platformHelper.EXPECT().AcquireNode(ctx, n).Return(true, nil)
 			createPodWithFinalizerOnNode(ctx, "test-node-0", "node0")
 			originalDrainTimeOut := drain.DrainTimeOut
 			drain.DrainTimeOut = 3 * time.Second
@@ -130,7 +168,7 @@ var _ = Describe("Drainer", Ordered, func() {
 				drain.DrainTimeOut = originalDrainTimeOut
 			}()
 
-			_, err := drn.DrainNode(ctx, n, true, false)
+			_, err := drn.DrainNode(ctx, n, true, false, nil)
 			Expect(err).To(HaveOccurred())
 		})
 
@@ -142,6 +180,7 @@ var _ = Describe("Drainer", Ordered, func() {
 			err := k8sClient.Status().Update(ctx, n)
 			Expect(err).ToNot(HaveOccurred())
 			platformHelper.EXPECT().OpenshiftBeforeDrainNode(ctx, n).Return(true, nil)
+			platformHelper.EXPECT().AcquireNode(ctx, n).Return(true, nil)
 			createPodOnNode(ctx, "regular-pod", "node1")
 			createPodWithSriovDeviceOnNode(ctx, "sriov-pod", "node1")
 
@@ -156,7 +195,7 @@ var _ = Describe("Drainer", Ordered, func() {
 				}, 2*time.Minute, time.Second).Should(Succeed())
 			}()
 
-			_, err = drn.DrainNode(ctx, n, false, false)
+			_, err = drn.DrainNode(ctx, n, false, false, nil)
 			Expect(err).ToNot(HaveOccurred())
 			pod := &corev1.Pod{}
 			err = k8sClient.Get(ctx, client.ObjectKey{Name: "regular-pod", Namespace: testNamespace}, pod)
@@ -166,6 +205,41 @@ var _ = Describe("Drainer", Ordered, func() {
 			Expect(errors.IsNotFound(err)).To(BeTrue())
 
 		})
+
+		It("should remove only pods using the requested sriov resources", func() {
+			n, _ := createNode("node2")
+			resources := map[corev1.ResourceName]resource.Quantity{
+				corev1.ResourceName(fmt.Sprintf("%s/test", vars.ResourcePrefix)):  resource.MustParse("1"),
+				corev1.ResourceName(fmt.Sprintf("%s/other", vars.ResourcePrefix)): resource.MustParse("1")}
+			n.Status.Allocatable = resources
+			n.Status.Capacity = resources
+			err := k8sClient.Status().Update(ctx, n)
+			Expect(err).ToNot(HaveOccurred())
+			platformHelper.EXPECT().OpenshiftBeforeDrainNode(ctx, n).Return(true, nil)
+			platformHelper.EXPECT().AcquireNode(ctx, n).Return(true, nil)
+			createPodWithSriovResourceOnNode(ctx, "sriov-pod-other", "node2", "other")
+			createPodWithSriovResourceOnNode(ctx, "sriov-pod-test", "node2", "test")
+
+			go func() {
+				Eventually(func(g Gomega) {
+					podObj := &corev1.Pod{}
+					err = k8sClient.Get(ctx, client.ObjectKey{Name: "sriov-pod-test", Namespace: testNamespace}, podObj)
+					g.Expect(err).ToNot(HaveOccurred())
+					g.Expect(podObj.DeletionTimestamp).ToNot(BeNil())
+					err = k8sClient.Delete(ctx, podObj, &client.DeleteOptions{GracePeriodSeconds: pointer.Int64(0)})
+					g.Expect(err).ToNot(HaveOccurred())
+				}, 2*time.Minute, time.Second).Should(Succeed())
+			}()
+
+			_, err = drn.DrainNode(ctx, n, false, false, []string{"test"})
+			Expect(err).ToNot(HaveOccurred())
+			pod := &corev1.Pod{}
+			err = k8sClient.Get(ctx, client.ObjectKey{Name: "sriov-pod-other", Namespace: testNamespace}, pod)
+			Expect(err).ToNot(HaveOccurred())
+			err = k8sClient.Get(ctx, client.ObjectKey{Name: "sriov-pod-test", Namespace: testNamespace}, pod)
+			Expect(err).To(HaveOccurred())
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
 	})
 
 	Context("CompleteDrain", func() {
@@ -194,6 +268,7 @@ var _ = Describe("Drainer", Ordered, func() {
 			n, _ := createNode("node0")
 			n.Spec.Unschedulable = true
 			platformHelper.EXPECT().OpenshiftAfterCompleteDrainNode(ctx, n).Return(true, nil)
+			platformHelper.EXPECT().ReleaseNode(ctx, n).Return(nil)
 
 			completed, err := drn.CompleteDrainNode(ctx, n)
 			Expect(err).ToNot(HaveOccurred())
@@ -249,7 +324,11 @@ func createPodWithFinalizerOnNode(ctx context.Context, podName, nodeName string)
 }
 
 func createPodWithSriovDeviceOnNode(ctx context.Context, podName, nodeName string) {
-	resources := map[corev1.ResourceName]resource.Quantity{corev1.ResourceName(fmt.Sprintf("%s/test", vars.ResourcePrefix)): resource.MustParse("1")}
+	createPodWithSriovResourceOnNode(ctx, podName, nodeName, "test")
+}
+
+func createPodWithSriovResourceOnNode(ctx context.Context, podName, nodeName, resourceName string) {
+	resources := map[corev1.ResourceName]resource.Quantity{corev1.ResourceName(fmt.Sprintf("%s/%s", vars.ResourcePrefix, resourceName)): resource.MustParse("1")}
 	pod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: podName, Namespace: testNamespace},
 		Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "test", Image: "test", Command: []string{"test"},
 			Resources: corev1.ResourceRequirements{Requests: resources, Limits: resources}}},
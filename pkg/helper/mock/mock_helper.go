@@ -72,6 +72,34 @@ func (mr *MockHostHelpersInterfaceMockRecorder) AddPersistPFNameUdevRule(pfPciAd
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPersistPFNameUdevRule", reflect.TypeOf((*MockHostHelpersInterface)(nil).AddPersistPFNameUdevRule), pfPciAddress, pfName)
 }
 
+// AddUnmanagedVfsConfig mocks base method.
+func (m *MockHostHelpersInterface) AddUnmanagedVfsConfig(pfPciAddress string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddUnmanagedVfsConfig", pfPciAddress)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddUnmanagedVfsConfig indicates an expected call of AddUnmanagedVfsConfig.
+func (mr *MockHostHelpersInterfaceMockRecorder) AddUnmanagedVfsConfig(pfPciAddress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUnmanagedVfsConfig", reflect.TypeOf((*MockHostHelpersInterface)(nil).AddUnmanagedVfsConfig), pfPciAddress)
+}
+
+// AddVfNameUdevRule mocks base method.
+func (m *MockHostHelpersInterface) AddVfNameUdevRule(pfPciAddress, pfName, template string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddVfNameUdevRule", pfPciAddress, pfName, template)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddVfNameUdevRule indicates an expected call of AddVfNameUdevRule.
+func (mr *MockHostHelpersInterfaceMockRecorder) AddVfNameUdevRule(pfPciAddress, pfName, template any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddVfNameUdevRule", reflect.TypeOf((*MockHostHelpersInterface)(nil).AddVfNameUdevRule), pfPciAddress, pfName, template)
+}
+
 // AddVfRepresentorUdevRule mocks base method.
 func (m *MockHostHelpersInterface) AddVfRepresentorUdevRule(pfPciAddress, pfName, pfSwitchID, pfSwitchPort string) error {
 	m.ctrl.T.Helper()
@@ -243,6 +271,20 @@ func (mr *MockHostHelpersInterfaceMockRecorder) ConfigureBridges(bridgesSpec, br
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigureBridges", reflect.TypeOf((*MockHostHelpersInterface)(nil).ConfigureBridges), bridgesSpec, bridgesStatus)
 }
 
+// ConfigureOVSHwOffload mocks base method.
+func (m *MockHostHelpersInterface) ConfigureOVSHwOffload(conf *v1.OvsHardwareOffload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfigureOVSHwOffload", conf)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfigureOVSHwOffload indicates an expected call of ConfigureOVSHwOffload.
+func (mr *MockHostHelpersInterfaceMockRecorder) ConfigureOVSHwOffload(conf any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigureOVSHwOffload", reflect.TypeOf((*MockHostHelpersInterface)(nil).ConfigureOVSHwOffload), conf)
+}
+
 // ConfigureVfGUID mocks base method.
 func (m *MockHostHelpersInterface) ConfigureVfGUID(vfAddr, pfAddr string, vfID int, pfLink netlink.Link) error {
 	m.ctrl.T.Helper()
@@ -386,6 +428,21 @@ func (mr *MockHostHelpersInterfaceMockRecorder) EnableService(service any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableService", reflect.TypeOf((*MockHostHelpersInterface)(nil).EnableService), service)
 }
 
+// GetBootID mocks base method.
+func (m *MockHostHelpersInterface) GetBootID() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBootID")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBootID indicates an expected call of GetBootID.
+func (mr *MockHostHelpersInterfaceMockRecorder) GetBootID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBootID", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetBootID))
+}
+
 // GetCPUVendor mocks base method.
 func (m *MockHostHelpersInterface) GetCPUVendor() (types.CPUVendor, error) {
 	m.ctrl.T.Helper()
@@ -431,6 +488,21 @@ func (mr *MockHostHelpersInterfaceMockRecorder) GetCurrentKernelArgs() *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentKernelArgs", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetCurrentKernelArgs))
 }
 
+// GetDeviceNumaNode mocks base method.
+func (m *MockHostHelpersInterface) GetDeviceNumaNode(pciAddr string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeviceNumaNode", pciAddr)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeviceNumaNode indicates an expected call of GetDeviceNumaNode.
+func (mr *MockHostHelpersInterfaceMockRecorder) GetDeviceNumaNode(pciAddr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeviceNumaNode", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetDeviceNumaNode), pciAddr)
+}
+
 // GetDevlinkDeviceParam mocks base method.
 func (m *MockHostHelpersInterface) GetDevlinkDeviceParam(pciAddr, paramName string) (string, error) {
 	m.ctrl.T.Helper()
@@ -461,6 +533,21 @@ func (mr *MockHostHelpersInterfaceMockRecorder) GetDriverByBusAndDevice(bus, dev
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDriverByBusAndDevice", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetDriverByBusAndDevice), bus, device)
 }
 
+// GetHugepages mocks base method.
+func (m *MockHostHelpersInterface) GetHugepages() ([]v1.HugepagesStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHugepages")
+	ret0, _ := ret[0].([]v1.HugepagesStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHugepages indicates an expected call of GetHugepages.
+func (mr *MockHostHelpersInterfaceMockRecorder) GetHugepages() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHugepages", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetHugepages))
+}
+
 // GetInterfaceIndex mocks base method.
 func (m *MockHostHelpersInterface) GetInterfaceIndex(pciAddr string) (int, error) {
 	m.ctrl.T.Helper()
@@ -476,6 +563,21 @@ func (mr *MockHostHelpersInterfaceMockRecorder) GetInterfaceIndex(pciAddr any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterfaceIndex", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetInterfaceIndex), pciAddr)
 }
 
+// GetKernelRelease mocks base method.
+func (m *MockHostHelpersInterface) GetKernelRelease() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKernelRelease")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKernelRelease indicates an expected call of GetKernelRelease.
+func (mr *MockHostHelpersInterfaceMockRecorder) GetKernelRelease() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKernelRelease", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetKernelRelease))
+}
+
 // GetLinkType mocks base method.
 func (m *MockHostHelpersInterface) GetLinkType(name string) string {
 	m.ctrl.T.Helper()
@@ -605,6 +707,21 @@ func (mr *MockHostHelpersInterfaceMockRecorder) GetNicSriovMode(pciAddr any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNicSriovMode", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetNicSriovMode), pciAddr)
 }
 
+// GetOVSHwOffload mocks base method.
+func (m *MockHostHelpersInterface) GetOVSHwOffload() (*v1.OvsHardwareOffload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOVSHwOffload")
+	ret0, _ := ret[0].(*v1.OvsHardwareOffload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOVSHwOffload indicates an expected call of GetOVSHwOffload.
+func (mr *MockHostHelpersInterfaceMockRecorder) GetOVSHwOffload() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOVSHwOffload", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetOVSHwOffload))
+}
+
 // GetPciAddressFromInterfaceName mocks base method.
 func (m *MockHostHelpersInterface) GetPciAddressFromInterfaceName(interfaceName string) (string, error) {
 	m.ctrl.T.Helper()
@@ -708,6 +825,21 @@ func (mr *MockHostHelpersInterfaceMockRecorder) IsKernelModuleLoaded(name any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsKernelModuleLoaded", reflect.TypeOf((*MockHostHelpersInterface)(nil).IsKernelModuleLoaded), name)
 }
 
+// IsKernelModuleParamsSet mocks base method.
+func (m *MockHostHelpersInterface) IsKernelModuleParamsSet(name string, params []string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsKernelModuleParamsSet", name, params)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsKernelModuleParamsSet indicates an expected call of IsKernelModuleParamsSet.
+func (mr *MockHostHelpersInterfaceMockRecorder) IsKernelModuleParamsSet(name, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsKernelModuleParamsSet", reflect.TypeOf((*MockHostHelpersInterface)(nil).IsKernelModuleParamsSet), name, params)
+}
+
 // IsServiceEnabled mocks base method.
 func (m *MockHostHelpersInterface) IsServiceEnabled(servicePath string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -738,6 +870,20 @@ func (mr *MockHostHelpersInterfaceMockRecorder) IsServiceExist(servicePath any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsServiceExist", reflect.TypeOf((*MockHostHelpersInterface)(nil).IsServiceExist), servicePath)
 }
 
+// IsSriovNumVfsResizeSupported mocks base method.
+func (m *MockHostHelpersInterface) IsSriovNumVfsResizeSupported(pciAddr string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsSriovNumVfsResizeSupported", pciAddr)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsSriovNumVfsResizeSupported indicates an expected call of IsSriovNumVfsResizeSupported.
+func (mr *MockHostHelpersInterfaceMockRecorder) IsSriovNumVfsResizeSupported(pciAddr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSriovNumVfsResizeSupported", reflect.TypeOf((*MockHostHelpersInterface)(nil).IsSriovNumVfsResizeSupported), pciAddr)
+}
+
 // IsSwitchdev mocks base method.
 func (m *MockHostHelpersInterface) IsSwitchdev(name string) bool {
 	m.ctrl.T.Helper()
@@ -752,6 +898,22 @@ func (mr *MockHostHelpersInterfaceMockRecorder) IsSwitchdev(name any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSwitchdev", reflect.TypeOf((*MockHostHelpersInterface)(nil).IsSwitchdev), name)
 }
 
+// LoadKernelArgsState mocks base method.
+func (m *MockHostHelpersInterface) LoadKernelArgsState() (*store.KernelArgsState, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadKernelArgsState")
+	ret0, _ := ret[0].(*store.KernelArgsState)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// LoadKernelArgsState indicates an expected call of LoadKernelArgsState.
+func (mr *MockHostHelpersInterfaceMockRecorder) LoadKernelArgsState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadKernelArgsState", reflect.TypeOf((*MockHostHelpersInterface)(nil).LoadKernelArgsState))
+}
+
 // LoadKernelModule mocks base method.
 func (m *MockHostHelpersInterface) LoadKernelModule(name string, args ...string) error {
 	m.ctrl.T.Helper()
@@ -771,6 +933,21 @@ func (mr *MockHostHelpersInterfaceMockRecorder) LoadKernelModule(name any, args
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadKernelModule", reflect.TypeOf((*MockHostHelpersInterface)(nil).LoadKernelModule), varargs...)
 }
 
+// LoadManagedKernelConfig mocks base method.
+func (m *MockHostHelpersInterface) LoadManagedKernelConfig() (*store.ManagedKernelConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadManagedKernelConfig")
+	ret0, _ := ret[0].(*store.ManagedKernelConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadManagedKernelConfig indicates an expected call of LoadManagedKernelConfig.
+func (mr *MockHostHelpersInterfaceMockRecorder) LoadManagedKernelConfig() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadManagedKernelConfig", reflect.TypeOf((*MockHostHelpersInterface)(nil).LoadManagedKernelConfig))
+}
+
 // LoadPfsStatus mocks base method.
 func (m *MockHostHelpersInterface) LoadPfsStatus(pciAddress string) (*v1.Interface, bool, error) {
 	m.ctrl.T.Helper()
@@ -859,6 +1036,20 @@ func (mr *MockHostHelpersInterfaceMockRecorder) PrepareNMUdevRule(supportedVfIds
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrepareNMUdevRule", reflect.TypeOf((*MockHostHelpersInterface)(nil).PrepareNMUdevRule), supportedVfIds)
 }
 
+// PrepareVFNameUdevRule mocks base method.
+func (m *MockHostHelpersInterface) PrepareVFNameUdevRule() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PrepareVFNameUdevRule")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PrepareVFNameUdevRule indicates an expected call of PrepareVFNameUdevRule.
+func (mr *MockHostHelpersInterfaceMockRecorder) PrepareVFNameUdevRule() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrepareVFNameUdevRule", reflect.TypeOf((*MockHostHelpersInterface)(nil).PrepareVFNameUdevRule))
+}
+
 // PrepareVFRepUdevRule mocks base method.
 func (m *MockHostHelpersInterface) PrepareVFRepUdevRule() error {
 	m.ctrl.T.Helper()
@@ -991,6 +1182,20 @@ func (mr *MockHostHelpersInterfaceMockRecorder) RemoveDisableNMUdevRule(pfPciAdd
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDisableNMUdevRule", reflect.TypeOf((*MockHostHelpersInterface)(nil).RemoveDisableNMUdevRule), pfPciAddress)
 }
 
+// RemoveKernelArgsState mocks base method.
+func (m *MockHostHelpersInterface) RemoveKernelArgsState() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveKernelArgsState")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveKernelArgsState indicates an expected call of RemoveKernelArgsState.
+func (mr *MockHostHelpersInterfaceMockRecorder) RemoveKernelArgsState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveKernelArgsState", reflect.TypeOf((*MockHostHelpersInterface)(nil).RemoveKernelArgsState))
+}
+
 // RemovePersistPFNameUdevRule mocks base method.
 func (m *MockHostHelpersInterface) RemovePersistPFNameUdevRule(pfPciAddress string) error {
 	m.ctrl.T.Helper()
@@ -1033,6 +1238,34 @@ func (mr *MockHostHelpersInterfaceMockRecorder) RemoveSriovResult() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSriovResult", reflect.TypeOf((*MockHostHelpersInterface)(nil).RemoveSriovResult))
 }
 
+// RemoveUnmanagedVfsConfig mocks base method.
+func (m *MockHostHelpersInterface) RemoveUnmanagedVfsConfig(pfPciAddress string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveUnmanagedVfsConfig", pfPciAddress)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveUnmanagedVfsConfig indicates an expected call of RemoveUnmanagedVfsConfig.
+func (mr *MockHostHelpersInterfaceMockRecorder) RemoveUnmanagedVfsConfig(pfPciAddress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUnmanagedVfsConfig", reflect.TypeOf((*MockHostHelpersInterface)(nil).RemoveUnmanagedVfsConfig), pfPciAddress)
+}
+
+// RemoveVfNameUdevRule mocks base method.
+func (m *MockHostHelpersInterface) RemoveVfNameUdevRule(pfPciAddress string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveVfNameUdevRule", pfPciAddress)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveVfNameUdevRule indicates an expected call of RemoveVfNameUdevRule.
+func (mr *MockHostHelpersInterfaceMockRecorder) RemoveVfNameUdevRule(pfPciAddress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveVfNameUdevRule", reflect.TypeOf((*MockHostHelpersInterface)(nil).RemoveVfNameUdevRule), pfPciAddress)
+}
+
 // RemoveVfRepresentorUdevRule mocks base method.
 func (m *MockHostHelpersInterface) RemoveVfRepresentorUdevRule(pfPciAddress string) error {
 	m.ctrl.T.Helper()
@@ -1082,6 +1315,20 @@ func (mr *MockHostHelpersInterfaceMockRecorder) RunCommand(arg0 any, arg1 ...any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunCommand", reflect.TypeOf((*MockHostHelpersInterface)(nil).RunCommand), varargs...)
 }
 
+// SaveKernelArgsState mocks base method.
+func (m *MockHostHelpersInterface) SaveKernelArgsState(state *store.KernelArgsState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveKernelArgsState", state)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveKernelArgsState indicates an expected call of SaveKernelArgsState.
+func (mr *MockHostHelpersInterfaceMockRecorder) SaveKernelArgsState(state any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveKernelArgsState", reflect.TypeOf((*MockHostHelpersInterface)(nil).SaveKernelArgsState), state)
+}
+
 // SaveLastPfAppliedStatus mocks base method.
 func (m *MockHostHelpersInterface) SaveLastPfAppliedStatus(PfInfo *v1.Interface) error {
 	m.ctrl.T.Helper()
@@ -1096,6 +1343,20 @@ func (mr *MockHostHelpersInterfaceMockRecorder) SaveLastPfAppliedStatus(PfInfo a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveLastPfAppliedStatus", reflect.TypeOf((*MockHostHelpersInterface)(nil).SaveLastPfAppliedStatus), PfInfo)
 }
 
+// SaveManagedKernelConfig mocks base method.
+func (m *MockHostHelpersInterface) SaveManagedKernelConfig(config *store.ManagedKernelConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveManagedKernelConfig", config)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveManagedKernelConfig indicates an expected call of SaveManagedKernelConfig.
+func (mr *MockHostHelpersInterfaceMockRecorder) SaveManagedKernelConfig(config any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveManagedKernelConfig", reflect.TypeOf((*MockHostHelpersInterface)(nil).SaveManagedKernelConfig), config)
+}
+
 // SetDevlinkDeviceParam mocks base method.
 func (m *MockHostHelpersInterface) SetDevlinkDeviceParam(pciAddr, paramName, value string) error {
 	m.ctrl.T.Helper()
@@ -1110,6 +1371,34 @@ func (mr *MockHostHelpersInterfaceMockRecorder) SetDevlinkDeviceParam(pciAddr, p
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDevlinkDeviceParam", reflect.TypeOf((*MockHostHelpersInterface)(nil).SetDevlinkDeviceParam), pciAddr, paramName, value)
 }
 
+// SetHugepages mocks base method.
+func (m *MockHostHelpersInterface) SetHugepages(numaNode int, size string, count int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHugepages", numaNode, size, count)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHugepages indicates an expected call of SetHugepages.
+func (mr *MockHostHelpersInterfaceMockRecorder) SetHugepages(numaNode, size, count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHugepages", reflect.TypeOf((*MockHostHelpersInterface)(nil).SetHugepages), numaNode, size, count)
+}
+
+// SetKernelModuleParams mocks base method.
+func (m *MockHostHelpersInterface) SetKernelModuleParams(name string, params []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetKernelModuleParams", name, params)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetKernelModuleParams indicates an expected call of SetKernelModuleParams.
+func (mr *MockHostHelpersInterfaceMockRecorder) SetKernelModuleParams(name, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetKernelModuleParams", reflect.TypeOf((*MockHostHelpersInterface)(nil).SetKernelModuleParams), name, params)
+}
+
 // SetNetdevMTU mocks base method.
 func (m *MockHostHelpersInterface) SetNetdevMTU(pciAddr string, mtu int) error {
 	m.ctrl.T.Helper()
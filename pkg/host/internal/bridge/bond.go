@@ -0,0 +1,171 @@
+package bridge
+
+import (
+	"errors"
+
+	"github.com/vishvananda/netlink"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	netlinkLibPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/netlink"
+)
+
+const defaultBondMode = "active-backup"
+
+// ensureBond creates the Linux bond used as the uplink of the bridge and attaches the uplinks(PFs) to it.
+// The bond is recreated if its mode or miimon doesn't match the configuration.
+// The PFs must be in switchdev mode before they are attached to the bond, e.g. to enable VF LAG.
+func (b *bridge) ensureBond(conf *sriovnetworkv1.OVSConfigExt) error {
+	funcLog := log.Log.WithValues("bridge", conf.Name, "bond", conf.Bond.Name)
+	funcLog.V(2).Info("ensureBond(): configure bond")
+	mode := conf.Bond.Mode
+	if mode == "" {
+		mode = defaultBondMode
+	}
+	bondLink, err := b.getLinkByName(conf.Bond.Name)
+	if err != nil {
+		funcLog.Error(err, "ensureBond(): failed to get bond link")
+		return err
+	}
+	if bondLink != nil {
+		bond, isBond := bondLink.(*netlink.Bond)
+		if !isBond || bond.Mode != netlink.StringToBondMode(mode) || bond.Miimon != conf.Bond.Miimon {
+			funcLog.V(2).Info("ensureBond(): bond configuration differs, recreate the bond")
+			if err := b.netlinkLib.LinkDel(bondLink); err != nil {
+				funcLog.Error(err, "ensureBond(): failed to remove the bond")
+				return err
+			}
+			bondLink = nil
+		}
+	}
+	if bondLink == nil {
+		newBond := netlink.NewLinkBond(netlink.LinkAttrs{Name: conf.Bond.Name})
+		newBond.Mode = netlink.StringToBondMode(mode)
+		newBond.Miimon = conf.Bond.Miimon
+		if err := b.netlinkLib.LinkAdd(newBond); err != nil {
+			funcLog.Error(err, "ensureBond(): failed to create the bond")
+			return err
+		}
+		bondLink, err = b.netlinkLib.LinkByName(conf.Bond.Name)
+		if err != nil {
+			funcLog.Error(err, "ensureBond(): failed to get the created bond")
+			return err
+		}
+	}
+	for _, uplink := range conf.Uplinks {
+		pfLink, err := b.netlinkLib.LinkByName(uplink.Name)
+		if err != nil {
+			funcLog.Error(err, "ensureBond(): failed to get PF link", "pf", uplink.Name)
+			return err
+		}
+		if pfLink.Attrs().MasterIndex == bondLink.Attrs().Index {
+			continue
+		}
+		funcLog.V(2).Info("ensureBond(): attach PF to the bond", "pf", uplink.Name)
+		if pfLink.Attrs().MasterIndex != 0 {
+			if err := b.netlinkLib.LinkSetNoMaster(pfLink); err != nil {
+				funcLog.Error(err, "ensureBond(): failed to detach PF from its master", "pf", uplink.Name)
+				return err
+			}
+		}
+		// the bond members must be down when they are attached
+		if err := b.netlinkLib.LinkSetDown(pfLink); err != nil {
+			funcLog.Error(err, "ensureBond(): failed to set PF down", "pf", uplink.Name)
+			return err
+		}
+		if err := b.netlinkLib.LinkSetMaster(pfLink, bondLink); err != nil {
+			funcLog.Error(err, "ensureBond(): failed to attach PF to the bond", "pf", uplink.Name)
+			return err
+		}
+	}
+	if err := b.netlinkLib.LinkSetUp(bondLink); err != nil {
+		funcLog.Error(err, "ensureBond(): failed to set bond up")
+		return err
+	}
+	return nil
+}
+
+// removeBond removes the Linux bond, the members are released by the kernel
+func (b *bridge) removeBond(name string) error {
+	log.Log.V(2).Info("removeBond(): remove bond", "bond", name)
+	bondLink, err := b.getLinkByName(name)
+	if err != nil {
+		return err
+	}
+	if bondLink == nil {
+		return nil
+	}
+	return b.netlinkLib.LinkDel(bondLink)
+}
+
+// detachFromManagedBond detaches the PF from the bond of a managed bridge,
+// the PF can't change its eSwitch mode while it is a member of the bond
+func (b *bridge) detachFromManagedBond(pciAddr string) error {
+	knownConfigs, err := b.store.GetManagedOVSBridges()
+	if err != nil {
+		log.Log.Error(err, "detachFromManagedBond(): failed to read data from store")
+		return err
+	}
+	for _, conf := range knownConfigs {
+		if conf.Bond == nil {
+			continue
+		}
+		for _, uplink := range conf.Uplinks {
+			if uplink.PciAddress != pciAddr {
+				continue
+			}
+			pfLink, err := b.getLinkByName(uplink.Name)
+			if err != nil {
+				return err
+			}
+			if pfLink == nil || pfLink.Attrs().MasterIndex == 0 {
+				return nil
+			}
+			log.Log.V(2).Info("detachFromManagedBond(): detach PF from the bond", "pf", uplink.Name, "bond", conf.Bond.Name)
+			return b.netlinkLib.LinkSetNoMaster(pfLink)
+		}
+	}
+	return nil
+}
+
+// filterBondMembers removes from the bridge state the uplinks which are not attached to the bond
+func (b *bridge) filterBondMembers(br *sriovnetworkv1.OVSConfigExt) error {
+	if br.Bond == nil || len(br.Uplinks) == 0 {
+		return nil
+	}
+	bondLink, err := b.getLinkByName(br.Bond.Name)
+	if err != nil {
+		return err
+	}
+	members := make([]sriovnetworkv1.OVSUplinkConfigExt, 0, len(br.Uplinks))
+	for _, uplink := range br.Uplinks {
+		if bondLink == nil {
+			break
+		}
+		pfLink, err := b.getLinkByName(uplink.Name)
+		if err != nil {
+			return err
+		}
+		if pfLink != nil && pfLink.Attrs().MasterIndex == bondLink.Attrs().Index {
+			members = append(members, uplink)
+		}
+	}
+	if len(members) == 0 {
+		members = nil
+	}
+	br.Uplinks = members
+	return nil
+}
+
+// getLinkByName returns the link, nil is returned if the link doesn't exist
+func (b *bridge) getLinkByName(name string) (netlinkLibPkg.Link, error) {
+	link, err := b.netlinkLib.LinkByName(name)
+	if err != nil {
+		var notFound netlink.LinkNotFoundError
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return link, nil
+}
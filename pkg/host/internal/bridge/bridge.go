@@ -2,24 +2,71 @@ package bridge
 
 import (
 	"context"
+	"fmt"
+	"slices"
 
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/bridge/linux"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/bridge/ovs"
 	ovsStorePkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/bridge/ovs/store"
+	netlinkLibPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/netlink"
+	sriovnetPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/sriovnet"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/types"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils"
+)
+
+const (
+	// systemd service of the OVS daemon, restarted to apply the hardware offload settings
+	ovsVswitchdService = "ovs-vswitchd"
 )
 
 type bridge struct {
-	ovs ovs.Interface
+	utilsHelper utils.CmdInterface
+	ovs         ovs.Interface
+	linux       linux.Interface
+	store       ovsStorePkg.Store
+	netlinkLib  netlinkLibPkg.NetlinkLib
 }
 
 // New return default implementation of the BridgeInterface
-func New() types.BridgeInterface {
+func New(utilsHelper utils.CmdInterface, netlinkLib netlinkLibPkg.NetlinkLib, networkHelper types.NetworkInterface,
+	sriovnetLib sriovnetPkg.SriovnetLib) types.BridgeInterface {
+	store := ovsStorePkg.New()
 	return &bridge{
-		ovs: ovs.New(ovsStorePkg.New()),
+		utilsHelper: utilsHelper,
+		ovs:         ovs.New(store, sriovnetLib),
+		linux:       linux.New(netlinkLib, networkHelper),
+		store:       store,
+		netlinkLib:  netlinkLib,
+	}
+}
+
+// GetOVSHwOffload returns the OVS hardware offload settings of the host
+func (b *bridge) GetOVSHwOffload() (*sriovnetworkv1.OvsHardwareOffload, error) {
+	return b.ovs.GetHwOffloadConfig(context.Background())
+}
+
+// ConfigureOVSHwOffload applies the OVS hardware offload settings,
+// the OVS daemon is restarted if the settings were changed.
+// The generic plugin requests a drain of the node before the settings are applied.
+func (b *bridge) ConfigureOVSHwOffload(conf *sriovnetworkv1.OvsHardwareOffload) error {
+	log.Log.V(1).Info("ConfigureOVSHwOffload(): configure OVS hardware offload", "enabled", conf.Enabled, "tcPolicy", conf.TcPolicy)
+	changed, err := b.ovs.SetHwOffloadConfig(context.Background(), conf)
+	if err != nil {
+		log.Log.Error(err, "ConfigureOVSHwOffload(): failed to set OVS hardware offload settings")
+		return err
 	}
+	if !changed {
+		return nil
+	}
+	log.Log.Info("ConfigureOVSHwOffload(): restart OVS to apply hardware offload settings", "service", ovsVswitchdService)
+	if _, stderr, err := b.utilsHelper.RunCommand("systemctl", "restart", ovsVswitchdService); err != nil {
+		log.Log.Error(err, "ConfigureOVSHwOffload(): failed to restart OVS", "stderr", stderr)
+		return fmt.Errorf("failed to restart %s: %v", ovsVswitchdService, err)
+	}
+	return nil
 }
 
 // DiscoverBridges returns information about managed bridges on the host
@@ -30,13 +77,26 @@ func (b *bridge) DiscoverBridges() (sriovnetworkv1.Bridges, error) {
 		log.Log.Error(err, "DiscoverBridges(): failed to discover managed OVS bridges")
 		return sriovnetworkv1.Bridges{}, err
 	}
-	return sriovnetworkv1.Bridges{OVS: discoveredOVSBridges}, nil
+	for i := range discoveredOVSBridges {
+		// report only the PFs attached to the bond to let the operator fix the bond
+		if err := b.filterBondMembers(&discoveredOVSBridges[i]); err != nil {
+			log.Log.Error(err, "DiscoverBridges(): failed to discover bond members", "bridge", discoveredOVSBridges[i].Name)
+			return sriovnetworkv1.Bridges{}, err
+		}
+	}
+	discoveredLinuxBridges, err := b.linux.GetLinuxBridges()
+	if err != nil {
+		log.Log.Error(err, "DiscoverBridges(): failed to discover managed Linux bridges")
+		return sriovnetworkv1.Bridges{}, err
+	}
+	return sriovnetworkv1.Bridges{OVS: discoveredOVSBridges, Linux: discoveredLinuxBridges}, nil
 }
 
 // ConfigureBridge configure managed bridges for the host
 func (b *bridge) ConfigureBridges(bridgesSpec sriovnetworkv1.Bridges, bridgesStatus sriovnetworkv1.Bridges) error {
 	log.Log.V(1).Info("ConfigureBridges(): configure bridges")
-	if len(bridgesSpec.OVS) == 0 && len(bridgesStatus.OVS) == 0 {
+	if len(bridgesSpec.OVS) == 0 && len(bridgesStatus.OVS) == 0 &&
+		len(bridgesSpec.Linux) == 0 && len(bridgesStatus.Linux) == 0 {
 		// there are no reported OVS bridges in the status and the spec doesn't contains bridges.
 		// no need to validated configuration
 		log.Log.V(2).Info("ConfigureBridges(): configuration is not required")
@@ -55,16 +115,53 @@ func (b *bridge) ConfigureBridges(bridgesSpec sriovnetworkv1.Bridges, bridgesSta
 				log.Log.Error(err, "ConfigureBridges(): failed to remove OVS bridge", "bridge", curBr.Name)
 				return err
 			}
+			if curBr.Bond != nil {
+				if err := b.removeBond(curBr.Bond.Name); err != nil {
+					log.Log.Error(err, "ConfigureBridges(): failed to remove bond", "bridge", curBr.Name)
+					return err
+				}
+			}
 		}
 	}
 	// create bridges, existing bridges will be updated only if the new config doesn't match current config
 	for i := range bridgesSpec.OVS {
 		desiredBr := bridgesSpec.OVS[i]
+		// the bond is created after the PFs are switched to switchdev mode
+		// and before it's attached to the bridge as the uplink
+		if desiredBr.Bond != nil {
+			if err := b.ensureBond(&desiredBr); err != nil {
+				log.Log.Error(err, "ConfigureBridges(): failed to configure bond", "bridge", desiredBr.Name)
+				return err
+			}
+		}
 		if err := b.ovs.CreateOVSBridge(context.Background(), &desiredBr); err != nil {
 			log.Log.Error(err, "ConfigureBridges(): failed to create OVS bridge", "bridge", desiredBr.Name)
 			return err
 		}
 	}
+	return b.configureLinuxBridges(bridgesSpec, bridgesStatus)
+}
+
+// configureLinuxBridges removes managed Linux bridges which are not in the spec and
+// creates or updates the bridges from the spec
+func (b *bridge) configureLinuxBridges(bridgesSpec sriovnetworkv1.Bridges, bridgesStatus sriovnetworkv1.Bridges) error {
+	for _, curBr := range bridgesStatus.Linux {
+		if slices.ContainsFunc(bridgesSpec.Linux, func(desiredBr sriovnetworkv1.LinuxBridgeConfigExt) bool {
+			return desiredBr.Name == curBr.Name
+		}) {
+			continue
+		}
+		if err := b.linux.RemoveLinuxBridge(curBr.Name); err != nil {
+			log.Log.Error(err, "ConfigureBridges(): failed to remove Linux bridge", "bridge", curBr.Name)
+			return err
+		}
+	}
+	for i := range bridgesSpec.Linux {
+		if err := b.linux.CreateLinuxBridge(&bridgesSpec.Linux[i]); err != nil {
+			log.Log.Error(err, "ConfigureBridges(): failed to create Linux bridge", "bridge", bridgesSpec.Linux[i].Name)
+			return err
+		}
+	}
 	return nil
 }
 
@@ -77,5 +174,13 @@ func (b *bridge) DetachInterfaceFromManagedBridge(pciAddr string) error {
 		log.Log.Error(err, "DetachInterfaceFromManagedBridge(): failed to detach interface from OVS bridge", "pciAddr", pciAddr)
 		return err
 	}
+	if err := b.detachFromManagedBond(pciAddr); err != nil {
+		log.Log.Error(err, "DetachInterfaceFromManagedBridge(): failed to detach interface from the bond", "pciAddr", pciAddr)
+		return err
+	}
+	if err := b.linux.RemoveInterfaceFromLinuxBridge(pciAddr); err != nil {
+		log.Log.Error(err, "DetachInterfaceFromManagedBridge(): failed to detach interface from Linux bridge", "pciAddr", pciAddr)
+		return err
+	}
 	return nil
 }
@@ -3,27 +3,40 @@ package bridge
 import (
 	"fmt"
 
+	"github.com/vishvananda/netlink"
 	"go.uber.org/mock/gomock"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	linuxMockPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/bridge/linux/mock"
 	ovsMockPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/bridge/ovs/mock"
+	ovsStoreMockPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/bridge/ovs/store/mock"
+	netlinkMockPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/netlink/mock"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/types"
+	utilsMockPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils/mock"
 )
 
 var _ = Describe("Bridge", func() {
 	var (
-		testCtrl *gomock.Controller
-		br       types.BridgeInterface
-		ovsMock  *ovsMockPkg.MockInterface
-		testErr  = fmt.Errorf("test")
+		testCtrl       *gomock.Controller
+		br             types.BridgeInterface
+		ovsMock        *ovsMockPkg.MockInterface
+		linuxMock      *linuxMockPkg.MockInterface
+		storeMock      *ovsStoreMockPkg.MockStore
+		netlinkLibMock *netlinkMockPkg.MockNetlinkLib
+		utilsMock      *utilsMockPkg.MockCmdInterface
+		testErr        = fmt.Errorf("test")
 	)
 	BeforeEach(func() {
 		testCtrl = gomock.NewController(GinkgoT())
 		ovsMock = ovsMockPkg.NewMockInterface(testCtrl)
-		br = &bridge{ovs: ovsMock}
+		linuxMock = linuxMockPkg.NewMockInterface(testCtrl)
+		storeMock = ovsStoreMockPkg.NewMockStore(testCtrl)
+		netlinkLibMock = netlinkMockPkg.NewMockNetlinkLib(testCtrl)
+		utilsMock = utilsMockPkg.NewMockCmdInterface(testCtrl)
+		br = &bridge{utilsHelper: utilsMock, ovs: ovsMock, linux: linuxMock, store: storeMock, netlinkLib: netlinkLibMock}
 	})
 	AfterEach(func() {
 		testCtrl.Finish()
@@ -31,9 +44,17 @@ var _ = Describe("Bridge", func() {
 	Context("DiscoverBridges", func() {
 		It("succeed", func() {
 			ovsMock.EXPECT().GetOVSBridges(gomock.Any()).Return([]sriovnetworkv1.OVSConfigExt{{Name: "test"}, {Name: "test2"}}, nil)
+			linuxMock.EXPECT().GetLinuxBridges().Return([]sriovnetworkv1.LinuxBridgeConfigExt{{Name: "test3"}}, nil)
 			ret, err := br.DiscoverBridges()
 			Expect(err).NotTo(HaveOccurred())
 			Expect(ret.OVS).To(HaveLen(2))
+			Expect(ret.Linux).To(HaveLen(1))
+		})
+		It("Linux bridges error", func() {
+			ovsMock.EXPECT().GetOVSBridges(gomock.Any()).Return(nil, nil)
+			linuxMock.EXPECT().GetLinuxBridges().Return(nil, testErr)
+			_, err := br.DiscoverBridges()
+			Expect(err).To(MatchError(testErr))
 		})
 		It("error", func() {
 			ovsMock.EXPECT().GetOVSBridges(gomock.Any()).Return(nil, testErr)
@@ -82,9 +103,136 @@ var _ = Describe("Bridge", func() {
 		})
 	})
 
+	Context("ConfigureBridges with Linux bridges", func() {
+		It("succeed", func() {
+			brCreate := sriovnetworkv1.LinuxBridgeConfigExt{Name: "br-to-create"}
+			brDelete := sriovnetworkv1.LinuxBridgeConfigExt{Name: "br-to-delete"}
+			linuxMock.EXPECT().RemoveLinuxBridge(brDelete.Name).Return(nil)
+			linuxMock.EXPECT().CreateLinuxBridge(&brCreate).Return(nil)
+			err := br.ConfigureBridges(
+				sriovnetworkv1.Bridges{Linux: []sriovnetworkv1.LinuxBridgeConfigExt{brCreate}},
+				sriovnetworkv1.Bridges{Linux: []sriovnetworkv1.LinuxBridgeConfigExt{brCreate, brDelete}})
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("failed on creation", func() {
+			brCreate := sriovnetworkv1.LinuxBridgeConfigExt{Name: "br-to-create"}
+			linuxMock.EXPECT().CreateLinuxBridge(&brCreate).Return(testErr)
+			err := br.ConfigureBridges(
+				sriovnetworkv1.Bridges{Linux: []sriovnetworkv1.LinuxBridgeConfigExt{brCreate}},
+				sriovnetworkv1.Bridges{})
+			Expect(err).To(MatchError(testErr))
+		})
+	})
+
+	Context("ConfigureBridges with bond", func() {
+		var (
+			bondBr sriovnetworkv1.OVSConfigExt
+			pf0    *netlink.Device
+			pf1    *netlink.Device
+		)
+		BeforeEach(func() {
+			bondBr = sriovnetworkv1.OVSConfigExt{
+				Name: "br-bond0",
+				Bond: &sriovnetworkv1.OVSBondConfig{Name: "bond0", Mode: "802.3ad", Miimon: 100},
+				Uplinks: []sriovnetworkv1.OVSUplinkConfigExt{
+					{PciAddress: "0000:d8:00.0", Name: "enp216s0f0np0"},
+					{PciAddress: "0000:d8:00.1", Name: "enp216s0f1np1"},
+				},
+			}
+			pf0 = &netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "enp216s0f0np0"}}
+			pf1 = &netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "enp216s0f1np1", MasterIndex: 10}}
+		})
+		It("create bond before the bridge", func() {
+			bond := &netlink.Bond{LinkAttrs: netlink.LinkAttrs{Name: "bond0", Index: 10},
+				Mode: netlink.BOND_MODE_802_3AD, Miimon: 100}
+			gomock.InOrder(
+				netlinkLibMock.EXPECT().LinkByName("bond0").Return(nil, netlink.LinkNotFoundError{}),
+				netlinkLibMock.EXPECT().LinkAdd(gomock.Any()).DoAndReturn(func(link netlink.Link) error {
+					Expect(link.(*netlink.Bond).Mode).To(Equal(netlink.BOND_MODE_802_3AD))
+					Expect(link.(*netlink.Bond).Miimon).To(Equal(100))
+					return nil
+				}),
+				netlinkLibMock.EXPECT().LinkByName("bond0").Return(bond, nil),
+				netlinkLibMock.EXPECT().LinkByName("enp216s0f0np0").Return(pf0, nil),
+				netlinkLibMock.EXPECT().LinkSetDown(pf0).Return(nil),
+				netlinkLibMock.EXPECT().LinkSetMaster(pf0, bond).Return(nil),
+				netlinkLibMock.EXPECT().LinkByName("enp216s0f1np1").Return(pf1, nil),
+				netlinkLibMock.EXPECT().LinkSetUp(bond).Return(nil),
+				ovsMock.EXPECT().CreateOVSBridge(gomock.Any(), &bondBr).Return(nil),
+			)
+			err := br.ConfigureBridges(
+				sriovnetworkv1.Bridges{OVS: []sriovnetworkv1.OVSConfigExt{bondBr}},
+				sriovnetworkv1.Bridges{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("recreate bond with a different mode", func() {
+			oldBond := &netlink.Bond{LinkAttrs: netlink.LinkAttrs{Name: "bond0", Index: 9},
+				Mode: netlink.BOND_MODE_ACTIVE_BACKUP, Miimon: 100}
+			bond := &netlink.Bond{LinkAttrs: netlink.LinkAttrs{Name: "bond0", Index: 10},
+				Mode: netlink.BOND_MODE_802_3AD, Miimon: 100}
+			netlinkLibMock.EXPECT().LinkByName("bond0").Return(oldBond, nil)
+			netlinkLibMock.EXPECT().LinkDel(oldBond).Return(nil)
+			netlinkLibMock.EXPECT().LinkAdd(gomock.Any()).Return(nil)
+			netlinkLibMock.EXPECT().LinkByName("bond0").Return(bond, nil)
+			netlinkLibMock.EXPECT().LinkByName("enp216s0f0np0").Return(pf0, nil)
+			netlinkLibMock.EXPECT().LinkSetDown(pf0).Return(nil)
+			netlinkLibMock.EXPECT().LinkSetMaster(pf0, bond).Return(nil)
+			netlinkLibMock.EXPECT().LinkByName("enp216s0f1np1").Return(pf1, nil)
+			netlinkLibMock.EXPECT().LinkSetUp(bond).Return(nil)
+			ovsMock.EXPECT().CreateOVSBridge(gomock.Any(), &bondBr).Return(nil)
+			err := br.ConfigureBridges(
+				sriovnetworkv1.Bridges{OVS: []sriovnetworkv1.OVSConfigExt{bondBr}},
+				sriovnetworkv1.Bridges{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("remove bond with the bridge", func() {
+			bond := &netlink.Bond{LinkAttrs: netlink.LinkAttrs{Name: "bond0", Index: 10}}
+			ovsMock.EXPECT().RemoveOVSBridge(gomock.Any(), "br-bond0").Return(nil)
+			netlinkLibMock.EXPECT().LinkByName("bond0").Return(bond, nil)
+			netlinkLibMock.EXPECT().LinkDel(bond).Return(nil)
+			err := br.ConfigureBridges(
+				sriovnetworkv1.Bridges{},
+				sriovnetworkv1.Bridges{OVS: []sriovnetworkv1.OVSConfigExt{bondBr}})
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("report only attached bond members", func() {
+			bond := &netlink.Bond{LinkAttrs: netlink.LinkAttrs{Name: "bond0", Index: 10}}
+			ovsMock.EXPECT().GetOVSBridges(gomock.Any()).Return([]sriovnetworkv1.OVSConfigExt{bondBr}, nil)
+			netlinkLibMock.EXPECT().LinkByName("bond0").Return(bond, nil)
+			netlinkLibMock.EXPECT().LinkByName("enp216s0f0np0").Return(pf0, nil)
+			netlinkLibMock.EXPECT().LinkByName("enp216s0f1np1").Return(pf1, nil)
+			linuxMock.EXPECT().GetLinuxBridges().Return(nil, nil)
+			ret, err := br.DiscoverBridges()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ret.OVS).To(HaveLen(1))
+			Expect(ret.OVS[0].Uplinks).To(Equal([]sriovnetworkv1.OVSUplinkConfigExt{
+				{PciAddress: "0000:d8:00.1", Name: "enp216s0f1np1"}}))
+		})
+	})
+
 	Context("DetachInterfaceFromManagedBridge", func() {
 		It("succeed", func() {
 			ovsMock.EXPECT().RemoveInterfaceFromOVSBridge(gomock.Any(), "0000:d8:00.0").Return(nil)
+			storeMock.EXPECT().GetManagedOVSBridges().Return(nil, nil)
+			linuxMock.EXPECT().RemoveInterfaceFromLinuxBridge("0000:d8:00.0").Return(nil)
+			err := br.DetachInterfaceFromManagedBridge("0000:d8:00.0")
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("detach from bond", func() {
+			ovsMock.EXPECT().RemoveInterfaceFromOVSBridge(gomock.Any(), "0000:d8:00.0").Return(nil)
+			storeMock.EXPECT().GetManagedOVSBridges().Return(map[string]*sriovnetworkv1.OVSConfigExt{
+				"br-bond0": {
+					Name: "br-bond0",
+					Bond: &sriovnetworkv1.OVSBondConfig{Name: "bond0"},
+					Uplinks: []sriovnetworkv1.OVSUplinkConfigExt{
+						{PciAddress: "0000:d8:00.0", Name: "enp216s0f0np0"},
+						{PciAddress: "0000:d8:00.1", Name: "enp216s0f1np1"},
+					},
+				}}, nil)
+			pfLink := &netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "enp216s0f0np0", MasterIndex: 10}}
+			netlinkLibMock.EXPECT().LinkByName("enp216s0f0np0").Return(pfLink, nil)
+			netlinkLibMock.EXPECT().LinkSetNoMaster(pfLink).Return(nil)
+			linuxMock.EXPECT().RemoveInterfaceFromLinuxBridge("0000:d8:00.0").Return(nil)
 			err := br.DetachInterfaceFromManagedBridge("0000:d8:00.0")
 			Expect(err).NotTo(HaveOccurred())
 		})
@@ -94,4 +242,21 @@ var _ = Describe("Bridge", func() {
 			Expect(err).To(MatchError(testErr))
 		})
 	})
+	Context("ConfigureOVSHwOffload", func() {
+		conf := &sriovnetworkv1.OvsHardwareOffload{Enabled: true}
+		It("restart OVS if settings changed", func() {
+			ovsMock.EXPECT().SetHwOffloadConfig(gomock.Any(), conf).Return(true, nil)
+			utilsMock.EXPECT().RunCommand("systemctl", "restart", "ovs-vswitchd").Return("", "", nil)
+			Expect(br.ConfigureOVSHwOffload(conf)).NotTo(HaveOccurred())
+		})
+		It("no restart if settings not changed", func() {
+			ovsMock.EXPECT().SetHwOffloadConfig(gomock.Any(), conf).Return(false, nil)
+			Expect(br.ConfigureOVSHwOffload(conf)).NotTo(HaveOccurred())
+		})
+		It("restart failed", func() {
+			ovsMock.EXPECT().SetHwOffloadConfig(gomock.Any(), conf).Return(true, nil)
+			utilsMock.EXPECT().RunCommand("systemctl", "restart", "ovs-vswitchd").Return("", "", testErr)
+			Expect(br.ConfigureOVSHwOffload(conf)).To(HaveOccurred())
+		})
+	})
 })
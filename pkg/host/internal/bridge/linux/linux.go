@@ -0,0 +1,353 @@
+package linux
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	netlinkLibPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/netlink"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/types"
+)
+
+// the alias is set on the Linux bridges created by the operator,
+// only the bridges with this alias are reported and removed
+const managedBridgeAlias = "managed-by-sriov-network-operator"
+
+// Interface provides functions to configure managed Linux bridges
+//
+//go:generate ../../../../../bin/mockgen -destination mock/mock_linux.go -source linux.go
+type Interface interface {
+	// CreateLinuxBridge creates Linux bridge from the provided config,
+	// if the bridge already exist it will be updated to match the config
+	CreateLinuxBridge(conf *sriovnetworkv1.LinuxBridgeConfigExt) error
+	// GetLinuxBridges returns configuration for all managed Linux bridges
+	GetLinuxBridges() ([]sriovnetworkv1.LinuxBridgeConfigExt, error)
+	// RemoveLinuxBridge removes managed Linux bridge by name
+	RemoveLinuxBridge(bridgeName string) error
+	// RemoveInterfaceFromLinuxBridge removes interface from the managed Linux bridge
+	RemoveInterfaceFromLinuxBridge(pciAddress string) error
+}
+
+// New creates new instance of the Linux bridge interface
+func New(netlinkLib netlinkLibPkg.NetlinkLib, networkHelper types.NetworkInterface) Interface {
+	return &linuxBridge{netlinkLib: netlinkLib, networkHelper: networkHelper}
+}
+
+type linuxBridge struct {
+	netlinkLib    netlinkLibPkg.NetlinkLib
+	networkHelper types.NetworkInterface
+}
+
+// uplinkPort contains information about the PF attached to the bridge
+type uplinkPort struct {
+	link       netlinkLibPkg.Link
+	pciAddress string
+}
+
+// CreateLinuxBridge creates Linux bridge from the provided config,
+// if the bridge already exist it will be updated to match the config
+func (l *linuxBridge) CreateLinuxBridge(conf *sriovnetworkv1.LinuxBridgeConfigExt) error {
+	funcLog := log.Log.WithValues("bridge", conf.Name)
+	funcLog.V(1).Info("CreateLinuxBridge(): start configuration of the Linux bridge")
+	brLink, err := l.getLinkByName(conf.Name)
+	if err != nil {
+		funcLog.Error(err, "CreateLinuxBridge(): failed to get bridge link")
+		return err
+	}
+	if brLink != nil && !isManagedBridge(brLink) {
+		err = fmt.Errorf("link %s already exist and is not a managed Linux bridge", conf.Name)
+		funcLog.Error(err, "CreateLinuxBridge(): can't configure the bridge")
+		return err
+	}
+	if brLink == nil {
+		funcLog.V(2).Info("CreateLinuxBridge(): create Linux bridge")
+		if err := l.netlinkLib.LinkAdd(&netlink.Bridge{LinkAttrs: netlink.LinkAttrs{Name: conf.Name}}); err != nil {
+			funcLog.Error(err, "CreateLinuxBridge(): failed to create the bridge")
+			return err
+		}
+		brLink, err = l.netlinkLib.LinkByName(conf.Name)
+		if err != nil {
+			funcLog.Error(err, "CreateLinuxBridge(): failed to get the created bridge")
+			return err
+		}
+		if err := l.netlinkLib.LinkSetAlias(brLink, managedBridgeAlias); err != nil {
+			funcLog.Error(err, "CreateLinuxBridge(): failed to mark the bridge as managed")
+			return err
+		}
+	}
+	if getVlanFiltering(brLink) != conf.Bridge.VlanFiltering {
+		funcLog.V(2).Info("CreateLinuxBridge(): set VLAN filtering", "vlanFiltering", conf.Bridge.VlanFiltering)
+		if err := l.netlinkLib.BridgeSetVlanFiltering(brLink, conf.Bridge.VlanFiltering); err != nil {
+			funcLog.Error(err, "CreateLinuxBridge(): failed to set VLAN filtering")
+			return err
+		}
+	}
+	if err := l.netlinkLib.LinkSetUp(brLink); err != nil {
+		funcLog.Error(err, "CreateLinuxBridge(): failed to set bridge up")
+		return err
+	}
+	currentUplinks, err := l.getBridgeUplinks(brLink)
+	if err != nil {
+		funcLog.Error(err, "CreateLinuxBridge(): failed to get current uplinks of the bridge")
+		return err
+	}
+	for _, cur := range currentUplinks {
+		if !slices.ContainsFunc(conf.Uplinks, func(uplink sriovnetworkv1.LinuxUplinkConfigExt) bool {
+			return uplink.PciAddress == cur.pciAddress
+		}) {
+			funcLog.V(2).Info("CreateLinuxBridge(): detach unexpected uplink from the bridge", "ifaceName", cur.link.Attrs().Name)
+			if err := l.netlinkLib.LinkSetNoMaster(cur.link); err != nil {
+				funcLog.Error(err, "CreateLinuxBridge(): failed to detach uplink from the bridge", "ifaceName", cur.link.Attrs().Name)
+				return err
+			}
+		}
+	}
+	var vlans map[int32][]*nl.BridgeVlanInfo
+	if conf.Bridge.VlanFiltering {
+		vlans, err = l.netlinkLib.BridgeVlanList()
+		if err != nil {
+			funcLog.Error(err, "CreateLinuxBridge(): failed to list VLANs")
+			return err
+		}
+	}
+	for i := range conf.Uplinks {
+		if err := l.configureUplink(brLink, &conf.Uplinks[i], conf.Bridge.VlanFiltering, vlans); err != nil {
+			funcLog.Error(err, "CreateLinuxBridge(): failed to configure uplink", "ifaceName", conf.Uplinks[i].Name)
+			return err
+		}
+	}
+	return nil
+}
+
+// configureUplink attaches the uplink to the bridge, enables TC flower offload on it
+// and configures tagged VLANs for the port if VLAN filtering is enabled
+func (l *linuxBridge) configureUplink(brLink netlinkLibPkg.Link, uplink *sriovnetworkv1.LinuxUplinkConfigExt,
+	vlanFiltering bool, vlans map[int32][]*nl.BridgeVlanInfo) error {
+	funcLog := log.Log.WithValues("bridge", brLink.Attrs().Name, "ifaceName", uplink.Name)
+	pfLink, err := l.netlinkLib.LinkByName(uplink.Name)
+	if err != nil {
+		return err
+	}
+	if pfLink.Attrs().MasterIndex != brLink.Attrs().Index {
+		funcLog.V(2).Info("configureUplink(): attach uplink to the bridge")
+		if pfLink.Attrs().MasterIndex != 0 {
+			if err := l.netlinkLib.LinkSetNoMaster(pfLink); err != nil {
+				return err
+			}
+		}
+		if err := l.netlinkLib.LinkSetMaster(pfLink, brLink); err != nil {
+			return err
+		}
+		// the port is attached with the default VLAN configuration
+		delete(vlans, int32(pfLink.Attrs().Index))
+	}
+	// TC flower rules are offloaded to the eSwitch through the uplink
+	if err := l.networkHelper.EnableHwTcOffload(uplink.Name); err != nil {
+		return err
+	}
+	if !vlanFiltering {
+		return nil
+	}
+	currentVlans := getTaggedVlans(vlans[int32(pfLink.Attrs().Index)])
+	for _, vid := range uplink.Interface.Vlans {
+		if slices.Contains(currentVlans, vid) {
+			continue
+		}
+		funcLog.V(2).Info("configureUplink(): add VLAN to the port", "vlan", vid)
+		if err := l.netlinkLib.BridgeVlanAdd(pfLink, uint16(vid), false, false, false, true); err != nil {
+			return err
+		}
+	}
+	for _, vid := range currentVlans {
+		if slices.Contains(uplink.Interface.Vlans, vid) {
+			continue
+		}
+		funcLog.V(2).Info("configureUplink(): remove VLAN from the port", "vlan", vid)
+		if err := l.netlinkLib.BridgeVlanDel(pfLink, uint16(vid), false, false, false, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetLinuxBridges returns configuration for all managed Linux bridges
+func (l *linuxBridge) GetLinuxBridges() ([]sriovnetworkv1.LinuxBridgeConfigExt, error) {
+	funcLog := log.Log
+	funcLog.V(1).Info("GetLinuxBridges(): get managed Linux bridges")
+	links, err := l.netlinkLib.LinkList()
+	if err != nil {
+		funcLog.Error(err, "GetLinuxBridges(): failed to list links")
+		return nil, err
+	}
+	var (
+		result []sriovnetworkv1.LinuxBridgeConfigExt
+		vlans  map[int32][]*nl.BridgeVlanInfo
+	)
+	for _, link := range links {
+		if !isManagedBridge(link) {
+			continue
+		}
+		br := sriovnetworkv1.LinuxBridgeConfigExt{
+			Name:   link.Attrs().Name,
+			Bridge: sriovnetworkv1.LinuxBridgeOptions{VlanFiltering: getVlanFiltering(link)},
+		}
+		if br.Bridge.VlanFiltering && vlans == nil {
+			vlans, err = l.netlinkLib.BridgeVlanList()
+			if err != nil {
+				funcLog.Error(err, "GetLinuxBridges(): failed to list VLANs")
+				return nil, err
+			}
+		}
+		uplinks, err := l.getBridgeUplinks(link)
+		if err != nil {
+			funcLog.Error(err, "GetLinuxBridges(): failed to get uplinks of the bridge", "bridge", br.Name)
+			return nil, err
+		}
+		for _, uplink := range uplinks {
+			uplinkConf := sriovnetworkv1.LinuxUplinkConfigExt{
+				PciAddress: uplink.pciAddress,
+				Name:       uplink.link.Attrs().Name,
+			}
+			if br.Bridge.VlanFiltering {
+				uplinkConf.Interface.Vlans = getTaggedVlans(vlans[int32(uplink.link.Attrs().Index)])
+			}
+			br.Uplinks = append(br.Uplinks, uplinkConf)
+		}
+		result = append(result, br)
+	}
+	// always return bridges in the same order to make sure that the caller can easily compare
+	// two results returned by the GetLinuxBridges function
+	slices.SortFunc(result, func(x, y sriovnetworkv1.LinuxBridgeConfigExt) int {
+		return strings.Compare(x.Name, y.Name)
+	})
+	return result, nil
+}
+
+// RemoveLinuxBridge removes managed Linux bridge by name
+func (l *linuxBridge) RemoveLinuxBridge(bridgeName string) error {
+	funcLog := log.Log.WithValues("bridge", bridgeName)
+	funcLog.V(1).Info("RemoveLinuxBridge(): remove managed bridge")
+	brLink, err := l.getLinkByName(bridgeName)
+	if err != nil {
+		funcLog.Error(err, "RemoveLinuxBridge(): failed to get bridge link")
+		return err
+	}
+	if brLink == nil || !isManagedBridge(brLink) {
+		funcLog.V(2).Info("RemoveLinuxBridge(): managed bridge not exist")
+		return nil
+	}
+	// the ports are released by the kernel
+	if err := l.netlinkLib.LinkDel(brLink); err != nil {
+		funcLog.Error(err, "RemoveLinuxBridge(): failed to remove managed bridge")
+		return err
+	}
+	return nil
+}
+
+// RemoveInterfaceFromLinuxBridge removes interface from the managed Linux bridge
+func (l *linuxBridge) RemoveInterfaceFromLinuxBridge(pciAddress string) error {
+	funcLog := log.Log.WithValues("pciAddress", pciAddress)
+	funcLog.V(1).Info("RemoveInterfaceFromLinuxBridge(): remove interface from managed bridge")
+	ifaceName := l.networkHelper.TryGetInterfaceName(pciAddress)
+	if ifaceName == "" {
+		funcLog.V(2).Info("RemoveInterfaceFromLinuxBridge(): interface not found")
+		return nil
+	}
+	link, err := l.getLinkByName(ifaceName)
+	if err != nil {
+		funcLog.Error(err, "RemoveInterfaceFromLinuxBridge(): failed to get interface link", "ifaceName", ifaceName)
+		return err
+	}
+	if link == nil || link.Attrs().MasterIndex == 0 {
+		return nil
+	}
+	master, err := l.netlinkLib.LinkByIndex(link.Attrs().MasterIndex)
+	if err != nil {
+		funcLog.Error(err, "RemoveInterfaceFromLinuxBridge(): failed to get master link", "ifaceName", ifaceName)
+		return err
+	}
+	if !isManagedBridge(master) {
+		funcLog.V(2).Info("RemoveInterfaceFromLinuxBridge(): interface is not attached to a managed bridge", "ifaceName", ifaceName)
+		return nil
+	}
+	funcLog.V(2).Info("RemoveInterfaceFromLinuxBridge(): detach interface from the bridge", "ifaceName", ifaceName, "bridge", master.Attrs().Name)
+	if err := l.netlinkLib.LinkSetNoMaster(link); err != nil {
+		funcLog.Error(err, "RemoveInterfaceFromLinuxBridge(): failed to detach interface from the bridge", "ifaceName", ifaceName)
+		return err
+	}
+	return nil
+}
+
+// getBridgeUplinks returns PFs attached to the bridge sorted by PCI address,
+// other ports of the bridge (e.g. VF representors) are ignored
+func (l *linuxBridge) getBridgeUplinks(brLink netlinkLibPkg.Link) ([]uplinkPort, error) {
+	links, err := l.netlinkLib.LinkList()
+	if err != nil {
+		return nil, err
+	}
+	var result []uplinkPort
+	for _, link := range links {
+		if link.Attrs().MasterIndex != brLink.Attrs().Index {
+			continue
+		}
+		pciAddress, err := l.networkHelper.GetPciAddressFromInterfaceName(link.Attrs().Name)
+		if err != nil || pciAddress == "" {
+			continue
+		}
+		// VF representors share the PCI address with the PF
+		if l.networkHelper.TryGetInterfaceName(pciAddress) != link.Attrs().Name {
+			continue
+		}
+		result = append(result, uplinkPort{link: link, pciAddress: pciAddress})
+	}
+	slices.SortFunc(result, func(x, y uplinkPort) int {
+		return strings.Compare(x.pciAddress, y.pciAddress)
+	})
+	return result, nil
+}
+
+// getLinkByName returns the link, nil is returned if the link doesn't exist
+func (l *linuxBridge) getLinkByName(name string) (netlinkLibPkg.Link, error) {
+	link, err := l.netlinkLib.LinkByName(name)
+	if err != nil {
+		var notFound netlink.LinkNotFoundError
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return link, nil
+}
+
+// isManagedBridge returns true if the link is a Linux bridge created by the operator
+func isManagedBridge(link netlinkLibPkg.Link) bool {
+	return link.Type() == "bridge" && link.Attrs().Alias == managedBridgeAlias
+}
+
+// getVlanFiltering returns the VLAN filtering state of the bridge
+func getVlanFiltering(link netlinkLibPkg.Link) bool {
+	br, ok := link.(*netlink.Bridge)
+	if !ok || br.VlanFiltering == nil {
+		return false
+	}
+	return *br.VlanFiltering
+}
+
+// getTaggedVlans returns sorted tagged VLANs of the port, the port VLAN (PVID) is ignored
+func getTaggedVlans(infos []*nl.BridgeVlanInfo) []int {
+	var result []int
+	for _, info := range infos {
+		if info.PortVID() || info.EngressUntag() {
+			continue
+		}
+		result = append(result, int(info.Vid))
+	}
+	slices.Sort(result)
+	return result
+}
@@ -0,0 +1,188 @@
+package linux
+
+import (
+	"fmt"
+
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+	"go.uber.org/mock/gomock"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	netlinkLibPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/netlink"
+	netlinkMockPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/netlink/mock"
+	hostMockPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/mock"
+)
+
+func newBridgeLink(name string, index int, vlanFiltering bool) *netlink.Bridge {
+	return &netlink.Bridge{
+		LinkAttrs:     netlink.LinkAttrs{Name: name, Index: index, Alias: managedBridgeAlias},
+		VlanFiltering: &vlanFiltering,
+	}
+}
+
+var _ = Describe("Linux bridge", func() {
+	var (
+		testCtrl       *gomock.Controller
+		l              Interface
+		netlinkLibMock *netlinkMockPkg.MockNetlinkLib
+		hostMock       *hostMockPkg.MockHostManagerInterface
+		testErr        = fmt.Errorf("test")
+	)
+	BeforeEach(func() {
+		testCtrl = gomock.NewController(GinkgoT())
+		netlinkLibMock = netlinkMockPkg.NewMockNetlinkLib(testCtrl)
+		hostMock = hostMockPkg.NewMockHostManagerInterface(testCtrl)
+		l = New(netlinkLibMock, hostMock)
+	})
+	AfterEach(func() {
+		testCtrl.Finish()
+	})
+
+	Context("CreateLinuxBridge", func() {
+		It("create bridge with VLAN filtering", func() {
+			conf := &sriovnetworkv1.LinuxBridgeConfigExt{
+				Name:   "br-edge",
+				Bridge: sriovnetworkv1.LinuxBridgeOptions{VlanFiltering: true},
+				Uplinks: []sriovnetworkv1.LinuxUplinkConfigExt{{
+					PciAddress: "0000:d8:00.0",
+					Name:       "enp216s0f0np0",
+					Interface:  sriovnetworkv1.LinuxUplinkConfig{Vlans: []int{100}},
+				}},
+			}
+			brLink := &netlink.Bridge{LinkAttrs: netlink.LinkAttrs{Name: "br-edge", Index: 10}}
+			pfLink := &netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "enp216s0f0np0", Index: 5}}
+			netlinkLibMock.EXPECT().LinkByName("br-edge").Return(nil, netlink.LinkNotFoundError{})
+			netlinkLibMock.EXPECT().LinkAdd(gomock.Any()).Return(nil)
+			netlinkLibMock.EXPECT().LinkByName("br-edge").Return(brLink, nil)
+			netlinkLibMock.EXPECT().LinkSetAlias(brLink, managedBridgeAlias).Return(nil)
+			netlinkLibMock.EXPECT().BridgeSetVlanFiltering(brLink, true).Return(nil)
+			netlinkLibMock.EXPECT().LinkSetUp(brLink).Return(nil)
+			netlinkLibMock.EXPECT().LinkList().Return([]netlinkLibPkg.Link{brLink, pfLink}, nil)
+			netlinkLibMock.EXPECT().BridgeVlanList().Return(map[int32][]*nl.BridgeVlanInfo{}, nil)
+			netlinkLibMock.EXPECT().LinkByName("enp216s0f0np0").Return(pfLink, nil)
+			netlinkLibMock.EXPECT().LinkSetMaster(pfLink, brLink).Return(nil)
+			hostMock.EXPECT().EnableHwTcOffload("enp216s0f0np0").Return(nil)
+			netlinkLibMock.EXPECT().BridgeVlanAdd(pfLink, uint16(100), false, false, false, true).Return(nil)
+			Expect(l.CreateLinuxBridge(conf)).NotTo(HaveOccurred())
+		})
+		It("update existing bridge", func() {
+			conf := &sriovnetworkv1.LinuxBridgeConfigExt{
+				Name:   "br-edge",
+				Bridge: sriovnetworkv1.LinuxBridgeOptions{VlanFiltering: true},
+				Uplinks: []sriovnetworkv1.LinuxUplinkConfigExt{{
+					PciAddress: "0000:d8:00.0",
+					Name:       "enp216s0f0np0",
+					Interface:  sriovnetworkv1.LinuxUplinkConfig{Vlans: []int{100}},
+				}},
+			}
+			brLink := newBridgeLink("br-edge", 10, true)
+			pf0Link := &netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "enp216s0f0np0", Index: 5, MasterIndex: 10}}
+			pf1Link := &netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "enp216s0f1np1", Index: 6, MasterIndex: 10}}
+			netlinkLibMock.EXPECT().LinkByName("br-edge").Return(brLink, nil)
+			netlinkLibMock.EXPECT().LinkSetUp(brLink).Return(nil)
+			netlinkLibMock.EXPECT().LinkList().Return([]netlinkLibPkg.Link{brLink, pf0Link, pf1Link}, nil)
+			hostMock.EXPECT().GetPciAddressFromInterfaceName("enp216s0f0np0").Return("0000:d8:00.0", nil)
+			hostMock.EXPECT().TryGetInterfaceName("0000:d8:00.0").Return("enp216s0f0np0")
+			hostMock.EXPECT().GetPciAddressFromInterfaceName("enp216s0f1np1").Return("0000:d8:00.1", nil)
+			hostMock.EXPECT().TryGetInterfaceName("0000:d8:00.1").Return("enp216s0f1np1")
+			// the uplink which is not in the config is detached from the bridge
+			netlinkLibMock.EXPECT().LinkSetNoMaster(pf1Link).Return(nil)
+			netlinkLibMock.EXPECT().BridgeVlanList().Return(map[int32][]*nl.BridgeVlanInfo{
+				5: {{Flags: nl.BRIDGE_VLAN_INFO_PVID | nl.BRIDGE_VLAN_INFO_UNTAGGED, Vid: 1}, {Vid: 100}, {Vid: 200}},
+			}, nil)
+			netlinkLibMock.EXPECT().LinkByName("enp216s0f0np0").Return(pf0Link, nil)
+			hostMock.EXPECT().EnableHwTcOffload("enp216s0f0np0").Return(nil)
+			netlinkLibMock.EXPECT().BridgeVlanDel(pf0Link, uint16(200), false, false, false, true).Return(nil)
+			Expect(l.CreateLinuxBridge(conf)).NotTo(HaveOccurred())
+		})
+		It("link exist and is not managed", func() {
+			netlinkLibMock.EXPECT().LinkByName("br-edge").Return(
+				&netlink.Bridge{LinkAttrs: netlink.LinkAttrs{Name: "br-edge", Index: 10}}, nil)
+			Expect(l.CreateLinuxBridge(&sriovnetworkv1.LinuxBridgeConfigExt{Name: "br-edge"})).To(HaveOccurred())
+		})
+		It("failed to create bridge", func() {
+			netlinkLibMock.EXPECT().LinkByName("br-edge").Return(nil, netlink.LinkNotFoundError{})
+			netlinkLibMock.EXPECT().LinkAdd(gomock.Any()).Return(testErr)
+			Expect(l.CreateLinuxBridge(&sriovnetworkv1.LinuxBridgeConfigExt{Name: "br-edge"})).To(MatchError(testErr))
+		})
+	})
+
+	Context("GetLinuxBridges", func() {
+		It("report managed bridges only", func() {
+			brLink := newBridgeLink("br-edge", 10, true)
+			unmanagedBrLink := &netlink.Bridge{LinkAttrs: netlink.LinkAttrs{Name: "br-other", Index: 11}}
+			pfLink := &netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "enp216s0f0np0", Index: 5, MasterIndex: 10}}
+			repLink := &netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "enp216s0f0npf0vf0", Index: 7, MasterIndex: 10}}
+			netlinkLibMock.EXPECT().LinkList().Return([]netlinkLibPkg.Link{brLink, unmanagedBrLink, pfLink, repLink}, nil).Times(2)
+			netlinkLibMock.EXPECT().BridgeVlanList().Return(map[int32][]*nl.BridgeVlanInfo{
+				5: {{Flags: nl.BRIDGE_VLAN_INFO_PVID | nl.BRIDGE_VLAN_INFO_UNTAGGED, Vid: 1}, {Vid: 200}, {Vid: 100}},
+			}, nil)
+			hostMock.EXPECT().GetPciAddressFromInterfaceName("enp216s0f0np0").Return("0000:d8:00.0", nil)
+			hostMock.EXPECT().GetPciAddressFromInterfaceName("enp216s0f0npf0vf0").Return("0000:d8:00.0", nil)
+			hostMock.EXPECT().TryGetInterfaceName("0000:d8:00.0").Return("enp216s0f0np0").Times(2)
+			ret, err := l.GetLinuxBridges()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ret).To(Equal([]sriovnetworkv1.LinuxBridgeConfigExt{{
+				Name:   "br-edge",
+				Bridge: sriovnetworkv1.LinuxBridgeOptions{VlanFiltering: true},
+				Uplinks: []sriovnetworkv1.LinuxUplinkConfigExt{{
+					PciAddress: "0000:d8:00.0",
+					Name:       "enp216s0f0np0",
+					Interface:  sriovnetworkv1.LinuxUplinkConfig{Vlans: []int{100, 200}},
+				}},
+			}}))
+		})
+		It("error", func() {
+			netlinkLibMock.EXPECT().LinkList().Return(nil, testErr)
+			_, err := l.GetLinuxBridges()
+			Expect(err).To(MatchError(testErr))
+		})
+	})
+
+	Context("RemoveLinuxBridge", func() {
+		It("remove managed bridge", func() {
+			brLink := newBridgeLink("br-edge", 10, false)
+			netlinkLibMock.EXPECT().LinkByName("br-edge").Return(brLink, nil)
+			netlinkLibMock.EXPECT().LinkDel(brLink).Return(nil)
+			Expect(l.RemoveLinuxBridge("br-edge")).NotTo(HaveOccurred())
+		})
+		It("should keep unmanaged bridge", func() {
+			netlinkLibMock.EXPECT().LinkByName("br-edge").Return(
+				&netlink.Bridge{LinkAttrs: netlink.LinkAttrs{Name: "br-edge", Index: 10}}, nil)
+			Expect(l.RemoveLinuxBridge("br-edge")).NotTo(HaveOccurred())
+		})
+		It("bridge not found", func() {
+			netlinkLibMock.EXPECT().LinkByName("br-edge").Return(nil, netlink.LinkNotFoundError{})
+			Expect(l.RemoveLinuxBridge("br-edge")).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("RemoveInterfaceFromLinuxBridge", func() {
+		It("detach interface from managed bridge", func() {
+			brLink := newBridgeLink("br-edge", 10, false)
+			pfLink := &netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "enp216s0f0np0", Index: 5, MasterIndex: 10}}
+			hostMock.EXPECT().TryGetInterfaceName("0000:d8:00.0").Return("enp216s0f0np0")
+			netlinkLibMock.EXPECT().LinkByName("enp216s0f0np0").Return(pfLink, nil)
+			netlinkLibMock.EXPECT().LinkByIndex(10).Return(brLink, nil)
+			netlinkLibMock.EXPECT().LinkSetNoMaster(pfLink).Return(nil)
+			Expect(l.RemoveInterfaceFromLinuxBridge("0000:d8:00.0")).NotTo(HaveOccurred())
+		})
+		It("should not detach interface from unmanaged bridge", func() {
+			pfLink := &netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "enp216s0f0np0", Index: 5, MasterIndex: 10}}
+			hostMock.EXPECT().TryGetInterfaceName("0000:d8:00.0").Return("enp216s0f0np0")
+			netlinkLibMock.EXPECT().LinkByName("enp216s0f0np0").Return(pfLink, nil)
+			netlinkLibMock.EXPECT().LinkByIndex(10).Return(
+				&netlink.Bridge{LinkAttrs: netlink.LinkAttrs{Name: "br-other", Index: 10}}, nil)
+			Expect(l.RemoveInterfaceFromLinuxBridge("0000:d8:00.0")).NotTo(HaveOccurred())
+		})
+		It("interface has no master", func() {
+			hostMock.EXPECT().TryGetInterfaceName("0000:d8:00.0").Return("enp216s0f0np0")
+			netlinkLibMock.EXPECT().LinkByName("enp216s0f0np0").Return(
+				&netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "enp216s0f0np0", Index: 5}}, nil)
+			Expect(l.RemoveInterfaceFromLinuxBridge("0000:d8:00.0")).NotTo(HaveOccurred())
+		})
+	})
+})
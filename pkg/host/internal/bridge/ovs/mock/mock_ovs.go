@@ -55,6 +55,21 @@ func (mr *MockInterfaceMockRecorder) CreateOVSBridge(ctx, conf any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOVSBridge", reflect.TypeOf((*MockInterface)(nil).CreateOVSBridge), ctx, conf)
 }
 
+// GetHwOffloadConfig mocks base method.
+func (m *MockInterface) GetHwOffloadConfig(ctx context.Context) (*v1.OvsHardwareOffload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHwOffloadConfig", ctx)
+	ret0, _ := ret[0].(*v1.OvsHardwareOffload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHwOffloadConfig indicates an expected call of GetHwOffloadConfig.
+func (mr *MockInterfaceMockRecorder) GetHwOffloadConfig(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHwOffloadConfig", reflect.TypeOf((*MockInterface)(nil).GetHwOffloadConfig), ctx)
+}
+
 // GetOVSBridges mocks base method.
 func (m *MockInterface) GetOVSBridges(ctx context.Context) ([]v1.OVSConfigExt, error) {
 	m.ctrl.T.Helper()
@@ -97,3 +112,18 @@ func (mr *MockInterfaceMockRecorder) RemoveOVSBridge(ctx, bridgeName any) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveOVSBridge", reflect.TypeOf((*MockInterface)(nil).RemoveOVSBridge), ctx, bridgeName)
 }
+
+// SetHwOffloadConfig mocks base method.
+func (m *MockInterface) SetHwOffloadConfig(ctx context.Context, conf *v1.OvsHardwareOffload) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHwOffloadConfig", ctx, conf)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetHwOffloadConfig indicates an expected call of SetHwOffloadConfig.
+func (mr *MockInterfaceMockRecorder) SetHwOffloadConfig(ctx, conf any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHwOffloadConfig", reflect.TypeOf((*MockInterface)(nil).SetHwOffloadConfig), ctx, conf)
+}
@@ -8,8 +8,9 @@ import (
 
 // OpenvSwitchEntry represents some fields of the object in the Open_vSwitch table
 type OpenvSwitchEntry struct {
-	UUID    string   `ovsdb:"_uuid"`
-	Bridges []string `ovsdb:"bridges"`
+	UUID        string            `ovsdb:"_uuid"`
+	Bridges     []string          `ovsdb:"bridges"`
+	OtherConfig map[string]string `ovsdb:"other_config"`
 }
 
 // BridgeEntry represents some fields of the object in the Bridge table
@@ -44,6 +45,16 @@ type PortEntry struct {
 	UUID       string   `ovsdb:"_uuid"`
 	Name       string   `ovsdb:"name"`
 	Interfaces []string `ovsdb:"interfaces"`
+	BondMode   *string  `ovsdb:"bond_mode"`
+	LACP       *string  `ovsdb:"lacp"`
+	Tag        *int     `ovsdb:"tag"`
+	Trunks     []int    `ovsdb:"trunks"`
+	VlanMode   *string  `ovsdb:"vlan_mode"`
+}
+
+// HasInterface returns true if ifaceUUID is found in Interfaces slice
+func (p *PortEntry) HasInterface(ifaceUUID string) bool {
+	return slices.Contains(p.Interfaces, ifaceUUID)
 }
 
 // DatabaseModel returns the DatabaseModel object to be used in libovsdb
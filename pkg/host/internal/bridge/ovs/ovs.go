@@ -6,7 +6,9 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -20,6 +22,7 @@ import (
 
 	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	ovsStorePkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/bridge/ovs/store"
+	sriovnetPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/sriovnet"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
 )
@@ -33,6 +36,14 @@ const (
 	interfaceErrorCheckCount = 2
 	// interval between checks
 	interfaceErrorCheckInterval = time.Second
+
+	// keys in the external_ids of the VF representor interfaces attached to the bridge by the operator
+	representorBridgeKey = "sriovnetwork.openshift.io/bridge"
+	representorPFKey     = "sriovnetwork.openshift.io/pf"
+
+	// keys in the other_config of the Open_vSwitch table
+	hwOffloadKey = "hw-offload"
+	tcPolicyKey  = "tc-policy"
 )
 
 // Interface provides functions to configure managed OVS bridges
@@ -49,15 +60,33 @@ type Interface interface {
 	RemoveOVSBridge(ctx context.Context, bridgeName string) error
 	// RemoveInterfaceFromOVSBridge interface from the managed OVS bridge
 	RemoveInterfaceFromOVSBridge(ctx context.Context, ifaceAddr string) error
+	// GetHwOffloadConfig returns the hardware offload settings from the Open_vSwitch table
+	GetHwOffloadConfig(ctx context.Context) (*sriovnetworkv1.OvsHardwareOffload, error)
+	// SetHwOffloadConfig updates the hardware offload settings in the Open_vSwitch table,
+	// returns true if the settings were changed and OVS needs to be restarted to apply them
+	SetHwOffloadConfig(ctx context.Context, conf *sriovnetworkv1.OvsHardwareOffload) (bool, error)
 }
 
 // New creates new instance of the OVS interface
-func New(store ovsStorePkg.Store) Interface {
-	return &ovs{store: store}
+func New(store ovsStorePkg.Store, sriovnetLib sriovnetPkg.SriovnetLib) Interface {
+	return &ovs{store: store, sriovnetLib: sriovnetLib}
 }
 
 type ovs struct {
-	store ovsStorePkg.Store
+	store       ovsStorePkg.Store
+	sriovnetLib sriovnetPkg.SriovnetLib
+}
+
+// portWithInterfaces contains the port and its interfaces to add to the bridge
+type portWithInterfaces struct {
+	port   *PortEntry
+	ifaces []*InterfaceEntry
+}
+
+// representorPort contains the desired configuration of the port for the VF representor
+type representorPort struct {
+	pfPciAddress string
+	conf         sriovnetworkv1.OVSRepresentorPortConfig
 }
 
 // CreateOVSBridge creates OVS bridge from the provided config,
@@ -66,10 +95,14 @@ type ovs struct {
 func (o *ovs) CreateOVSBridge(ctx context.Context, conf *sriovnetworkv1.OVSConfigExt) error {
 	ctx, cancel := setDefaultTimeout(ctx)
 	defer cancel()
-	if len(conf.Uplinks) != 1 {
-		return fmt.Errorf("unsupported configuration, uplinks list must contain one element")
+	if len(conf.Uplinks) == 0 {
+		return fmt.Errorf("unsupported configuration, uplinks list must not be empty")
+	}
+	if conf.Bond != nil && conf.PortBond != nil {
+		return fmt.Errorf("unsupported configuration, Linux bond and OVS bond port can't be used together")
 	}
-	funcLog := log.Log.WithValues("bridge", conf.Name, "ifaceAddr", conf.Uplinks[0].PciAddress, "ifaceName", conf.Uplinks[0].Name)
+	uplinkNames := getUplinkInterfaceNames(conf)
+	funcLog := log.Log.WithValues("bridge", conf.Name, "ifaceAddr", conf.Uplinks[0].PciAddress, "ifaceNames", uplinkNames)
 	funcLog.V(1).Info("CreateOVSBridge(): start configuration of the OVS bridge")
 
 	dbClient, err := getClient(ctx)
@@ -108,19 +141,36 @@ func (o *ovs) CreateOVSBridge(ctx context.Context, conf *sriovnetworkv1.OVSConfi
 				funcLog.V(2).Info("CreateOVSBridge(): bridge state already match current configuration, no actions required")
 				return nil
 			}
+			if equality.Semantic.DeepEqual(withoutRepresentors(conf), withoutRepresentors(currentState)) {
+				funcLog.V(2).Info("CreateOVSBridge(): only representor ports differ from the current configuration")
+				bridge, err := o.getBridgeByName(ctx, dbClient, conf.Name)
+				if err != nil {
+					funcLog.Error(err, "CreateOVSBridge(): failed to retrieve information about the bridge from OVSDB")
+					return err
+				}
+				return o.syncRepresentorPorts(ctx, funcLog, dbClient, bridge, conf)
+			}
 			funcLog.V(2).Info("CreateOVSBridge(): bridge state differs from the current configuration, reconfiguration required")
 			keepBridge = equality.Semantic.DeepEqual(conf.Bridge, currentState.Bridge)
 		}
 	} else {
 		funcLog.V(2).Info("CreateOVSBridge(): configuration for the bridge not found in the store, create the bridge")
 	}
-	funcLog.V(2).Info("CreateOVSBridge(): ensure uplink is not attached to any bridge")
+	funcLog.V(2).Info("CreateOVSBridge(): ensure uplinks are not attached to any bridge")
 	// removal of the bridge should also remove all interfaces that are attached to it.
-	// we need to remove interface with additional call even if keepBridge is false to make
-	// sure that the interface is not attached to a different OVS bridge
-	if err := o.deleteInterfaceByName(ctx, dbClient, conf.Uplinks[0].Name); err != nil {
-		funcLog.Error(err, "CreateOVSBridge(): failed to remove uplink interface")
-		return err
+	// we need to remove interfaces with additional calls even if keepBridge is false to make
+	// sure that the interfaces are not attached to a different OVS bridge
+	if conf.PortBond != nil {
+		if err := o.deletePortByName(ctx, dbClient, conf.PortBond.Name); err != nil {
+			funcLog.Error(err, "CreateOVSBridge(): failed to remove bond port")
+			return err
+		}
+	}
+	for _, uplinkName := range uplinkNames {
+		if err := o.deleteInterfaceByName(ctx, dbClient, uplinkName); err != nil {
+			funcLog.Error(err, "CreateOVSBridge(): failed to remove uplink interface", "ifaceName", uplinkName)
+			return err
+		}
 	}
 	if !keepBridge {
 		// make sure that bridge with provided name not exist
@@ -153,22 +203,178 @@ func (o *ovs) CreateOVSBridge(ctx context.Context, conf *sriovnetworkv1.OVSConfi
 		funcLog.Error(err, "CreateOVSBridge(): failed to add internal interface to the bridge")
 		return err
 	}
-	funcLog.V(2).Info("CreateOVSBridge(): add uplink interface to the bridge")
-	if err := o.addInterface(ctx, dbClient, bridge, &InterfaceEntry{
-		Name:        conf.Uplinks[0].Name,
-		UUID:        uuid.NewString(),
-		Type:        conf.Uplinks[0].Interface.Type,
-		Options:     conf.Uplinks[0].Interface.Options,
-		ExternalIDs: conf.Uplinks[0].Interface.ExternalIDs,
-		OtherConfig: conf.Uplinks[0].Interface.OtherConfig,
-		MTURequest:  conf.Uplinks[0].Interface.MTURequest,
+	if conf.PortBond != nil {
+		funcLog.V(2).Info("CreateOVSBridge(): add bond port to the bridge")
+		ifaces := make([]*InterfaceEntry, 0, len(conf.Uplinks))
+		for i := range conf.Uplinks {
+			ifaces = append(ifaces, newUplinkInterfaceEntry(conf.Uplinks[i].Name, &conf.Uplinks[i]))
+		}
+		port := &PortEntry{Name: conf.PortBond.Name, UUID: uuid.NewString()}
+		if conf.PortBond.Mode != "" {
+			port.BondMode = &conf.PortBond.Mode
+		}
+		if conf.PortBond.LACP != "" {
+			port.LACP = &conf.PortBond.LACP
+		}
+		if err := o.addPorts(ctx, dbClient, bridge, portWithInterfaces{port: port, ifaces: ifaces}); err != nil {
+			funcLog.Error(err, "CreateOVSBridge(): failed to add bond port to the bridge")
+			return err
+		}
+	} else {
+		for i, uplinkName := range uplinkNames {
+			funcLog.V(2).Info("CreateOVSBridge(): add uplink interface to the bridge", "ifaceName", uplinkName)
+			// the Linux bond uses the configuration of the first uplink
+			if err := o.addInterface(ctx, dbClient, bridge, newUplinkInterfaceEntry(uplinkName, &conf.Uplinks[i])); err != nil {
+				funcLog.Error(err, "CreateOVSBridge(): failed to add uplink interface to the bridge", "ifaceName", uplinkName)
+				return err
+			}
+		}
+	}
+	return o.syncRepresentorPorts(ctx, funcLog, dbClient, bridge, conf)
+}
+
+// syncRepresentorPorts attaches the VF representors to the bridge with the configured port settings
+// and removes the representor ports which are not expected anymore
+func (o *ovs) syncRepresentorPorts(ctx context.Context, funcLog logr.Logger, dbClient client.Client,
+	bridge *BridgeEntry, conf *sriovnetworkv1.OVSConfigExt) error {
+	desiredPorts, err := o.getDesiredRepresentorPorts(conf)
+	if err != nil {
+		funcLog.Error(err, "CreateOVSBridge(): failed to get VF representors")
+		return err
+	}
+	if err := o.deleteRepresentorInterfaces(ctx, dbClient, func(iface *InterfaceEntry) bool {
+		_, desired := desiredPorts[iface.Name]
+		return iface.ExternalIDs[representorBridgeKey] == conf.Name && !desired
 	}); err != nil {
-		funcLog.Error(err, "CreateOVSBridge(): failed to add uplink interface to the bridge")
+		funcLog.Error(err, "CreateOVSBridge(): failed to remove unexpected representor ports")
+		return err
+	}
+	repNames := make([]string, 0, len(desiredPorts))
+	for name := range desiredPorts {
+		repNames = append(repNames, name)
+	}
+	sort.Strings(repNames)
+	var toAdd []portWithInterfaces
+	for _, repName := range repNames {
+		desired := desiredPorts[repName]
+		iface, port, err := o.getUplinkInterface(ctx, funcLog, dbClient, bridge, repName)
+		if err != nil {
+			return err
+		}
+		if iface != nil && iface.ExternalIDs[representorBridgeKey] == conf.Name &&
+			equality.Semantic.DeepEqual(getRepresentorPortConfig(port), normalizeRepresentorPortConfig(desired.conf)) {
+			continue
+		}
+		// make sure that the representor is not attached to a different bridge or port
+		if err := o.deleteInterfaceByName(ctx, dbClient, repName); err != nil {
+			funcLog.Error(err, "CreateOVSBridge(): failed to remove representor interface", "ifaceName", repName)
+			return err
+		}
+		funcLog.V(2).Info("CreateOVSBridge(): add representor port to the bridge", "ifaceName", repName)
+		repPort := &PortEntry{Name: repName, UUID: uuid.NewString(), Trunks: desired.conf.Trunks}
+		if desired.conf.Tag > 0 {
+			repPort.Tag = &desired.conf.Tag
+		}
+		if desired.conf.VlanMode != "" {
+			repPort.VlanMode = &desired.conf.VlanMode
+		}
+		toAdd = append(toAdd, portWithInterfaces{port: repPort, ifaces: []*InterfaceEntry{{
+			Name:        repName,
+			UUID:        uuid.NewString(),
+			ExternalIDs: map[string]string{representorBridgeKey: conf.Name, representorPFKey: desired.pfPciAddress},
+		}}})
+	}
+	if len(toAdd) == 0 {
+		return nil
+	}
+	if err := o.addPorts(ctx, dbClient, bridge, toAdd...); err != nil {
+		funcLog.Error(err, "CreateOVSBridge(): failed to add representor ports to the bridge")
 		return err
 	}
 	return nil
 }
 
+// getDesiredRepresentorPorts returns the desired configuration of the ports for the VF representors,
+// the name of the representor is a key in the map
+func (o *ovs) getDesiredRepresentorPorts(conf *sriovnetworkv1.OVSConfigExt) (map[string]representorPort, error) {
+	result := map[string]representorPort{}
+	for _, uplink := range conf.Uplinks {
+		for _, group := range uplink.Representors {
+			repNames, err := o.getRepresentorNames(uplink.Name, group.VfRange)
+			if err != nil {
+				return nil, err
+			}
+			for _, repName := range repNames {
+				result[repName] = representorPort{pfPciAddress: uplink.PciAddress, conf: group.Port}
+			}
+		}
+	}
+	return result, nil
+}
+
+// getRepresentorNames returns names of the representors for the VFs in the range
+func (o *ovs) getRepresentorNames(pfName, vfRange string) ([]string, error) {
+	var rngStart, rngEnd int
+	if _, err := fmt.Sscanf(vfRange, "%d-%d", &rngStart, &rngEnd); err != nil {
+		return nil, fmt.Errorf("failed to parse VF range %s: %v", vfRange, err)
+	}
+	result := make([]string, 0, rngEnd-rngStart+1)
+	for vfID := rngStart; vfID <= rngEnd; vfID++ {
+		repName, err := o.sriovnetLib.GetVfRepresentor(pfName, vfID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get representor for VF %d of %s: %v", vfID, pfName, err)
+		}
+		result = append(result, repName)
+	}
+	return result, nil
+}
+
+// withoutRepresentors returns a copy of the bridge configuration without configuration for the representors
+func withoutRepresentors(conf *sriovnetworkv1.OVSConfigExt) *sriovnetworkv1.OVSConfigExt {
+	result := conf.DeepCopy()
+	for i := range result.Uplinks {
+		result.Uplinks[i].Representors = nil
+	}
+	return result
+}
+
+// getRepresentorPortConfig returns current configuration of the representor port
+func getRepresentorPortConfig(port *PortEntry) sriovnetworkv1.OVSRepresentorPortConfig {
+	conf := sriovnetworkv1.OVSRepresentorPortConfig{Trunks: port.Trunks}
+	if port.Tag != nil {
+		conf.Tag = *port.Tag
+	}
+	if port.VlanMode != nil {
+		conf.VlanMode = *port.VlanMode
+	}
+	return normalizeRepresentorPortConfig(conf)
+}
+
+// normalizeRepresentorPortConfig returns the configuration with sorted trunks,
+// empty trunks list is replaced with nil
+func normalizeRepresentorPortConfig(conf sriovnetworkv1.OVSRepresentorPortConfig) sriovnetworkv1.OVSRepresentorPortConfig {
+	if len(conf.Trunks) == 0 {
+		conf.Trunks = nil
+		return conf
+	}
+	conf.Trunks = slices.Clone(conf.Trunks)
+	slices.Sort(conf.Trunks)
+	return conf
+}
+
+// newUplinkInterfaceEntry returns the Interface table entry for the uplink
+func newUplinkInterfaceEntry(name string, uplink *sriovnetworkv1.OVSUplinkConfigExt) *InterfaceEntry {
+	return &InterfaceEntry{
+		Name:        name,
+		UUID:        uuid.NewString(),
+		Type:        uplink.Interface.Type,
+		Options:     uplink.Interface.Options,
+		ExternalIDs: uplink.Interface.ExternalIDs,
+		OtherConfig: uplink.Interface.OtherConfig,
+		MTURequest:  uplink.Interface.MTURequest,
+	}
+}
+
 func (o *ovs) ensureInternalInterface(ctx context.Context, funcLog logr.Logger, dbClient client.Client, bridge *BridgeEntry) error {
 	funcLog.V(2).Info("CreateOVSBridge(): Check if internal interface exists in the bridge")
 	existingIface, err := o.getInterfaceByName(ctx, dbClient, bridge.Name)
@@ -304,11 +510,25 @@ func (o *ovs) RemoveInterfaceFromOVSBridge(ctx context.Context, pciAddress strin
 		funcLog.Error(err, "RemoveInterfaceFromOVSBridge(): failed to read data from store")
 		return fmt.Errorf("failed to read data from store: %v", err)
 	}
-	var relatedBridges []*sriovnetworkv1.OVSConfigExt
+	var (
+		relatedBridges []*sriovnetworkv1.OVSConfigExt
+		ifaceName      string
+	)
 	for _, kc := range knownConfigs {
-		if len(kc.Uplinks) > 0 && kc.Uplinks[0].PciAddress == pciAddress && kc.Uplinks[0].Name != "" {
-			relatedBridges = append(relatedBridges, kc)
+		idx := slices.IndexFunc(kc.Uplinks, func(uplink sriovnetworkv1.OVSUplinkConfigExt) bool {
+			return uplink.PciAddress == pciAddress && uplink.Name != ""
+		})
+		if idx < 0 {
+			continue
 		}
+		if len(relatedBridges) == 0 {
+			ifaceName = kc.Uplinks[idx].Name
+			if kc.Bond != nil {
+				// the bond is removed from the bridge if one of its members is detached
+				ifaceName = kc.Bond.Name
+			}
+		}
+		relatedBridges = append(relatedBridges, kc)
 	}
 	if len(relatedBridges) == 0 {
 		funcLog.V(2).Info("RemoveInterfaceFromOVSBridge(): can't find related managed OVS bridge in the store")
@@ -341,11 +561,18 @@ func (o *ovs) RemoveInterfaceFromOVSBridge(ctx context.Context, pciAddress strin
 		return nil
 	}
 
-	funcLog.V(2).Info("RemoveInterfaceFromOVSBridge(): remove interface from the bridge")
-	if err := o.deleteInterfaceByName(ctx, dbClient, brConf.Uplinks[0].Name); err != nil {
+	funcLog.V(2).Info("RemoveInterfaceFromOVSBridge(): remove interface from the bridge", "ifaceName", ifaceName)
+	if err := o.deleteInterfaceByName(ctx, dbClient, ifaceName); err != nil {
 		funcLog.Error(err, "RemoveInterfaceFromOVSBridge(): failed to remove interface from the bridge", "bridge", brConf.Name)
 		return err
 	}
+	funcLog.V(2).Info("RemoveInterfaceFromOVSBridge(): remove representors of the interface from the bridge")
+	if err := o.deleteRepresentorInterfaces(ctx, dbClient, func(iface *InterfaceEntry) bool {
+		return iface.ExternalIDs[representorBridgeKey] == brConf.Name && iface.ExternalIDs[representorPFKey] == pciAddress
+	}); err != nil {
+		funcLog.Error(err, "RemoveInterfaceFromOVSBridge(): failed to remove representors from the bridge", "bridge", brConf.Name)
+		return err
+	}
 
 	return nil
 }
@@ -374,6 +601,18 @@ func (o *ovs) getInterfaceByName(ctx context.Context, dbClient client.Client, na
 	return iface, nil
 }
 
+func (o *ovs) getPortByName(ctx context.Context, dbClient client.Client, name string) (*PortEntry, error) {
+	port := &PortEntry{Name: name}
+	if err := dbClient.Get(ctx, port); err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			return nil, nil
+		} else {
+			return nil, fmt.Errorf("get call for the port %s failed: %v", name, err)
+		}
+	}
+	return port, nil
+}
+
 func (o *ovs) getPortByInterface(ctx context.Context, dbClient client.Client, iface *InterfaceEntry) (*PortEntry, error) {
 	portEntry := &PortEntry{}
 	portEntryList := []*PortEntry{}
@@ -435,37 +674,62 @@ func (o *ovs) createBridge(ctx context.Context, dbClient client.Client, br *Brid
 // add interface with provided configuration to the provided bridge
 // and check that interface has no error for the next 2 seconds
 func (o *ovs) addInterface(ctx context.Context, dbClient client.Client, br *BridgeEntry, iface *InterfaceEntry) error {
-	addInterfaceOPs, err := dbClient.Create(iface)
-	if err != nil {
-		return fmt.Errorf("failed to prepare operation for interface creation: %v", err)
-	}
-	port := &PortEntry{Name: iface.Name, UUID: uuid.NewString(), Interfaces: []string{iface.UUID}}
-	addPortOPs, err := dbClient.Create(port)
-	if err != nil {
-		return fmt.Errorf("failed to prepare operation for port creation: %v", err)
+	return o.addPorts(ctx, dbClient, br, portWithInterfaces{
+		port:   &PortEntry{Name: iface.Name, UUID: uuid.NewString()},
+		ifaces: []*InterfaceEntry{iface},
+	})
+}
+
+// add ports with provided interfaces to the provided bridge in a single transaction
+// and check that interfaces have no error for the next 2 seconds
+func (o *ovs) addPorts(ctx context.Context, dbClient client.Client, br *BridgeEntry, ports ...portWithInterfaces) error {
+	var (
+		operations [][]ovsdb.Operation
+		ifaces     []*InterfaceEntry
+		portUUIDs  []string
+	)
+	for _, p := range ports {
+		for _, iface := range p.ifaces {
+			addInterfaceOPs, err := dbClient.Create(iface)
+			if err != nil {
+				return fmt.Errorf("failed to prepare operation for interface creation: %v", err)
+			}
+			operations = append(operations, addInterfaceOPs)
+			p.port.Interfaces = append(p.port.Interfaces, iface.UUID)
+			ifaces = append(ifaces, iface)
+		}
+		addPortOPs, err := dbClient.Create(p.port)
+		if err != nil {
+			return fmt.Errorf("failed to prepare operation for port creation: %v", err)
+		}
+		operations = append(operations, addPortOPs)
+		portUUIDs = append(portUUIDs, p.port.UUID)
 	}
 	bridgeMutateOps, err := dbClient.Where(br).Mutate(br, model.Mutation{
 		Field:   &br.Ports,
 		Mutator: ovsdb.MutateOperationInsert,
-		Value:   []string{port.UUID},
+		Value:   portUUIDs,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to prepare operation for bridge mutate: %v", err)
 	}
-	if err := o.execTransaction(ctx, dbClient, addInterfaceOPs, addPortOPs, bridgeMutateOps); err != nil {
+	operations = append(operations, bridgeMutateOps)
+	if err := o.execTransaction(ctx, dbClient, operations...); err != nil {
 		return fmt.Errorf("bridge add interface failed: %v", err)
 	}
-	// check that interface has no error right after creation
+	// check that interfaces have no error right after creation
 	for i := 0; i < interfaceErrorCheckCount; i++ {
 		select {
 		case <-time.After(interfaceErrorCheckInterval):
 		case <-ctx.Done():
 		}
-		if err := dbClient.Get(ctx, iface); err != nil {
-			return fmt.Errorf("failed to read interface after creation: %v", err)
-		}
-		if iface.Error != nil {
-			return fmt.Errorf("created interface is in error state: %s", *iface.Error)
+		for _, iface := range ifaces {
+			if err := dbClient.Get(ctx, iface); err != nil {
+				return fmt.Errorf("failed to read interface after creation: %v", err)
+			}
+			if iface.Error != nil {
+				return fmt.Errorf("created interface %s is in error state: %s", iface.Name, *iface.Error)
+			}
 		}
 	}
 	return nil
@@ -522,7 +786,18 @@ func (o *ovs) deleteInterfaceByName(ctx context.Context, dbClient client.Client,
 	if err != nil {
 		return err
 	}
-	if port != nil {
+	if port != nil && len(port.Interfaces) > 1 {
+		// the port is a bond with other interfaces, remove only the interface from the port
+		portMutateOps, err := dbClient.Where(port).Mutate(port, model.Mutation{
+			Field:   &port.Interfaces,
+			Mutator: ovsdb.MutateOperationDelete,
+			Value:   []string{iface.UUID},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to prepare operation for port mutate: %v", err)
+		}
+		operations = append(operations, portMutateOps)
+	} else if port != nil {
 		delPortOPs, err := dbClient.Where(port).Delete()
 		if err != nil {
 			return fmt.Errorf("failed to prepare operation for port deletion: %v", err)
@@ -551,6 +826,66 @@ func (o *ovs) deleteInterfaceByName(ctx context.Context, dbClient client.Client,
 	return nil
 }
 
+// delete the VF representor interfaces which match the filter
+func (o *ovs) deleteRepresentorInterfaces(ctx context.Context, dbClient client.Client, filter func(iface *InterfaceEntry) bool) error {
+	ifaces := []*InterfaceEntry{}
+	if err := dbClient.List(ctx, &ifaces); err != nil {
+		return fmt.Errorf("failed to list interfaces: %v", err)
+	}
+	for _, iface := range ifaces {
+		if iface.ExternalIDs[representorBridgeKey] == "" || !filter(iface) {
+			continue
+		}
+		if err := o.deleteInterfaceByName(ctx, dbClient, iface.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// delete port by the name with all its interfaces
+func (o *ovs) deletePortByName(ctx context.Context, dbClient client.Client, portName string) error {
+	port, err := o.getPortByName(ctx, dbClient, portName)
+	if err != nil {
+		return err
+	}
+	if port == nil {
+		return nil
+	}
+	var operations [][]ovsdb.Operation
+	for _, ifaceUUID := range port.Interfaces {
+		delIfaceOPs, err := dbClient.Where(&InterfaceEntry{UUID: ifaceUUID}).Delete()
+		if err != nil {
+			return fmt.Errorf("failed to prepare operation for interface deletion: %v", err)
+		}
+		operations = append(operations, delIfaceOPs)
+	}
+	delPortOPs, err := dbClient.Where(port).Delete()
+	if err != nil {
+		return fmt.Errorf("failed to prepare operation for port deletion: %v", err)
+	}
+	operations = append(operations, delPortOPs)
+	bridge, err := o.getBridgeByPort(ctx, dbClient, port)
+	if err != nil {
+		return err
+	}
+	if bridge != nil {
+		bridgeMutateOps, err := dbClient.Where(bridge).Mutate(bridge, model.Mutation{
+			Field:   &bridge.Ports,
+			Mutator: ovsdb.MutateOperationDelete,
+			Value:   []string{port.UUID},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to prepare operation for bridge mutate: %v", err)
+		}
+		operations = append(operations, bridgeMutateOps)
+	}
+	if err := o.execTransaction(ctx, dbClient, operations...); err != nil {
+		return fmt.Errorf("failed to remove port %s: %v", port.Name, err)
+	}
+	return nil
+}
+
 // execute multiple prepared OVSDB operations as a single transaction
 func (o *ovs) execTransaction(ctx context.Context, dbClient client.Client, ops ...[]ovsdb.Operation) error {
 	var operations []ovsdb.Operation
@@ -591,52 +926,165 @@ func (o *ovs) getCurrentBridgeState(ctx context.Context, dbClient client.Client,
 			OtherConfig: updateMap(knownConfig.Bridge.OtherConfig, bridge.OtherConfig),
 		},
 	}
+	if knownConfig.Bond != nil {
+		// the state of the Linux bond is not stored in OVSDB
+		bond := *knownConfig.Bond
+		currentConfig.Bond = &bond
+	}
 	if len(knownConfig.Uplinks) == 0 {
 		return currentConfig, nil
 	}
-	knownConfigUplink := knownConfig.Uplinks[0]
-	iface, err := o.getInterfaceByName(ctx, dbClient, knownConfigUplink.Name)
+	switch {
+	case knownConfig.Bond != nil:
+		iface, _, err := o.getUplinkInterface(ctx, funcLog, dbClient, bridge, knownConfig.Bond.Name)
+		if err != nil {
+			return nil, err
+		}
+		if iface == nil {
+			return currentConfig, nil
+		}
+		// all the members of the bond share the configuration of the bond interface
+		for _, member := range knownConfig.Uplinks {
+			currentConfig.Uplinks = append(currentConfig.Uplinks, getUplinkState(&knownConfig.Uplinks[0], &member, iface))
+		}
+	case knownConfig.PortBond != nil:
+		port, err := o.getPortByName(ctx, dbClient, knownConfig.PortBond.Name)
+		if err != nil {
+			return nil, err
+		}
+		if port == nil || !bridge.HasPort(port.UUID) {
+			// bond port not found or belongs to a wrong bridge, do not include uplinks config to
+			// the current bridge state to let the operator try to fix this
+			return currentConfig, nil
+		}
+		currentConfig.PortBond = &sriovnetworkv1.OVSPortBondConfig{Name: port.Name}
+		if port.BondMode != nil {
+			currentConfig.PortBond.Mode = *port.BondMode
+		}
+		if port.LACP != nil {
+			currentConfig.PortBond.LACP = *port.LACP
+		}
+		for i := range knownConfig.Uplinks {
+			iface, ifacePort, err := o.getUplinkInterface(ctx, funcLog, dbClient, bridge, knownConfig.Uplinks[i].Name)
+			if err != nil {
+				return nil, err
+			}
+			if iface == nil || ifacePort.UUID != port.UUID {
+				continue
+			}
+			currentConfig.Uplinks = append(currentConfig.Uplinks, getUplinkState(&knownConfig.Uplinks[i], &knownConfig.Uplinks[i], iface))
+		}
+	default:
+		for i := range knownConfig.Uplinks {
+			iface, _, err := o.getUplinkInterface(ctx, funcLog, dbClient, bridge, knownConfig.Uplinks[i].Name)
+			if err != nil {
+				return nil, err
+			}
+			if iface == nil {
+				continue
+			}
+			currentConfig.Uplinks = append(currentConfig.Uplinks, getUplinkState(&knownConfig.Uplinks[i], &knownConfig.Uplinks[i], iface))
+		}
+	}
+	for i := range currentConfig.Uplinks {
+		uplink := &currentConfig.Uplinks[i]
+		knownUplinkIdx := slices.IndexFunc(knownConfig.Uplinks, func(u sriovnetworkv1.OVSUplinkConfigExt) bool {
+			return u.PciAddress == uplink.PciAddress
+		})
+		uplink.Representors, err = o.getRepresentorsState(ctx, funcLog, dbClient, bridge, &knownConfig.Uplinks[knownUplinkIdx])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return currentConfig, nil
+}
+
+// getRepresentorsState returns the configuration of the representors for the VF groups of the uplink,
+// the VF group is reported only if the representors of all VFs in the group are attached to the bridge with the right config
+func (o *ovs) getRepresentorsState(ctx context.Context, funcLog logr.Logger, dbClient client.Client,
+	bridge *BridgeEntry, knownUplink *sriovnetworkv1.OVSUplinkConfigExt) ([]sriovnetworkv1.OVSRepresentorsConfigExt, error) {
+	var result []sriovnetworkv1.OVSRepresentorsConfigExt
+OUTER:
+	for _, group := range knownUplink.Representors {
+		repNames, err := o.getRepresentorNames(knownUplink.Name, group.VfRange)
+		if err != nil {
+			funcLog.V(2).Info("getCurrentBridgeState(): failed to get VF representors", "pf", knownUplink.Name, "vfRange", group.VfRange, "error", err)
+			continue
+		}
+		for _, repName := range repNames {
+			iface, port, err := o.getUplinkInterface(ctx, funcLog, dbClient, bridge, repName)
+			if err != nil {
+				return nil, err
+			}
+			if iface == nil || iface.ExternalIDs[representorBridgeKey] != bridge.Name ||
+				!equality.Semantic.DeepEqual(getRepresentorPortConfig(port), normalizeRepresentorPortConfig(group.Port)) {
+				continue OUTER
+			}
+		}
+		result = append(result, *group.DeepCopy())
+	}
+	return result, nil
+}
+
+// getUplinkInterface returns the uplink interface and its port,
+// nil is returned if the interface doesn't exist, has an error or is attached to a different bridge
+func (o *ovs) getUplinkInterface(ctx context.Context, funcLog logr.Logger, dbClient client.Client,
+	bridge *BridgeEntry, ifaceName string) (*InterfaceEntry, *PortEntry, error) {
+	iface, err := o.getInterfaceByName(ctx, dbClient, ifaceName)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if iface == nil {
-		return currentConfig, nil
+		return nil, nil, nil
 	}
-
 	if iface.Error != nil {
 		funcLog.V(2).Info("getCurrentBridgeState(): interface has an error, remove it from the bridge state", "interface", iface.Name, "error", iface.Error)
 		// interface has an error, do not report info about it to let the operator try to recreate it
-		return currentConfig, nil
+		return nil, nil, nil
 	}
-
 	port, err := o.getPortByInterface(ctx, dbClient, iface)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	if port == nil {
-		return currentConfig, nil
-	}
-
-	if !bridge.HasPort(port.UUID) {
+	if port == nil || !bridge.HasPort(port.UUID) {
 		// interface belongs to a wrong bridge, do not include uplink config to
 		// the current bridge state to let the operator try to fix this
-		return currentConfig, nil
+		return nil, nil, nil
 	}
-	currentConfig.Uplinks = []sriovnetworkv1.OVSUplinkConfigExt{{
-		PciAddress: knownConfigUplink.PciAddress,
-		Name:       knownConfigUplink.Name,
+	return iface, port, nil
+}
+
+// getUplinkState returns the current state of the uplink,
+// knownConfig is used to check which fields are managed by the operator
+func getUplinkState(knownConfig, uplink *sriovnetworkv1.OVSUplinkConfigExt, iface *InterfaceEntry) sriovnetworkv1.OVSUplinkConfigExt {
+	state := sriovnetworkv1.OVSUplinkConfigExt{
+		PciAddress: uplink.PciAddress,
+		Name:       uplink.Name,
 		Interface: sriovnetworkv1.OVSInterfaceConfig{
 			Type:        iface.Type,
-			ExternalIDs: updateMap(knownConfigUplink.Interface.ExternalIDs, iface.ExternalIDs),
-			Options:     updateMap(knownConfigUplink.Interface.Options, iface.Options),
-			OtherConfig: updateMap(knownConfigUplink.Interface.OtherConfig, iface.OtherConfig),
+			ExternalIDs: updateMap(knownConfig.Interface.ExternalIDs, iface.ExternalIDs),
+			Options:     updateMap(knownConfig.Interface.Options, iface.Options),
+			OtherConfig: updateMap(knownConfig.Interface.OtherConfig, iface.OtherConfig),
 		},
-	}}
+	}
 	if iface.MTURequest != nil {
 		mtu := *iface.MTURequest
-		currentConfig.Uplinks[0].Interface.MTURequest = &mtu
+		state.Interface.MTURequest = &mtu
 	}
-	return currentConfig, nil
+	return state
+}
+
+// getUplinkInterfaceNames returns the names of the interfaces used as the uplinks of the bridge,
+// the bond interface if set or the PFs
+func getUplinkInterfaceNames(conf *sriovnetworkv1.OVSConfigExt) []string {
+	if conf.Bond != nil {
+		return []string{conf.Bond.Name}
+	}
+	names := make([]string, 0, len(conf.Uplinks))
+	for _, uplink := range conf.Uplinks {
+		names = append(names, uplink.Name)
+	}
+	return names
 }
 
 func (o *ovs) getRootObj(ctx context.Context, dbClient client.Client) (*OpenvSwitchEntry, error) {
@@ -650,6 +1098,73 @@ func (o *ovs) getRootObj(ctx context.Context, dbClient client.Client) (*OpenvSwi
 	return ovsList[0], nil
 }
 
+// GetHwOffloadConfig returns the hardware offload settings from the Open_vSwitch table
+func (o *ovs) GetHwOffloadConfig(ctx context.Context) (*sriovnetworkv1.OvsHardwareOffload, error) {
+	ctx, cancel := setDefaultTimeout(ctx)
+	defer cancel()
+	dbClient, err := getClient(ctx)
+	if err != nil {
+		log.Log.Error(err, "GetHwOffloadConfig(): failed to connect to OVSDB")
+		return nil, fmt.Errorf("failed to connect to OVSDB: %v", err)
+	}
+	defer dbClient.Close()
+	rootObj, err := o.getRootObj(ctx, dbClient)
+	if err != nil {
+		return nil, err
+	}
+	return getHwOffloadConfig(rootObj), nil
+}
+
+// SetHwOffloadConfig updates the hardware offload settings in the Open_vSwitch table,
+// returns true if the settings were changed and OVS needs to be restarted to apply them
+func (o *ovs) SetHwOffloadConfig(ctx context.Context, conf *sriovnetworkv1.OvsHardwareOffload) (bool, error) {
+	ctx, cancel := setDefaultTimeout(ctx)
+	defer cancel()
+	funcLog := log.Log.WithValues("hwOffload", conf.Enabled, "tcPolicy", conf.TcPolicy)
+	dbClient, err := getClient(ctx)
+	if err != nil {
+		funcLog.Error(err, "SetHwOffloadConfig(): failed to connect to OVSDB")
+		return false, fmt.Errorf("failed to connect to OVSDB: %v", err)
+	}
+	defer dbClient.Close()
+	rootObj, err := o.getRootObj(ctx, dbClient)
+	if err != nil {
+		return false, err
+	}
+	current := getHwOffloadConfig(rootObj)
+	if current.Enabled == conf.Enabled && (conf.TcPolicy == "" || current.TcPolicy == conf.TcPolicy) {
+		funcLog.V(2).Info("SetHwOffloadConfig(): hardware offload settings already match, no actions required")
+		return false, nil
+	}
+	otherConfig := map[string]string{}
+	for k, v := range rootObj.OtherConfig {
+		otherConfig[k] = v
+	}
+	otherConfig[hwOffloadKey] = strconv.FormatBool(conf.Enabled)
+	if conf.TcPolicy != "" {
+		otherConfig[tcPolicyKey] = conf.TcPolicy
+	}
+	rootObj.OtherConfig = otherConfig
+	updateOps, err := dbClient.Where(rootObj).Update(rootObj, &rootObj.OtherConfig)
+	if err != nil {
+		return false, fmt.Errorf("failed to prepare operation for Open_vSwitch table update: %v", err)
+	}
+	funcLog.V(2).Info("SetHwOffloadConfig(): update hardware offload settings")
+	if err := o.execTransaction(ctx, dbClient, updateOps); err != nil {
+		funcLog.Error(err, "SetHwOffloadConfig(): failed to update hardware offload settings")
+		return false, err
+	}
+	return true, nil
+}
+
+// getHwOffloadConfig returns the hardware offload settings from the other_config of the root object
+func getHwOffloadConfig(rootObj *OpenvSwitchEntry) *sriovnetworkv1.OvsHardwareOffload {
+	return &sriovnetworkv1.OvsHardwareOffload{
+		Enabled:  rootObj.OtherConfig[hwOffloadKey] == "true",
+		TcPolicy: rootObj.OtherConfig[tcPolicyKey],
+	}
+}
+
 // if the provided context has no timeout, the default timeout will be set
 func setDefaultTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
 	_, ok := ctx.Deadline()
@@ -734,6 +1249,7 @@ func getClient(ctx context.Context) (client.Client, error) {
 		client.WithTable(openvSwitchEntry,
 			&openvSwitchEntry.UUID,
 			&openvSwitchEntry.Bridges,
+			&openvSwitchEntry.OtherConfig,
 		),
 		client.WithTable(bridgeEntry,
 			&bridgeEntry.UUID,
@@ -757,6 +1273,11 @@ func getClient(ctx context.Context) (client.Client, error) {
 			&portEntry.UUID,
 			&portEntry.Name,
 			&portEntry.Interfaces,
+			&portEntry.BondMode,
+			&portEntry.LACP,
+			&portEntry.Tag,
+			&portEntry.Trunks,
+			&portEntry.VlanMode,
 		),
 	))
 	if err != nil {
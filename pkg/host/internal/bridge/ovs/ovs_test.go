@@ -21,6 +21,7 @@ import (
 
 	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	ovsStoreMockPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/bridge/ovs/store/mock"
+	sriovnetMockPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/sriovnet/mock"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/test/util/fakefilesystem"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/test/util/helpers"
@@ -297,6 +298,7 @@ var _ = Describe("OVS", func() {
 	Context("manage bridges", func() {
 		var (
 			store            *ovsStoreMockPkg.MockStore
+			sriovnetLib      *sriovnetMockPkg.MockSriovnetLib
 			testCtrl         *gomock.Controller
 			tempDir          string
 			testServerSocket string
@@ -312,12 +314,12 @@ var _ = Describe("OVS", func() {
 			Expect(err).NotTo(HaveOccurred())
 			testCtrl = gomock.NewController(GinkgoT())
 			store = ovsStoreMockPkg.NewMockStore(testCtrl)
-			_ = store
+			sriovnetLib = sriovnetMockPkg.NewMockSriovnetLib(testCtrl)
 			stopServerFunc = startServer("unix", testServerSocket)
 			vars.OVSDBSocketPath = "unix://" + testServerSocket
 			ovsClient, err = getClient(ctx)
 			Expect(err).NotTo(HaveOccurred())
-			ovs = New(store)
+			ovs = New(store, sriovnetLib)
 		})
 
 		AfterEach(func() {
@@ -365,6 +367,173 @@ var _ = Describe("OVS", func() {
 
 				validateDBConfig(getDBContent(ctx, ovsClient), expectedConf)
 			})
+			It("No Bridge, create bridge with bond uplink", func() {
+				conf := getManagedBridges()["br-0000_d8_00.0"]
+				conf.Name = "br-bond0"
+				conf.Bond = &sriovnetworkv1.OVSBondConfig{Name: "bond0", Mode: "802.3ad"}
+				member := conf.Uplinks[0]
+				member.PciAddress = "0000:d8:00.1"
+				member.Name = "enp216s0f1np1"
+				conf.Uplinks = append(conf.Uplinks, member)
+				store.EXPECT().GetManagedOVSBridge("br-bond0").Return(nil, nil)
+
+				rootUUID := uuid.NewString()
+				createInitialDBContent(ctx, ovsClient, &testDBEntries{OpenVSwitch: []*OpenvSwitchEntry{{UUID: rootUUID}}})
+
+				store.EXPECT().AddManagedOVSBridge(conf).Return(nil)
+				Expect(ovs.CreateOVSBridge(ctx, conf)).NotTo(HaveOccurred())
+
+				// the bond is attached to the bridge instead of the PFs
+				expectedDBConf := conf.DeepCopy()
+				expectedDBConf.Uplinks = expectedDBConf.Uplinks[:1]
+				expectedDBConf.Uplinks[0].Name = "bond0"
+				validateDBConfig(getDBContent(ctx, ovsClient), expectedDBConf)
+
+				store.EXPECT().GetManagedOVSBridges().Return(map[string]*sriovnetworkv1.OVSConfigExt{conf.Name: conf}, nil)
+				ret, err := ovs.GetOVSBridges(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ret).To(HaveLen(1))
+				Expect(ret[0]).To(Equal(*conf))
+			})
+			It("No Bridge, create bridge with multiple uplinks", func() {
+				conf := getManagedBridges()["br-0000_d8_00.0"]
+				conf.Name = "br-shared"
+				member := conf.Uplinks[0]
+				member.PciAddress = "0000:d8:00.1"
+				member.Name = "enp216s0f1np1"
+				conf.Uplinks = append(conf.Uplinks, member)
+				store.EXPECT().GetManagedOVSBridge("br-shared").Return(nil, nil)
+
+				rootUUID := uuid.NewString()
+				createInitialDBContent(ctx, ovsClient, &testDBEntries{OpenVSwitch: []*OpenvSwitchEntry{{UUID: rootUUID}}})
+
+				store.EXPECT().AddManagedOVSBridge(conf).Return(nil)
+				Expect(ovs.CreateOVSBridge(ctx, conf)).NotTo(HaveOccurred())
+
+				// each uplink is attached to the bridge as a separate port
+				dbContent := getDBContent(ctx, ovsClient)
+				Expect(dbContent.Bridge).To(HaveLen(1))
+				Expect(dbContent.Port).To(HaveLen(3))
+				Expect(dbContent.Interface).To(HaveLen(3))
+				for _, p := range dbContent.Port {
+					Expect(p.Interfaces).To(HaveLen(1))
+					Expect(dbContent.Bridge[0].Ports).To(ContainElement(p.UUID))
+				}
+
+				store.EXPECT().GetManagedOVSBridges().Return(map[string]*sriovnetworkv1.OVSConfigExt{conf.Name: conf}, nil)
+				ret, err := ovs.GetOVSBridges(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ret).To(HaveLen(1))
+				Expect(ret[0]).To(Equal(*conf))
+			})
+			It("No Bridge, create bridge with bond port", func() {
+				conf := getManagedBridges()["br-0000_d8_00.0"]
+				conf.Name = "br-shared"
+				conf.PortBond = &sriovnetworkv1.OVSPortBondConfig{Name: "bond-shared", Mode: "balance-tcp", LACP: "active"}
+				member := conf.Uplinks[0]
+				member.PciAddress = "0000:d8:00.1"
+				member.Name = "enp216s0f1np1"
+				conf.Uplinks = append(conf.Uplinks, member)
+				store.EXPECT().GetManagedOVSBridge("br-shared").Return(nil, nil)
+
+				rootUUID := uuid.NewString()
+				createInitialDBContent(ctx, ovsClient, &testDBEntries{OpenVSwitch: []*OpenvSwitchEntry{{UUID: rootUUID}}})
+
+				store.EXPECT().AddManagedOVSBridge(conf).Return(nil)
+				Expect(ovs.CreateOVSBridge(ctx, conf)).NotTo(HaveOccurred())
+
+				// the uplinks are attached to the bridge as a single bond port
+				dbContent := getDBContent(ctx, ovsClient)
+				Expect(dbContent.Port).To(HaveLen(2))
+				Expect(dbContent.Interface).To(HaveLen(3))
+				var bondPort *PortEntry
+				for _, p := range dbContent.Port {
+					if p.Name == "bond-shared" {
+						bondPort = p
+					}
+				}
+				Expect(bondPort).NotTo(BeNil())
+				Expect(bondPort.Interfaces).To(HaveLen(2))
+				Expect(*bondPort.BondMode).To(Equal("balance-tcp"))
+				Expect(*bondPort.LACP).To(Equal("active"))
+
+				store.EXPECT().GetManagedOVSBridges().Return(map[string]*sriovnetworkv1.OVSConfigExt{conf.Name: conf}, nil)
+				ret, err := ovs.GetOVSBridges(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ret).To(HaveLen(1))
+				Expect(ret[0]).To(Equal(*conf))
+
+				// detach one member from the bond port
+				store.EXPECT().GetManagedOVSBridges().Return(map[string]*sriovnetworkv1.OVSConfigExt{conf.Name: conf}, nil)
+				Expect(ovs.RemoveInterfaceFromOVSBridge(ctx, "0000:d8:00.1")).NotTo(HaveOccurred())
+				dbContent = getDBContent(ctx, ovsClient)
+				Expect(dbContent.Port).To(HaveLen(2))
+				Expect(dbContent.Interface).To(HaveLen(2))
+
+				store.EXPECT().GetManagedOVSBridges().Return(map[string]*sriovnetworkv1.OVSConfigExt{conf.Name: conf}, nil)
+				ret, err = ovs.GetOVSBridges(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ret[0].Uplinks).To(HaveLen(1))
+				Expect(ret[0].Uplinks[0].Name).To(Equal("enp216s0f0np0"))
+			})
+			It("Bridge exists, attach VF representors", func() {
+				storedConf := getManagedBridges()["br-0000_d8_00.0"]
+				store.EXPECT().GetManagedOVSBridge("br-0000_d8_00.0").Return(storedConf, nil).Times(2)
+				initialDBContent := getDefaultInitialDBContent()
+				createInitialDBContent(ctx, ovsClient, initialDBContent)
+				sriovnetLib.EXPECT().GetVfRepresentor("enp216s0f0np0", 0).Return("pf0vf0", nil).AnyTimes()
+				sriovnetLib.EXPECT().GetVfRepresentor("enp216s0f0np0", 1).Return("pf0vf1", nil).AnyTimes()
+
+				conf := storedConf.DeepCopy()
+				conf.Uplinks[0].Representors = []sriovnetworkv1.OVSRepresentorsConfigExt{{
+					VfRange: "0-1",
+					Port:    sriovnetworkv1.OVSRepresentorPortConfig{Tag: 100, VlanMode: "access"},
+				}}
+				store.EXPECT().AddManagedOVSBridge(conf).Return(nil)
+				Expect(ovs.CreateOVSBridge(ctx, conf)).NotTo(HaveOccurred())
+
+				// the uplink is not recreated, representors are added as separate ports
+				dbContent := getDBContent(ctx, ovsClient)
+				Expect(dbContent.Bridge[0].UUID).To(Equal(initialDBContent.Bridge[0].UUID))
+				Expect(dbContent.Port).To(HaveLen(3))
+				Expect(dbContent.Interface).To(HaveLen(3))
+				for _, p := range dbContent.Port {
+					if p.Name == "pf0vf0" || p.Name == "pf0vf1" {
+						Expect(*p.Tag).To(Equal(100))
+						Expect(*p.VlanMode).To(Equal("access"))
+					}
+				}
+				for _, i := range dbContent.Interface {
+					if i.Name == "pf0vf0" || i.Name == "pf0vf1" {
+						Expect(i.ExternalIDs).To(HaveKeyWithValue(representorBridgeKey, "br-0000_d8_00.0"))
+						Expect(i.ExternalIDs).To(HaveKeyWithValue(representorPFKey, "0000:d8:00.0"))
+					}
+				}
+
+				store.EXPECT().GetManagedOVSBridges().Return(map[string]*sriovnetworkv1.OVSConfigExt{conf.Name: conf}, nil)
+				ret, err := ovs.GetOVSBridges(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ret).To(HaveLen(1))
+				Expect(ret[0]).To(Equal(*conf))
+
+				// shrink the VF range, representor of the VF 1 should be removed
+				newConf := conf.DeepCopy()
+				newConf.Uplinks[0].Representors[0].VfRange = "0-0"
+				store.EXPECT().AddManagedOVSBridge(newConf).Return(nil)
+				Expect(ovs.CreateOVSBridge(ctx, newConf)).NotTo(HaveOccurred())
+				dbContent = getDBContent(ctx, ovsClient)
+				Expect(dbContent.Port).To(HaveLen(2))
+				Expect(dbContent.Interface).To(HaveLen(2))
+
+				// detach the PF, representors should be removed
+				store.EXPECT().GetManagedOVSBridges().Return(map[string]*sriovnetworkv1.OVSConfigExt{newConf.Name: newConf}, nil)
+				store.EXPECT().RemoveManagedOVSBridge(newConf.Name).Return(nil).AnyTimes()
+				Expect(ovs.RemoveInterfaceFromOVSBridge(ctx, "0000:d8:00.0")).NotTo(HaveOccurred())
+				dbContent = getDBContent(ctx, ovsClient)
+				for _, i := range dbContent.Interface {
+					Expect(i.Name).NotTo(Equal("pf0vf0"))
+				}
+			})
 			It("Bridge exist, no data in store, should recreate", func() {
 				expectedConf := getManagedBridges()["br-0000_d8_00.0"]
 				store.EXPECT().GetManagedOVSBridge("br-0000_d8_00.0").Return(nil, nil)
@@ -596,6 +765,39 @@ var _ = Describe("OVS", func() {
 				Expect(ovs.RemoveInterfaceFromOVSBridge(ctx, "0000:d8:00.0")).NotTo(HaveOccurred())
 			})
 		})
+		Context("HwOffloadConfig", func() {
+			It("should enable hardware offload and keep other settings", func() {
+				initialDBContent := &testDBEntries{OpenVSwitch: []*OpenvSwitchEntry{{
+					UUID:        uuid.NewString(),
+					OtherConfig: map[string]string{"other_key": "other_value"},
+				}}}
+				createInitialDBContent(ctx, ovsClient, initialDBContent)
+				conf, err := ovs.GetHwOffloadConfig(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(conf.Enabled).To(BeFalse())
+
+				changed, err := ovs.SetHwOffloadConfig(ctx, &sriovnetworkv1.OvsHardwareOffload{Enabled: true, TcPolicy: "skip_sw"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(changed).To(BeTrue())
+				dbContent := getDBContent(ctx, ovsClient)
+				Expect(dbContent.OpenVSwitch[0].OtherConfig).To(Equal(map[string]string{
+					"other_key": "other_value", "hw-offload": "true", "tc-policy": "skip_sw"}))
+
+				conf, err = ovs.GetHwOffloadConfig(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(conf).To(Equal(&sriovnetworkv1.OvsHardwareOffload{Enabled: true, TcPolicy: "skip_sw"}))
+			})
+			It("should do nothing if settings already match", func() {
+				initialDBContent := &testDBEntries{OpenVSwitch: []*OpenvSwitchEntry{{
+					UUID:        uuid.NewString(),
+					OtherConfig: map[string]string{"hw-offload": "true", "tc-policy": "none"},
+				}}}
+				createInitialDBContent(ctx, ovsClient, initialDBContent)
+				changed, err := ovs.SetHwOffloadConfig(ctx, &sriovnetworkv1.OvsHardwareOffload{Enabled: true})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(changed).To(BeFalse())
+			})
+		})
 	})
 
 })
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -29,6 +30,16 @@ func (k *kernel) LoadKernelModule(name string, args ...string) error {
 	chrootDefinition := utils.GetChrootExtension()
 	cmdArgs := strings.Join(args, " ")
 
+	// persist the module arguments so they are also used when the module is loaded on boot,
+	// they are kept apart from the parameters of the SriovNetworkPoolConfig which override them
+	if len(args) > 0 {
+		path := filepath.Join(utils.GetHostExtension(), consts.ModprobeConfigFolder, getModprobeLoadConfigFileName(name))
+		if err := writeModprobeConfig(path, name, args); err != nil {
+			log.Log.Error(err, "LoadKernelModule(): failed to persist kernel module arguments", "name", name, "args", args)
+			return err
+		}
+	}
+
 	// check if the driver is already loaded in to the system
 	isLoaded, err := k.IsKernelModuleLoaded(name)
 	if err != nil {
@@ -71,6 +82,74 @@ func (k *kernel) IsKernelModuleLoaded(kernelModuleName string) (bool, error) {
 	return false, nil
 }
 
+// SetKernelModuleParams persists the kernel module parameters in the /etc/modprobe.d folder of the host,
+// the configuration file is removed if the list of parameters is empty
+func (k *kernel) SetKernelModuleParams(name string, params []string) error {
+	log.Log.Info("SetKernelModuleParams(): set kernel module parameters", "name", name, "params", params)
+	path := filepath.Join(utils.GetHostExtension(), consts.ModprobeConfigFolder, getModprobeConfigFileName(name))
+
+	if len(params) == 0 {
+		err := os.Remove(path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.Log.Error(err, "SetKernelModuleParams(): failed to remove kernel module config file", "path", path)
+			return err
+		}
+		return nil
+	}
+
+	return writeModprobeConfig(path, name, params)
+}
+
+// writeModprobeConfig writes the options of the kernel module to the modprobe.d configuration file,
+// the file is not rewritten if its content didn't change
+func writeModprobeConfig(path, name string, params []string) error {
+	config := fmt.Sprintf("# This file is managed by sriov-network-operator do not edit.\noptions %s %s\n", name, strings.Join(params, " "))
+	current, err := os.ReadFile(path)
+	if err == nil && string(current) == config {
+		log.Log.V(2).Info("writeModprobeConfig(): kernel module config file is up to date", "path", path)
+		return nil
+	}
+	if err := os.WriteFile(path, []byte(config), 0644); err != nil {
+		log.Log.Error(err, "writeModprobeConfig(): failed to write kernel module config file", "path", path)
+		return fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	return nil
+}
+
+// IsKernelModuleParamsSet compares the requested parameters with the values exposed in /sys/module/<name>/parameters,
+// parameters that are not exposed in the sysfs can't be verified and are considered as set.
+// Returns true if the module is not loaded as the parameters will be used on the next module load.
+func (k *kernel) IsKernelModuleParamsSet(name string, params []string) (bool, error) {
+	modulePath := filepath.Join(vars.FilesystemRoot, consts.SysModule, strings.ReplaceAll(name, "-", "_"))
+	if _, err := os.Stat(modulePath); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			log.Log.V(2).Info("IsKernelModuleParamsSet(): kernel module is not loaded", "name", name)
+			return true, nil
+		}
+		return false, err
+	}
+
+	for _, param := range params {
+		key, value, _ := strings.Cut(param, "=")
+		data, err := os.ReadFile(filepath.Join(modulePath, "parameters", key))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				log.Log.V(2).Info("IsKernelModuleParamsSet(): kernel module parameter is not exposed, skip", "name", name, "param", key)
+				continue
+			}
+			log.Log.Error(err, "IsKernelModuleParamsSet(): failed to read kernel module parameter", "name", name, "param", key)
+			return false, err
+		}
+		current := strings.TrimSpace(string(data))
+		if normalizeModuleParamValue(current) != normalizeModuleParamValue(value) {
+			log.Log.Info("IsKernelModuleParamsSet(): kernel module parameter mismatch",
+				"name", name, "param", key, "current", current, "desired", value)
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 func (k *kernel) TryEnableTun() {
 	if err := k.LoadKernelModule("tun"); err != nil {
 		log.Log.Error(err, "tryEnableTun(): TUN kernel module not loaded")
@@ -98,6 +177,37 @@ func (k *kernel) GetCurrentKernelArgs() (string, error) {
 	return string(cmdLine), nil
 }
 
+// GetBootID returns the random ID the kernel generates on every boot,
+// the ID changes after each reboot including a kexec into a new kernel
+func (k *kernel) GetBootID() (string, error) {
+	path := consts.ProcKernelBootID
+	if !vars.UsingSystemdMode {
+		path = filepath.Join(consts.Host, path)
+	}
+
+	path = filepath.Join(vars.FilesystemRoot, path)
+	bootID, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("GetBootID(): Error reading %s: %v", path, err)
+	}
+	return strings.TrimSpace(string(bootID)), nil
+}
+
+// GetKernelRelease returns the release of the running kernel
+func (k *kernel) GetKernelRelease() (string, error) {
+	path := consts.ProcKernelOSRelease
+	if !vars.UsingSystemdMode {
+		path = filepath.Join(consts.Host, path)
+	}
+
+	path = filepath.Join(vars.FilesystemRoot, path)
+	release, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("GetKernelRelease(): Error reading %s: %v", path, err)
+	}
+	return strings.TrimSpace(string(release)), nil
+}
+
 // IsKernelArgsSet This checks if the kernel cmd line is set properly. Please note that the same key could be repeated
 // several times in the kernel cmd line. We can only ensure that the kernel cmd line has the key/val kernel arg that we set.
 func (k *kernel) IsKernelArgsSet(cmdLine string, karg string) bool {
@@ -310,6 +420,134 @@ func (k *kernel) IsKernelLockdownMode() bool {
 	return strings.Contains(stdout, "[integrity]") || strings.Contains(stdout, "[confidentiality]")
 }
 
+// GetDeviceNumaNode reads the NUMA node of the device from the sysfs,
+// the kernel reports -1 if the host has no NUMA topology
+func (k *kernel) GetDeviceNumaNode(pciAddr string) (int, error) {
+	path := filepath.Join(vars.FilesystemRoot, consts.SysBusPciDevices, pciAddr, "numa_node")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		log.Log.Error(err, "GetDeviceNumaNode(): failed to read numa_node", "device", pciAddr)
+		return 0, err
+	}
+	numaNode, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse numa_node for device %s: %v", pciAddr, err)
+	}
+	if numaNode < 0 {
+		return 0, nil
+	}
+	return numaNode, nil
+}
+
+// GetHugepages reads the hugepages counters from /sys/devices/system/node/node<N>/hugepages/hugepages-<size>kB
+func (k *kernel) GetHugepages() ([]sriovnetworkv1.HugepagesStatus, error) {
+	nodes, err := filepath.Glob(filepath.Join(vars.FilesystemRoot, consts.SysDevicesSystemNode, "node[0-9]*"))
+	if err != nil {
+		return nil, err
+	}
+	result := []sriovnetworkv1.HugepagesStatus{}
+	for _, nodePath := range nodes {
+		numaNode, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(nodePath), "node"))
+		if err != nil {
+			continue
+		}
+		pagesDirs, err := filepath.Glob(filepath.Join(nodePath, "hugepages", "hugepages-*kB"))
+		if err != nil {
+			return nil, err
+		}
+		for _, pagesDir := range pagesDirs {
+			sizeKB, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(filepath.Base(pagesDir), "hugepages-"), "kB"))
+			if err != nil {
+				continue
+			}
+			total, err := readIntFromFile(filepath.Join(pagesDir, "nr_hugepages"))
+			if err != nil {
+				return nil, err
+			}
+			free, err := readIntFromFile(filepath.Join(pagesDir, "free_hugepages"))
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, sriovnetworkv1.HugepagesStatus{
+				NumaNode: numaNode,
+				Size:     hugepagesSizeFromKB(sizeKB),
+				Total:    total,
+				Free:     free,
+			})
+		}
+	}
+	return result, nil
+}
+
+// SetHugepages writes the requested count of hugepages to the nr_hugepages file of the NUMA node,
+// the kernel may allocate less pages than requested if there is not enough contiguous memory
+func (k *kernel) SetHugepages(numaNode int, size string, count int) error {
+	log.Log.Info("SetHugepages(): set hugepages", "numaNode", numaNode, "size", size, "count", count)
+	sizeKB, err := hugepagesSizeToKB(size)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(vars.FilesystemRoot, consts.SysDevicesSystemNode, fmt.Sprintf("node%d", numaNode),
+		"hugepages", fmt.Sprintf("hugepages-%dkB", sizeKB), "nr_hugepages")
+	if err := os.WriteFile(path, []byte(strconv.Itoa(count)), os.ModeAppend); err != nil {
+		log.Log.Error(err, "SetHugepages(): failed to write nr_hugepages", "path", path)
+		return err
+	}
+	return nil
+}
+
+// returns the size of the hugepage in kB, e.g. 2048 for "2M"
+func hugepagesSizeToKB(size string) (int, error) {
+	switch size {
+	case "2M":
+		return 2048, nil
+	case "1G":
+		return 1048576, nil
+	}
+	return 0, fmt.Errorf("unsupported hugepages size %q", size)
+}
+
+// returns the hugepage size in the format used by the API, e.g. "2M" for 2048
+func hugepagesSizeFromKB(sizeKB int) string {
+	switch {
+	case sizeKB%1048576 == 0:
+		return fmt.Sprintf("%dG", sizeKB/1048576)
+	case sizeKB%1024 == 0:
+		return fmt.Sprintf("%dM", sizeKB/1024)
+	}
+	return fmt.Sprintf("%dK", sizeKB)
+}
+
+func readIntFromFile(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(data)))
+}
+
+// returns the name of the modprobe.d configuration file for the kernel module
+func getModprobeConfigFileName(name string) string {
+	return fmt.Sprintf("sriov_network_operator_module_%s.conf", name)
+}
+
+// the files are read in the lexical order by modprobe, the arguments of LoadKernelModule are
+// read before the parameters from the SriovNetworkPoolConfig so the latter take precedence
+func getModprobeLoadConfigFileName(name string) string {
+	return fmt.Sprintf("sriov_network_operator_load_%s.conf", name)
+}
+
+// boolean module parameters are reported as Y/N in the sysfs but can be set with 1/0
+func normalizeModuleParamValue(value string) string {
+	switch value {
+	case "1", "y", "Y":
+		return "Y"
+	case "0", "n", "N":
+		return "N"
+	}
+	return value
+}
+
 // returns driver for device on the bus
 func getDriverByBusAndDevice(bus, device string) (string, error) {
 	driverLink := filepath.Join(vars.FilesystemRoot, consts.SysBus, bus, "devices", device, "driver")
@@ -2,13 +2,17 @@ package kernel
 
 import (
 	"fmt"
+	"os"
 	"path"
+	"path/filepath"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	"go.uber.org/mock/gomock"
 
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/types"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils"
@@ -95,10 +99,148 @@ var _ = Describe("Kernel", func() {
 			})
 
 			It("should pass the args to the modprobe command", func() {
+				helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+					Dirs: []string{"/host/etc/modprobe.d"},
+				})
 				u.EXPECT().RunCommand("/bin/sh", "-c", fmt.Sprintf("chroot %s lsmod | grep \"^tun\"", getHost())).Return("", "", nil)
 				u.EXPECT().RunCommand("/bin/sh", "-c", fmt.Sprintf("chroot %s modprobe tun test=ok", getHost())).Return("", "", nil)
 				err := kMocked.LoadKernelModule("tun", "test=ok")
 				Expect(err).ToNot(HaveOccurred())
+				helpers.GinkgoAssertFileContentsEquals("/host/etc/modprobe.d/sriov_network_operator_load_tun.conf",
+					"# This file is managed by sriov-network-operator do not edit.\noptions tun test=ok\n")
+				// the file managed from the SriovNetworkPoolConfig is not touched
+				_, err = os.Stat(filepath.Join(vars.FilesystemRoot, "/host/etc/modprobe.d/sriov_network_operator_module_tun.conf"))
+				Expect(os.IsNotExist(err)).To(BeTrue())
+			})
+
+			It("should return error if not able to persist the args", func() {
+				helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{})
+				err := kMocked.LoadKernelModule("tun", "test=ok")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Context("SetKernelModuleParams", func() {
+			It("should write the module parameters", func() {
+				helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+					Dirs: []string{"/host/etc/modprobe.d"},
+				})
+				Expect(k.SetKernelModuleParams("mlx5_core", []string{"prof_sel=2", "probe_vf=0"})).To(Succeed())
+				helpers.GinkgoAssertFileContentsEquals("/host/etc/modprobe.d/sriov_network_operator_module_mlx5_core.conf",
+					"# This file is managed by sriov-network-operator do not edit.\noptions mlx5_core prof_sel=2 probe_vf=0\n")
+			})
+
+			It("should not rewrite the config file if the parameters didn't change", func() {
+				config := "# This file is managed by sriov-network-operator do not edit.\noptions mlx5_core prof_sel=2\n"
+				helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+					Dirs:  []string{"/host/etc/modprobe.d"},
+					Files: map[string][]byte{"/host/etc/modprobe.d/sriov_network_operator_module_mlx5_core.conf": []byte(config)},
+				})
+				path := filepath.Join(vars.FilesystemRoot, "/host/etc/modprobe.d/sriov_network_operator_module_mlx5_core.conf")
+				modTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+				Expect(os.Chtimes(path, modTime, modTime)).To(Succeed())
+				Expect(k.SetKernelModuleParams("mlx5_core", []string{"prof_sel=2"})).To(Succeed())
+				info, err := os.Stat(path)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(info.ModTime()).To(BeTemporally("==", modTime))
+			})
+
+			It("should remove the config file if there are no parameters", func() {
+				helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+					Dirs: []string{"/host/etc/modprobe.d"},
+					Files: map[string][]byte{
+						"/host/etc/modprobe.d/sriov_network_operator_module_mlx5_core.conf": []byte("options mlx5_core prof_sel=2")},
+				})
+				Expect(k.SetKernelModuleParams("mlx5_core", nil)).To(Succeed())
+				_, err := os.Stat(filepath.Join(vars.FilesystemRoot, "/host/etc/modprobe.d/sriov_network_operator_module_mlx5_core.conf"))
+				Expect(os.IsNotExist(err)).To(BeTrue())
+				// removing a missing file is not an error
+				Expect(k.SetKernelModuleParams("mlx5_core", nil)).To(Succeed())
+			})
+		})
+
+		Context("IsKernelModuleParamsSet", func() {
+			BeforeEach(func() {
+				helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+					Dirs: []string{"/sys/module/vfio_pci/parameters"},
+					Files: map[string][]byte{
+						"/sys/module/vfio_pci/parameters/disable_idle_d3": []byte("Y\n"),
+						"/sys/module/vfio_pci/parameters/nointxmask":      []byte("N\n")},
+				})
+			})
+
+			It("should return true if the module is not loaded", func() {
+				set, err := k.IsKernelModuleParamsSet("mlx5_core", []string{"prof_sel=2"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(set).To(BeTrue())
+			})
+
+			It("should return true if the parameters match", func() {
+				set, err := k.IsKernelModuleParamsSet("vfio-pci", []string{"disable_idle_d3=1", "nointxmask=N", "ids=8086:154c"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(set).To(BeTrue())
+			})
+
+			It("should return false if a parameter doesn't match", func() {
+				set, err := k.IsKernelModuleParamsSet("vfio_pci", []string{"disable_idle_d3=1", "nointxmask=1"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(set).To(BeFalse())
+			})
+		})
+
+		Context("Hugepages", func() {
+			BeforeEach(func() {
+				helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+					Dirs: []string{
+						"/sys/bus/pci/devices/0000:d8:00.0",
+						"/sys/bus/pci/devices/0000:3b:00.0",
+						"/sys/devices/system/node/node0/hugepages/hugepages-2048kB",
+						"/sys/devices/system/node/node0/hugepages/hugepages-1048576kB",
+						"/sys/devices/system/node/node1/hugepages/hugepages-1048576kB",
+					},
+					Files: map[string][]byte{
+						"/sys/bus/pci/devices/0000:d8:00.0/numa_node":                                 []byte("1\n"),
+						"/sys/bus/pci/devices/0000:3b:00.0/numa_node":                                 []byte("-1\n"),
+						"/sys/devices/system/node/node0/hugepages/hugepages-2048kB/nr_hugepages":      []byte("512\n"),
+						"/sys/devices/system/node/node0/hugepages/hugepages-2048kB/free_hugepages":    []byte("256\n"),
+						"/sys/devices/system/node/node0/hugepages/hugepages-1048576kB/nr_hugepages":   []byte("0\n"),
+						"/sys/devices/system/node/node0/hugepages/hugepages-1048576kB/free_hugepages": []byte("0\n"),
+						"/sys/devices/system/node/node1/hugepages/hugepages-1048576kB/nr_hugepages":   []byte("4\n"),
+						"/sys/devices/system/node/node1/hugepages/hugepages-1048576kB/free_hugepages": []byte("2\n"),
+					},
+				})
+			})
+
+			It("should return the NUMA node of the device", func() {
+				numaNode, err := k.GetDeviceNumaNode("0000:d8:00.0")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(numaNode).To(Equal(1))
+			})
+
+			It("should return NUMA node 0 if the host has no NUMA topology", func() {
+				numaNode, err := k.GetDeviceNumaNode("0000:3b:00.0")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(numaNode).To(Equal(0))
+			})
+
+			It("should return the hugepages of all the NUMA nodes", func() {
+				hugepages, err := k.GetHugepages()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(hugepages).To(ConsistOf(
+					sriovnetworkv1.HugepagesStatus{NumaNode: 0, Size: "2M", Total: 512, Free: 256},
+					sriovnetworkv1.HugepagesStatus{NumaNode: 0, Size: "1G", Total: 0, Free: 0},
+					sriovnetworkv1.HugepagesStatus{NumaNode: 1, Size: "1G", Total: 4, Free: 2},
+				))
+			})
+
+			It("should set the hugepages count on the NUMA node", func() {
+				Expect(k.SetHugepages(0, "1G", 8)).To(Succeed())
+				helpers.GinkgoAssertFileContentsEquals(
+					"/sys/devices/system/node/node0/hugepages/hugepages-1048576kB/nr_hugepages", "8")
+			})
+
+			It("should fail for an unsupported hugepages size", func() {
+				Expect(k.SetHugepages(0, "16G", 1)).ToNot(Succeed())
 			})
 		})
 
@@ -170,6 +312,42 @@ var _ = Describe("Kernel", func() {
 			})
 		})
 
+		Context("GetBootID", func() {
+			It("should return error if not able to read the boot_id file", func() {
+				bootID, err := k.GetBootID()
+				Expect(err).To(HaveOccurred())
+				Expect(bootID).To(Equal(""))
+			})
+
+			It("should return the boot id", func() {
+				helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+					Dirs: []string{
+						"/host/proc/sys/kernel/random"},
+					Files: map[string][]byte{
+						"/host/proc/sys/kernel/random/boot_id": []byte("b5e6e3a4-5b2c-4d0f-9a51-1e6a0f7d2c3e\n")},
+				})
+
+				bootID, err := k.GetBootID()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(bootID).To(Equal("b5e6e3a4-5b2c-4d0f-9a51-1e6a0f7d2c3e"))
+			})
+		})
+
+		Context("GetKernelRelease", func() {
+			It("should return the kernel release", func() {
+				helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+					Dirs: []string{
+						"/host/proc/sys/kernel"},
+					Files: map[string][]byte{
+						"/host/proc/sys/kernel/osrelease": []byte("5.14.0-427.el9.x86_64\n")},
+				})
+
+				release, err := k.GetKernelRelease()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(release).To(Equal("5.14.0-427.el9.x86_64"))
+			})
+		})
+
 		Context("IsKernelArgsSet", func() {
 			It("should return false if the kernel arg does not exist is cmdline", func() {
 				set := k.IsKernelArgsSet("iommu=pt", consts.KernelArgIntelIommu)
@@ -15,6 +15,7 @@ import (
 
 	netlink "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/netlink"
 	netlink0 "github.com/vishvananda/netlink"
+	nl "github.com/vishvananda/netlink/nl"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -94,6 +95,63 @@ func (m *MockNetlinkLib) EXPECT() *MockNetlinkLibMockRecorder {
 	return m.recorder
 }
 
+// BridgeSetVlanFiltering mocks base method.
+func (m *MockNetlinkLib) BridgeSetVlanFiltering(link netlink.Link, on bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BridgeSetVlanFiltering", link, on)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BridgeSetVlanFiltering indicates an expected call of BridgeSetVlanFiltering.
+func (mr *MockNetlinkLibMockRecorder) BridgeSetVlanFiltering(link, on any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BridgeSetVlanFiltering", reflect.TypeOf((*MockNetlinkLib)(nil).BridgeSetVlanFiltering), link, on)
+}
+
+// BridgeVlanAdd mocks base method.
+func (m *MockNetlinkLib) BridgeVlanAdd(link netlink.Link, vid uint16, pvid, untagged, self, master bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BridgeVlanAdd", link, vid, pvid, untagged, self, master)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BridgeVlanAdd indicates an expected call of BridgeVlanAdd.
+func (mr *MockNetlinkLibMockRecorder) BridgeVlanAdd(link, vid, pvid, untagged, self, master any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BridgeVlanAdd", reflect.TypeOf((*MockNetlinkLib)(nil).BridgeVlanAdd), link, vid, pvid, untagged, self, master)
+}
+
+// BridgeVlanDel mocks base method.
+func (m *MockNetlinkLib) BridgeVlanDel(link netlink.Link, vid uint16, pvid, untagged, self, master bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BridgeVlanDel", link, vid, pvid, untagged, self, master)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BridgeVlanDel indicates an expected call of BridgeVlanDel.
+func (mr *MockNetlinkLibMockRecorder) BridgeVlanDel(link, vid, pvid, untagged, self, master any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BridgeVlanDel", reflect.TypeOf((*MockNetlinkLib)(nil).BridgeVlanDel), link, vid, pvid, untagged, self, master)
+}
+
+// BridgeVlanList mocks base method.
+func (m *MockNetlinkLib) BridgeVlanList() (map[int32][]*nl.BridgeVlanInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BridgeVlanList")
+	ret0, _ := ret[0].(map[int32][]*nl.BridgeVlanInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BridgeVlanList indicates an expected call of BridgeVlanList.
+func (mr *MockNetlinkLibMockRecorder) BridgeVlanList() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BridgeVlanList", reflect.TypeOf((*MockNetlinkLib)(nil).BridgeVlanList))
+}
+
 // DevLinkGetDeviceByName mocks base method.
 func (m *MockNetlinkLib) DevLinkGetDeviceByName(bus, device string) (*netlink0.DevlinkDevice, error) {
 	m.ctrl.T.Helper()
@@ -166,6 +224,20 @@ func (mr *MockNetlinkLibMockRecorder) IsLinkAdminStateUp(link any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsLinkAdminStateUp", reflect.TypeOf((*MockNetlinkLib)(nil).IsLinkAdminStateUp), link)
 }
 
+// LinkAdd mocks base method.
+func (m *MockNetlinkLib) LinkAdd(link netlink.Link) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkAdd", link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkAdd indicates an expected call of LinkAdd.
+func (mr *MockNetlinkLibMockRecorder) LinkAdd(link any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkAdd", reflect.TypeOf((*MockNetlinkLib)(nil).LinkAdd), link)
+}
+
 // LinkByIndex mocks base method.
 func (m *MockNetlinkLib) LinkByIndex(index int) (netlink.Link, error) {
 	m.ctrl.T.Helper()
@@ -196,6 +268,20 @@ func (mr *MockNetlinkLibMockRecorder) LinkByName(name any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkByName", reflect.TypeOf((*MockNetlinkLib)(nil).LinkByName), name)
 }
 
+// LinkDel mocks base method.
+func (m *MockNetlinkLib) LinkDel(link netlink.Link) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkDel", link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkDel indicates an expected call of LinkDel.
+func (mr *MockNetlinkLibMockRecorder) LinkDel(link any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkDel", reflect.TypeOf((*MockNetlinkLib)(nil).LinkDel), link)
+}
+
 // LinkList mocks base method.
 func (m *MockNetlinkLib) LinkList() ([]netlink.Link, error) {
 	m.ctrl.T.Helper()
@@ -211,6 +297,34 @@ func (mr *MockNetlinkLibMockRecorder) LinkList() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkList", reflect.TypeOf((*MockNetlinkLib)(nil).LinkList))
 }
 
+// LinkSetAlias mocks base method.
+func (m *MockNetlinkLib) LinkSetAlias(link netlink.Link, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkSetAlias", link, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkSetAlias indicates an expected call of LinkSetAlias.
+func (mr *MockNetlinkLibMockRecorder) LinkSetAlias(link, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSetAlias", reflect.TypeOf((*MockNetlinkLib)(nil).LinkSetAlias), link, name)
+}
+
+// LinkSetDown mocks base method.
+func (m *MockNetlinkLib) LinkSetDown(link netlink.Link) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkSetDown", link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkSetDown indicates an expected call of LinkSetDown.
+func (mr *MockNetlinkLibMockRecorder) LinkSetDown(link any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSetDown", reflect.TypeOf((*MockNetlinkLib)(nil).LinkSetDown), link)
+}
+
 // LinkSetMTU mocks base method.
 func (m *MockNetlinkLib) LinkSetMTU(link netlink.Link, mtu int) error {
 	m.ctrl.T.Helper()
@@ -225,6 +339,48 @@ func (mr *MockNetlinkLibMockRecorder) LinkSetMTU(link, mtu any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSetMTU", reflect.TypeOf((*MockNetlinkLib)(nil).LinkSetMTU), link, mtu)
 }
 
+// LinkSetMaster mocks base method.
+func (m *MockNetlinkLib) LinkSetMaster(link, master netlink.Link) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkSetMaster", link, master)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkSetMaster indicates an expected call of LinkSetMaster.
+func (mr *MockNetlinkLibMockRecorder) LinkSetMaster(link, master any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSetMaster", reflect.TypeOf((*MockNetlinkLib)(nil).LinkSetMaster), link, master)
+}
+
+// LinkSetName mocks base method.
+func (m *MockNetlinkLib) LinkSetName(link netlink.Link, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkSetName", link, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkSetName indicates an expected call of LinkSetName.
+func (mr *MockNetlinkLibMockRecorder) LinkSetName(link, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSetName", reflect.TypeOf((*MockNetlinkLib)(nil).LinkSetName), link, name)
+}
+
+// LinkSetNoMaster mocks base method.
+func (m *MockNetlinkLib) LinkSetNoMaster(link netlink.Link) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkSetNoMaster", link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkSetNoMaster indicates an expected call of LinkSetNoMaster.
+func (mr *MockNetlinkLibMockRecorder) LinkSetNoMaster(link any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSetNoMaster", reflect.TypeOf((*MockNetlinkLib)(nil).LinkSetNoMaster), link)
+}
+
 // LinkSetUp mocks base method.
 func (m *MockNetlinkLib) LinkSetUp(link netlink.Link) error {
 	m.ctrl.T.Helper()
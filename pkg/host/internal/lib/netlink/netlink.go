@@ -4,6 +4,7 @@ import (
 	"net"
 
 	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
 )
 
 func New() NetlinkLib {
@@ -35,6 +36,39 @@ type NetlinkLib interface {
 	// LinkSetUp enables the link device.
 	// Equivalent to: `ip link set $link up`
 	LinkSetUp(link Link) error
+	// LinkSetDown disables the link device.
+	// Equivalent to: `ip link set $link down`
+	LinkSetDown(link Link) error
+	// LinkSetName sets the name of the link device.
+	// Equivalent to: `ip link set $link name $name`
+	LinkSetName(link Link, name string) error
+	// LinkAdd adds a new link device.
+	// Equivalent to: `ip link add $link`
+	LinkAdd(link Link) error
+	// LinkDel deletes the link device.
+	// Equivalent to: `ip link del $link`
+	LinkDel(link Link) error
+	// LinkSetMaster sets the master of the link device.
+	// Equivalent to: `ip link set $link master $master`
+	LinkSetMaster(link Link, master Link) error
+	// LinkSetNoMaster removes the master of the link device.
+	// Equivalent to: `ip link set $link nomaster`
+	LinkSetNoMaster(link Link) error
+	// LinkSetAlias sets the alias of the link device.
+	// Equivalent to: `ip link set $link alias $name`
+	LinkSetAlias(link Link, name string) error
+	// BridgeSetVlanFiltering enables or disables VLAN filtering on the bridge.
+	// Equivalent to: `ip link set $link type bridge vlan_filtering $on`
+	BridgeSetVlanFiltering(link Link, on bool) error
+	// BridgeVlanList gets a map of device id to bridge vlan infos.
+	// Equivalent to: `bridge vlan show`
+	BridgeVlanList() (map[int32][]*nl.BridgeVlanInfo, error)
+	// BridgeVlanAdd adds a new vlan filter entry
+	// Equivalent to: `bridge vlan add dev DEV vid VID [ pvid ] [ untagged ] [ self ] [ master ]`
+	BridgeVlanAdd(link Link, vid uint16, pvid, untagged, self, master bool) error
+	// BridgeVlanDel deletes a vlan filter entry
+	// Equivalent to: `bridge vlan del dev DEV vid VID [ pvid ] [ untagged ] [ self ] [ master ]`
+	BridgeVlanDel(link Link, vid uint16, pvid, untagged, self, master bool) error
 	// LinkSetMTU sets the mtu of the link device.
 	// Equivalent to: `ip link set $link mtu $mtu`
 	LinkSetMTU(link Link, mtu int) error
@@ -125,6 +159,72 @@ func (w *libWrapper) LinkSetUp(link Link) error {
 	return netlink.LinkSetUp(link)
 }
 
+// LinkSetDown disables the link device.
+// Equivalent to: `ip link set $link down`
+func (w *libWrapper) LinkSetDown(link Link) error {
+	return netlink.LinkSetDown(link)
+}
+
+// LinkSetName sets the name of the link device.
+// Equivalent to: `ip link set $link name $name`
+func (w *libWrapper) LinkSetName(link Link, name string) error {
+	return netlink.LinkSetName(link, name)
+}
+
+// LinkAdd adds a new link device.
+// Equivalent to: `ip link add $link`
+func (w *libWrapper) LinkAdd(link Link) error {
+	return netlink.LinkAdd(link)
+}
+
+// LinkDel deletes the link device.
+// Equivalent to: `ip link del $link`
+func (w *libWrapper) LinkDel(link Link) error {
+	return netlink.LinkDel(link)
+}
+
+// LinkSetMaster sets the master of the link device.
+// Equivalent to: `ip link set $link master $master`
+func (w *libWrapper) LinkSetMaster(link Link, master Link) error {
+	return netlink.LinkSetMaster(link, master)
+}
+
+// LinkSetNoMaster removes the master of the link device.
+// Equivalent to: `ip link set $link nomaster`
+func (w *libWrapper) LinkSetNoMaster(link Link) error {
+	return netlink.LinkSetNoMaster(link)
+}
+
+// LinkSetAlias sets the alias of the link device.
+// Equivalent to: `ip link set $link alias $name`
+func (w *libWrapper) LinkSetAlias(link Link, name string) error {
+	return netlink.LinkSetAlias(link, name)
+}
+
+// BridgeSetVlanFiltering enables or disables VLAN filtering on the bridge.
+// Equivalent to: `ip link set $link type bridge vlan_filtering $on`
+func (w *libWrapper) BridgeSetVlanFiltering(link Link, on bool) error {
+	return netlink.BridgeSetVlanFiltering(link, on)
+}
+
+// BridgeVlanList gets a map of device id to bridge vlan infos.
+// Equivalent to: `bridge vlan show`
+func (w *libWrapper) BridgeVlanList() (map[int32][]*nl.BridgeVlanInfo, error) {
+	return netlink.BridgeVlanList()
+}
+
+// BridgeVlanAdd adds a new vlan filter entry
+// Equivalent to: `bridge vlan add dev DEV vid VID [ pvid ] [ untagged ] [ self ] [ master ]`
+func (w *libWrapper) BridgeVlanAdd(link Link, vid uint16, pvid, untagged, self, master bool) error {
+	return netlink.BridgeVlanAdd(link, vid, pvid, untagged, self, master)
+}
+
+// BridgeVlanDel deletes a vlan filter entry
+// Equivalent to: `bridge vlan del dev DEV vid VID [ pvid ] [ untagged ] [ self ] [ master ]`
+func (w *libWrapper) BridgeVlanDel(link Link, vid uint16, pvid, untagged, self, master bool) error {
+	return netlink.BridgeVlanDel(link, vid, pvid, untagged, self, master)
+}
+
 // LinkSetMTU sets the mtu of the link device.
 // Equivalent to: `ip link set $link mtu $mtu`
 func (w *libWrapper) LinkSetMTU(link Link, mtu int) error {
@@ -0,0 +1,208 @@
+package netmanager
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/types"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
+)
+
+const (
+	SystemdNetworkd = "systemd-networkd"
+
+	// the systemd-networkd files are sorted before the default ones and the ones generated by netplan
+	networkdFilePrefix = "05-sriov-network-operator-"
+)
+
+// provider writes the configuration of a network manager.
+// The VFs are matched by a stable key, e.g. the udev property set by the udev rule of the PF, and not
+// by their netdev names, which change when the VFs are renamed, moved to a pod or bound to a DPDK driver.
+type provider interface {
+	// name returns the name of the network manager
+	name() string
+	// isUsed returns true if the network manager runs or configures the network on the host
+	isUsed() bool
+	// writeConfig writes the configuration that prevents the network manager from managing the VFs of the PF,
+	// returns true if the configuration changed
+	writeConfig(pfPciAddress string) (bool, error)
+	// removeConfig removes the configuration of the PF, returns true if the configuration changed
+	removeConfig(pfPciAddress string) (bool, error)
+	// reload makes the running network manager apply the configuration changes
+	reload() error
+}
+
+type netManager struct {
+	utilsHelper utils.CmdInterface
+	providers   []provider
+}
+
+// New returns the host network manager integration, it selects the providers of the network managers
+// detected on the host for each call.
+// Only systemd-networkd has a provider. No NetworkManager keyfile is written, its device specs match the
+// interface names, MACs or drivers of the VFs and none of them is stable, NetworkManager skips the VFs with
+// the NM_UNMANAGED udev property set by the udev rule of the PF instead. No netplan file is written either,
+// the VFs are left to the backend netplan renders its configuration for.
+func New(utilsHelper utils.CmdInterface) types.HostNetworkManagerInterface {
+	return &netManager{
+		utilsHelper: utilsHelper,
+		providers: []provider{
+			&networkdProvider{utilsHelper: utilsHelper},
+		},
+	}
+}
+
+// AddUnmanagedVfsConfig writes the configuration that prevents the network managers detected on the host
+// from managing or renaming the VFs of the PF
+func (n *netManager) AddUnmanagedVfsConfig(pfPciAddress string) error {
+	log.Log.V(2).Info("AddUnmanagedVfsConfig()", "device", pfPciAddress)
+	for _, p := range n.getUsedProviders() {
+		changed, err := p.writeConfig(pfPciAddress)
+		if err != nil {
+			log.Log.Error(err, "AddUnmanagedVfsConfig(): failed to write config", "manager", p.name(), "device", pfPciAddress)
+			return err
+		}
+		if changed {
+			if err := p.reload(); err != nil {
+				log.Log.Error(err, "AddUnmanagedVfsConfig(): failed to reload config", "manager", p.name())
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// RemoveUnmanagedVfsConfig removes the configuration written by AddUnmanagedVfsConfig for the PF,
+// the configuration is removed for all the network managers
+func (n *netManager) RemoveUnmanagedVfsConfig(pfPciAddress string) error {
+	log.Log.V(2).Info("RemoveUnmanagedVfsConfig()", "device", pfPciAddress)
+	for _, p := range n.providers {
+		changed, err := p.removeConfig(pfPciAddress)
+		if err != nil {
+			log.Log.Error(err, "RemoveUnmanagedVfsConfig(): failed to remove config", "manager", p.name(), "device", pfPciAddress)
+			return err
+		}
+		if changed && p.isUsed() {
+			if err := p.reload(); err != nil {
+				log.Log.Error(err, "RemoveUnmanagedVfsConfig(): failed to reload config", "manager", p.name())
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (n *netManager) getUsedProviders() []provider {
+	used := []provider{}
+	for _, p := range n.providers {
+		if p.isUsed() {
+			used = append(used, p)
+		}
+	}
+	return used
+}
+
+// networkdProvider writes a systemd-networkd .network file that marks the VFs as unmanaged
+// and a .link file that keeps the kernel names of the VFs.
+// The VFs are matched with the udev property set by the udev rule of the PF.
+type networkdProvider struct {
+	utilsHelper utils.CmdInterface
+}
+
+func (p *networkdProvider) name() string {
+	return SystemdNetworkd
+}
+
+func (p *networkdProvider) isUsed() bool {
+	return pathExists(consts.SystemdNetworkdStateFile)
+}
+
+func (p *networkdProvider) writeConfig(pfPciAddress string) (bool, error) {
+	return writeNetworkdConfig(pfPciAddress)
+}
+
+func (p *networkdProvider) removeConfig(pfPciAddress string) (bool, error) {
+	return removeNetworkdConfig(pfPciAddress)
+}
+
+func (p *networkdProvider) reload() error {
+	_, stderr, err := p.utilsHelper.RunCommand("networkctl", "reload")
+	if err != nil {
+		log.Log.Error(err, "networkdProvider.reload(): failed to reload systemd-networkd config", "stderr", stderr)
+		return err
+	}
+	return nil
+}
+
+func getNetworkdConfigPath(pfPciAddress, ext string) string {
+	return filepath.Join(consts.SystemdNetworkConfDir, networkdFilePrefix+pfPciAddress+ext)
+}
+
+func writeNetworkdConfig(pfPciAddress string) (bool, error) {
+	match := fmt.Sprintf("[Match]\nProperty=%s=%s\n\n", consts.UdevPropertySriovPF, pfPciAddress)
+	networkChanged, err := writeFile(getNetworkdConfigPath(pfPciAddress, ".network"),
+		match+"[Link]\nUnmanaged=yes\n", 0644)
+	if err != nil {
+		return false, err
+	}
+	linkChanged, err := writeFile(getNetworkdConfigPath(pfPciAddress, ".link"),
+		match+"[Link]\nNamePolicy=keep kernel\n", 0644)
+	if err != nil {
+		return false, err
+	}
+	return networkChanged || linkChanged, nil
+}
+
+func removeNetworkdConfig(pfPciAddress string) (bool, error) {
+	networkChanged, err := removeFile(getNetworkdConfigPath(pfPciAddress, ".network"))
+	if err != nil {
+		return false, err
+	}
+	linkChanged, err := removeFile(getNetworkdConfigPath(pfPciAddress, ".link"))
+	if err != nil {
+		return false, err
+	}
+	return networkChanged || linkChanged, nil
+}
+
+func pathExists(path string) bool {
+	_, err := os.Stat(filepath.Join(vars.FilesystemRoot, path))
+	return err == nil
+}
+
+// writeFile writes the content to the file if it differs, returns true if the file changed
+func writeFile(path, content string, perm os.FileMode) (bool, error) {
+	fullPath := filepath.Join(vars.FilesystemRoot, path)
+	current, err := os.ReadFile(fullPath)
+	if err == nil && bytes.Equal(current, []byte(content)) {
+		return false, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(fullPath), os.ModePerm); err != nil {
+		log.Log.Error(err, "writeFile(): failed to create dir", "path", filepath.Dir(fullPath))
+		return false, err
+	}
+	if err := os.WriteFile(fullPath, []byte(content), perm); err != nil {
+		log.Log.Error(err, "writeFile(): failed to write file", "path", fullPath)
+		return false, err
+	}
+	return true, nil
+}
+
+// removeFile removes the file if it exists, returns true if the file was removed
+func removeFile(path string) (bool, error) {
+	err := os.Remove(filepath.Join(vars.FilesystemRoot, path))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		log.Log.Error(err, "removeFile(): failed to remove file", "path", path)
+		return false, err
+	}
+	return true, nil
+}
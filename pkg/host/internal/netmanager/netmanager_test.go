@@ -0,0 +1,127 @@
+package netmanager
+
+import (
+	"os"
+	"path/filepath"
+
+	"go.uber.org/mock/gomock"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/types"
+	utilsMockPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils/mock"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/test/util/fakefilesystem"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/test/util/helpers"
+)
+
+const (
+	testExpectedNetworkdNetwork = "[Match]\nProperty=SRIOV_NETWORK_OPERATOR_PF=0000:d8:00.0\n\n" +
+		"[Link]\nUnmanaged=yes\n"
+	testExpectedNetworkdLink = "[Match]\nProperty=SRIOV_NETWORK_OPERATOR_PF=0000:d8:00.0\n\n" +
+		"[Link]\nNamePolicy=keep kernel\n"
+
+	testNetworkdNetworkPath = "/etc/systemd/network/05-sriov-network-operator-0000:d8:00.0.network"
+	testNetworkdLinkPath    = "/etc/systemd/network/05-sriov-network-operator-0000:d8:00.0.link"
+)
+
+func assertFileNotExist(path string) {
+	_, err := os.Stat(filepath.Join(vars.FilesystemRoot, path))
+	ExpectWithOffset(1, os.IsNotExist(err)).To(BeTrue())
+}
+
+var _ = Describe("NetManager", func() {
+	var (
+		n         types.HostNetworkManagerInterface
+		testCtrl  *gomock.Controller
+		utilsMock *utilsMockPkg.MockCmdInterface
+	)
+
+	BeforeEach(func() {
+		testCtrl = gomock.NewController(GinkgoT())
+		utilsMock = utilsMockPkg.NewMockCmdInterface(testCtrl)
+		n = New(utilsMock)
+	})
+
+	AfterEach(func() {
+		testCtrl.Finish()
+	})
+
+	Context("AddUnmanagedVfsConfig", func() {
+		It("NetworkManager and netplan", func() {
+			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+				Dirs: []string{"/run/NetworkManager", "/etc/NetworkManager/conf.d", "/etc/netplan"},
+			})
+			Expect(n.AddUnmanagedVfsConfig("0000:d8:00.0")).To(Succeed())
+			assertFileNotExist(testNetworkdNetworkPath)
+			assertFileNotExist(testNetworkdLinkPath)
+			// NetworkManager and netplan rely on the udev properties set by the udev rule of the PF
+			for _, dir := range []string{"/etc/NetworkManager/conf.d", "/etc/netplan"} {
+				entries, err := os.ReadDir(filepath.Join(vars.FilesystemRoot, dir))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(entries).To(BeEmpty())
+			}
+		})
+		It("systemd-networkd", func() {
+			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+				Dirs:  []string{"/run/systemd/netif"},
+				Files: map[string][]byte{"/run/systemd/netif/state": []byte("")},
+			})
+			utilsMock.EXPECT().RunCommand("networkctl", "reload").Return("", "", nil)
+			Expect(n.AddUnmanagedVfsConfig("0000:d8:00.0")).To(Succeed())
+			helpers.GinkgoAssertFileContentsEquals(testNetworkdNetworkPath, testExpectedNetworkdNetwork)
+			helpers.GinkgoAssertFileContentsEquals(testNetworkdLinkPath, testExpectedNetworkdLink)
+		})
+		It("systemd-networkd not changed", func() {
+			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+				Dirs: []string{"/run/systemd/netif", "/etc/systemd/network"},
+				Files: map[string][]byte{
+					"/run/systemd/netif/state": []byte(""),
+					testNetworkdNetworkPath:    []byte(testExpectedNetworkdNetwork),
+					testNetworkdLinkPath:       []byte(testExpectedNetworkdLink),
+				},
+			})
+			Expect(n.AddUnmanagedVfsConfig("0000:d8:00.0")).To(Succeed())
+		})
+		It("Reload failed", func() {
+			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+				Dirs:  []string{"/run/systemd/netif"},
+				Files: map[string][]byte{"/run/systemd/netif/state": []byte("")},
+			})
+			utilsMock.EXPECT().RunCommand("networkctl", "reload").Return("", "error", os.ErrInvalid)
+			Expect(n.AddUnmanagedVfsConfig("0000:d8:00.0")).To(MatchError(os.ErrInvalid))
+		})
+	})
+	Context("RemoveUnmanagedVfsConfig", func() {
+		It("Exist", func() {
+			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+				Dirs: []string{"/run/systemd/netif", "/etc/systemd/network"},
+				Files: map[string][]byte{
+					"/run/systemd/netif/state": []byte(""),
+					testNetworkdNetworkPath:    []byte(testExpectedNetworkdNetwork),
+					testNetworkdLinkPath:       []byte(testExpectedNetworkdLink),
+				},
+			})
+			utilsMock.EXPECT().RunCommand("networkctl", "reload").Return("", "", nil)
+			Expect(n.RemoveUnmanagedVfsConfig("0000:d8:00.0")).To(Succeed())
+			assertFileNotExist(testNetworkdNetworkPath)
+			assertFileNotExist(testNetworkdLinkPath)
+		})
+		It("Not used", func() {
+			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+				Dirs: []string{"/etc/systemd/network"},
+				Files: map[string][]byte{
+					testNetworkdNetworkPath: []byte(testExpectedNetworkdNetwork),
+					testNetworkdLinkPath:    []byte(testExpectedNetworkdLink),
+				},
+			})
+			Expect(n.RemoveUnmanagedVfsConfig("0000:d8:00.0")).To(Succeed())
+			assertFileNotExist(testNetworkdNetworkPath)
+		})
+		It("Not found", func() {
+			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{Dirs: []string{"/run/NetworkManager"}})
+			Expect(n.RemoveUnmanagedVfsConfig("0000:d8:00.0")).To(Succeed())
+		})
+	})
+})
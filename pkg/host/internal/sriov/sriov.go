@@ -44,6 +44,7 @@ type sriov struct {
 	sriovnetLib      sriovnetPkg.SriovnetLib
 	ghwLib           ghwPkg.GHWLib
 	bridgeHelper     types.BridgeInterface
+	netManagerHelper types.HostNetworkManagerInterface
 }
 
 func New(utilsHelper utils.CmdInterface,
@@ -56,7 +57,8 @@ func New(utilsHelper utils.CmdInterface,
 	dputilsLib dputilsPkg.DPUtilsLib,
 	sriovnetLib sriovnetPkg.SriovnetLib,
 	ghwLib ghwPkg.GHWLib,
-	bridgeHelper types.BridgeInterface) types.SriovInterface {
+	bridgeHelper types.BridgeInterface,
+	netManagerHelper types.HostNetworkManagerInterface) types.SriovInterface {
 	return &sriov{utilsHelper: utilsHelper,
 		kernelHelper:     kernelHelper,
 		networkHelper:    networkHelper,
@@ -68,6 +70,7 @@ func New(utilsHelper utils.CmdInterface,
 		sriovnetLib:      sriovnetLib,
 		ghwLib:           ghwLib,
 		bridgeHelper:     bridgeHelper,
+		netManagerHelper: netManagerHelper,
 	}
 }
 
@@ -91,6 +94,34 @@ func (s *sriov) SetSriovNumVfs(pciAddr string, numVfs int) error {
 	return nil
 }
 
+// IsSriovNumVfsResizeSupported checks the PF driver against the list of drivers configured with the
+// --live-vf-resize-drivers flag of the config daemon
+func (s *sriov) IsSriovNumVfsResizeSupported(pciAddr string) bool {
+	if len(vars.LiveVfResizeDrivers) == 0 {
+		return false
+	}
+	pfDriverName, err := s.dputilsLib.GetDriverName(pciAddr)
+	if err != nil {
+		log.Log.Error(err, "IsSriovNumVfsResizeSupported(): failed to get driver name for device", "device", pciAddr)
+		return false
+	}
+	return sriovnetworkv1.StringInArray(pfDriverName, vars.LiveVfResizeDrivers)
+}
+
+// resizeSriovNumVfs writes the new number of VFs without resetting sriov_numvfs to 0 first,
+// the VFs that are already allocated are kept by the driver
+func (s *sriov) resizeSriovNumVfs(pciAddr string, numVfs int) error {
+	log.Log.V(2).Info("resizeSriovNumVfs(): resize NumVfs", "device", pciAddr, "numVfs", numVfs)
+	numVfsFilePath := filepath.Join(vars.FilesystemRoot, consts.SysBusPciDevices, pciAddr, consts.NumVfsFile)
+	err := os.WriteFile(numVfsFilePath, []byte(strconv.Itoa(numVfs)), os.ModeAppend)
+	if err != nil {
+		// don't fallback to the reset of the VFs, the node may not be drained for the resize
+		log.Log.Error(err, "resizeSriovNumVfs(): fail to resize NumVfs", "path", numVfsFilePath)
+		return fmt.Errorf("failed to change the number of VFs for device %s without reset: %v", pciAddr, err)
+	}
+	return nil
+}
+
 func (s *sriov) ResetSriovDevice(ifaceStatus sriovnetworkv1.InterfaceExt) error {
 	log.Log.V(2).Info("ResetSriovDevice(): reset SRIOV device", "address", ifaceStatus.PciAddress)
 	if ifaceStatus.LinkType == consts.LinkTypeETH {
@@ -192,6 +223,33 @@ func (s *sriov) VFIsReady(pciAddr string) (netlink.Link, error) {
 	return vfLink, nil
 }
 
+// setVfName renames the VF netdev using the VF name template of the PF,
+// the udev rule keeps the name when the VF is recreated, the VF is renamed here
+// because the kernel doesn't allow to rename the netdevs which are up.
+func (s *sriov) setVfName(iface *sriovnetworkv1.Interface, vfID int, vfLink netlink.Link) error {
+	if iface.VfNameTemplate == "" || sriovnetworkv1.GetEswitchModeFromSpec(iface) != sriovnetworkv1.ESwithModeLegacy {
+		return nil
+	}
+	name := sriovnetworkv1.RenderVfName(iface.VfNameTemplate, iface.Name, vfID)
+	if vfLink.Attrs().Name == name {
+		return nil
+	}
+	log.Log.V(2).Info("setVfName(): rename VF", "current", vfLink.Attrs().Name, "desired", name)
+	isUp := s.netlinkLib.IsLinkAdminStateUp(vfLink)
+	if isUp {
+		if err := s.netlinkLib.LinkSetDown(vfLink); err != nil {
+			return err
+		}
+	}
+	if err := s.netlinkLib.LinkSetName(vfLink, name); err != nil {
+		return err
+	}
+	if isUp {
+		return s.netlinkLib.LinkSetUp(vfLink)
+	}
+	return nil
+}
+
 func (s *sriov) SetVfAdminMac(vfAddr string, pfLink, vfLink netlink.Link) error {
 	log.Log.Info("SetVfAdminMac()", "vf", vfAddr)
 
@@ -311,48 +369,56 @@ func (s *sriov) DiscoverSriovDevices(storeManager store.ManagerInterface) ([]sri
 	return pfList, nil
 }
 
-func (s *sriov) configSriovPFDevice(iface *sriovnetworkv1.Interface) error {
+// configSriovPFDevice configures the PF and creates the VFs,
+// returns the number of VFs that were kept when the number of VFs was changed without reset
+func (s *sriov) configSriovPFDevice(iface *sriovnetworkv1.Interface) (int, error) {
 	log.Log.V(2).Info("configSriovPFDevice(): configure PF sriov device",
 		"device", iface.PciAddress)
 	totalVfs := s.dputilsLib.GetSriovVFcapacity(iface.PciAddress)
 	if iface.NumVfs > totalVfs {
 		err := fmt.Errorf("cannot config SRIOV device: NumVfs (%d) is larger than TotalVfs (%d)", iface.NumVfs, totalVfs)
 		log.Log.Error(err, "configSriovPFDevice(): fail to set NumVfs for device", "device", iface.PciAddress)
-		return err
+		return 0, err
 	}
 	if err := s.configureHWOptionsForSwitchdev(iface); err != nil {
-		return err
+		return 0, err
 	}
 	// remove all UDEV rules for the PF before adding new rules to
 	// make sure that rules are always in a consistent state, e.g. there is no
 	// switchdev-related rules for PF in legacy mode
 	if err := s.removeUdevRules(iface.PciAddress); err != nil {
 		log.Log.Error(err, "configSriovPFDevice(): fail to remove udev rules", "device", iface.PciAddress)
-		return err
+		return 0, err
 	}
 	err := s.addUdevRules(iface)
 	if err != nil {
 		log.Log.Error(err, "configSriovPFDevice(): fail to add udev rules", "device", iface.PciAddress)
-		return err
+		return 0, err
+	}
+	// the configuration of the host network managers matches the VFs by the udev property set by the udev rules,
+	// it's written before the VFs are created so it applies to their add events
+	if err := s.netManagerHelper.AddUnmanagedVfsConfig(iface.PciAddress); err != nil {
+		log.Log.Error(err, "configSriovPFDevice(): fail to add host network manager config", "device", iface.PciAddress)
+		return 0, err
 	}
-	err = s.createVFs(iface)
+	keptVfs, err := s.createVFs(iface)
 	if err != nil {
 		log.Log.Error(err, "configSriovPFDevice(): fail to set NumVfs for device", "device", iface.PciAddress)
-		return err
+		return 0, err
 	}
 	if err := s.addVfRepresentorUdevRule(iface); err != nil {
 		log.Log.Error(err, "configSriovPFDevice(): fail to add VR representor udev rule", "device", iface.PciAddress)
-		return err
+		return 0, err
 	}
 	// set PF mtu
 	if iface.Mtu > 0 && iface.Mtu > s.networkHelper.GetNetdevMTU(iface.PciAddress) {
 		err = s.networkHelper.SetNetdevMTU(iface.PciAddress, iface.Mtu)
 		if err != nil {
 			log.Log.Error(err, "configSriovPFDevice(): fail to set mtu for PF", "device", iface.PciAddress)
-			return err
+			return 0, err
 		}
 	}
-	return nil
+	return keptVfs, nil
 }
 
 func (s *sriov) configureHWOptionsForSwitchdev(iface *sriovnetworkv1.Interface) error {
@@ -432,9 +498,10 @@ func (s *sriov) checkExternallyManagedPF(iface *sriovnetworkv1.Interface) error
 	return nil
 }
 
-func (s *sriov) configSriovVFDevices(iface *sriovnetworkv1.Interface) error {
+// configSriovVFDevices configures the VFs of the PF with an index greater or equal to firstVfID
+func (s *sriov) configSriovVFDevices(iface *sriovnetworkv1.Interface, firstVfID int) error {
 	log.Log.V(2).Info("configSriovVFDevices(): configure PF sriov device",
-		"device", iface.PciAddress)
+		"device", iface.PciAddress, "firstVfID", firstVfID)
 	if iface.NumVfs > 0 {
 		vfAddrs, err := s.dputilsLib.GetVFList(iface.PciAddress)
 		if err != nil {
@@ -447,6 +514,16 @@ func (s *sriov) configSriovVFDevices(iface *sriovnetworkv1.Interface) error {
 		}
 
 		for _, addr := range vfAddrs {
+			vfID, err := s.dputilsLib.GetVFID(addr)
+			if err != nil {
+				log.Log.Error(err, "configSriovVFDevices(): unable to get VF id", "device", iface.PciAddress)
+				return err
+			}
+			if vfID < firstVfID {
+				// the VF was kept when the number of VFs changed and it may be used by a pod
+				continue
+			}
+
 			hasDriver, _ := s.kernelHelper.HasDriver(addr)
 			if !hasDriver {
 				if err := s.kernelHelper.BindDefaultDriver(addr); err != nil {
@@ -456,12 +533,6 @@ func (s *sriov) configSriovVFDevices(iface *sriovnetworkv1.Interface) error {
 			}
 			var group *sriovnetworkv1.VfGroup
 
-			vfID, err := s.dputilsLib.GetVFID(addr)
-			if err != nil {
-				log.Log.Error(err, "configSriovVFDevices(): unable to get VF id", "device", iface.PciAddress)
-				return err
-			}
-
 			for i := range iface.VfGroups {
 				if sriovnetworkv1.IndexInRange(vfID, iface.VfGroups[i].VfRange) {
 					group = &iface.VfGroups[i]
@@ -512,6 +583,10 @@ func (s *sriov) configSriovVFDevices(iface *sriovnetworkv1.Interface) error {
 						log.Log.Error(err, "configSriovVFDevices(): fail to configure VF admin mac", "device", addr)
 						return err
 					}
+					if err = s.setVfName(iface, vfID, vfLink); err != nil {
+						log.Log.Error(err, "configSriovVFDevices(): fail to configure VF name", "device", addr)
+						return err
+					}
 				}
 			}
 
@@ -559,13 +634,20 @@ func (s *sriov) configSriovVFDevices(iface *sriovnetworkv1.Interface) error {
 	return nil
 }
 
-func (s *sriov) configSriovDevice(iface *sriovnetworkv1.Interface, skipVFConfiguration bool) error {
+func (s *sriov) configSriovDevice(iface *sriovnetworkv1.Interface, ifaceStatus *sriovnetworkv1.InterfaceExt, skipVFConfiguration bool) error {
 	log.Log.V(2).Info("configSriovDevice(): configure sriov device",
 		"device", iface.PciAddress, "config", iface, "skipVFConfiguration", skipVFConfiguration)
+	firstVfID := 0
 	if !iface.ExternallyManaged {
-		if err := s.configSriovPFDevice(iface); err != nil {
+		keptVfs, err := s.configSriovPFDevice(iface)
+		if err != nil {
 			return err
 		}
+		// the node is not drained when the number of VFs changes without reset and the existing VFs
+		// don't need any change, only the new VFs are configured in this case
+		if keptVfs > 0 && !sriovnetworkv1.NeedToUpdateExistingVFs(iface, ifaceStatus) {
+			firstVfID = keptVfs
+		}
 	}
 	if skipVFConfiguration {
 		if iface.ExternallyManaged {
@@ -584,7 +666,7 @@ func (s *sriov) configSriovDevice(iface *sriovnetworkv1.Interface, skipVFConfigu
 			return err
 		}
 	}
-	if err := s.configSriovVFDevices(iface); err != nil {
+	if err := s.configSriovVFDevices(iface, firstVfID); err != nil {
 		return err
 	}
 	// Set PF link up
@@ -618,9 +700,11 @@ func (s *sriov) ConfigSriovInterfaces(storeManager store.ManagerInterface,
 		log.Log.Error(err, "cannot configure sriov interfaces")
 		return fmt.Errorf("cannot configure sriov interfaces")
 	}
-	if sriovnetworkv1.ContainsSwitchdevInterface(interfaces) && len(toBeConfigured) > 0 {
+	if (sriovnetworkv1.ContainsSwitchdevInterface(interfaces) || sriovnetworkv1.ContainsVfNameTemplate(interfaces)) &&
+		len(toBeConfigured) > 0 {
 		// for switchdev devices we create udev rule that renames VF representors
-		// after VFs are created. Reload rules to update interfaces
+		// after VFs are created, for legacy devices the VFs can be renamed by a udev rule.
+		// Reload rules to update interfaces
 		if err := s.udevHelper.LoadUdevRules(); err != nil {
 			log.Log.Error(err, "cannot reload udev rules")
 			return fmt.Errorf("failed to reload udev rules: %v", err)
@@ -679,7 +763,7 @@ func (s *sriov) configSriovInterfacesInParallel(storeManager store.ManagerInterf
 		interfacesToConfigure += 1
 		go func(iface *interfaceToConfigure) {
 			var err error
-			if err = s.configSriovDevice(&iface.iface, skipVFConfiguration); err != nil {
+			if err = s.configSriovDevice(&iface.iface, &iface.ifaceStatus, skipVFConfiguration); err != nil {
 				log.Log.Error(err, "configSriovInterfacesInParallel(): fail to configure sriov interface. resetting interface.", "address", iface.iface.PciAddress)
 				if iface.iface.ExternallyManaged {
 					log.Log.V(2).Info("configSriovInterfacesInParallel(): skipping device reset as the nic is marked as externally created")
@@ -743,7 +827,7 @@ func (s *sriov) resetSriovInterfacesInParallel(storeManager store.ManagerInterfa
 func (s *sriov) configSriovInterfaces(storeManager store.ManagerInterface, interfaces []interfaceToConfigure, skipVFConfiguration bool) error {
 	log.Log.V(2).Info("configSriovInterfaces(): start sriov configuration")
 	for _, iface := range interfaces {
-		if err := s.configSriovDevice(&iface.iface, skipVFConfiguration); err != nil {
+		if err := s.configSriovDevice(&iface.iface, &iface.ifaceStatus, skipVFConfiguration); err != nil {
 			log.Log.Error(err, "configSriovInterfaces(): fail to configure sriov interface. resetting interface.", "address", iface.iface.PciAddress)
 			if iface.iface.ExternallyManaged {
 				log.Log.V(2).Info("configSriovInterfaces(): skipping device reset as the nic is marked as externally created")
@@ -827,6 +911,10 @@ func (s *sriov) checkForConfigAndReset(ifaceStatus sriovnetworkv1.InterfaceExt,
 	if err != nil {
 		return err
 	}
+	err = s.netManagerHelper.RemoveUnmanagedVfsConfig(ifaceStatus.PciAddress)
+	if err != nil {
+		return err
+	}
 
 	if ifaceStatus.NumVfs > 0 {
 		if err = s.ResetSriovDevice(ifaceStatus); err != nil {
@@ -941,6 +1029,7 @@ func (s *sriov) encapTypeToLinkType(encapType string) string {
 // create required udev rules for PF:
 // * rule to disable NetworkManager for VFs - for all modes
 // * rule to keep PF name after switching to switchdev mode - only for switchdev mode
+// * rule to rename VFs using the name template - only for legacy mode
 func (s *sriov) addUdevRules(iface *sriovnetworkv1.Interface) error {
 	log.Log.V(2).Info("addUdevRules(): add udev rules for device",
 		"device", iface.PciAddress)
@@ -951,6 +1040,10 @@ func (s *sriov) addUdevRules(iface *sriovnetworkv1.Interface) error {
 		if err := s.udevHelper.AddPersistPFNameUdevRule(iface.PciAddress, iface.Name); err != nil {
 			return err
 		}
+	} else if iface.VfNameTemplate != "" {
+		if err := s.udevHelper.AddVfNameUdevRule(iface.PciAddress, iface.Name, iface.VfNameTemplate); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -986,23 +1079,34 @@ func (s *sriov) removeUdevRules(pciAddress string) error {
 	if err := s.udevHelper.RemoveVfRepresentorUdevRule(pciAddress); err != nil {
 		return err
 	}
+	if err := s.udevHelper.RemoveVfNameUdevRule(pciAddress); err != nil {
+		return err
+	}
 	return s.udevHelper.RemovePersistPFNameUdevRule(pciAddress)
 }
 
-// create VFs on the PF
-func (s *sriov) createVFs(iface *sriovnetworkv1.Interface) error {
+// create VFs on the PF, returns the number of VFs that were kept when the number of VFs was changed without reset
+func (s *sriov) createVFs(iface *sriovnetworkv1.Interface) (int, error) {
 	expectedEswitchMode := sriovnetworkv1.GetEswitchModeFromSpec(iface)
 	log.Log.V(2).Info("createVFs(): configure VFs for device",
 		"device", iface.PciAddress, "count", iface.NumVfs, "mode", expectedEswitchMode)
 
-	if s.dputilsLib.GetVFconfigured(iface.PciAddress) == iface.NumVfs {
+	currentNumVfs := s.dputilsLib.GetVFconfigured(iface.PciAddress)
+	if currentNumVfs == iface.NumVfs {
 		if s.GetNicSriovMode(iface.PciAddress) == expectedEswitchMode {
 			log.Log.V(2).Info("createVFs(): device is already configured",
 				"device", iface.PciAddress, "count", iface.NumVfs, "mode", expectedEswitchMode)
-			return nil
+			return 0, nil
+		}
+	} else if currentNumVfs > 0 && iface.NumVfs > 0 && expectedEswitchMode == sriovnetworkv1.ESwithModeLegacy &&
+		s.IsSriovNumVfsResizeSupported(iface.PciAddress) &&
+		s.GetNicSriovMode(iface.PciAddress) == sriovnetworkv1.ESwithModeLegacy {
+		if err := s.resizeSriovNumVfs(iface.PciAddress, iface.NumVfs); err != nil {
+			return 0, err
 		}
+		return min(currentNumVfs, iface.NumVfs), nil
 	}
-	return s.setEswitchModeAndNumVFs(iface.PciAddress, expectedEswitchMode, iface.NumVfs)
+	return 0, s.setEswitchModeAndNumVFs(iface.PciAddress, expectedEswitchMode, iface.NumVfs)
 }
 
 type setEswitchModeAndNumVFsFn func(string, string, int) error
@@ -10,6 +10,7 @@ import (
 
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/types"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils"
@@ -53,18 +54,18 @@ func (u *udev) PrepareNMUdevRule(supportedVfIds []string) error {
 // PrepareVFRepUdevRule creates a script which helps to configure representor name for the VF
 func (u *udev) PrepareVFRepUdevRule() error {
 	log.Log.V(2).Info("PrepareVFRepUdevRule()")
-	targetPath := filepath.Join(vars.FilesystemRoot, consts.HostUdevFolder, filepath.Base(consts.UdevRepName))
-	data, err := os.ReadFile(filepath.Join(vars.FilesystemRoot, consts.UdevRepName))
-	if err != nil {
-		log.Log.Error(err, "PrepareVFRepUdevRule(): failed to read source for representor name UDEV script")
-		return err
-	}
-	if err := os.WriteFile(targetPath, data, 0755); err != nil {
-		log.Log.Error(err, "PrepareVFRepUdevRule(): failed to write representor name UDEV script")
+	if err := u.installUdevScript(consts.UdevRepName); err != nil {
+		log.Log.Error(err, "PrepareVFRepUdevRule(): failed to install representor name UDEV script")
 		return err
 	}
-	if err := os.Chmod(targetPath, 0755); err != nil {
-		log.Log.Error(err, "PrepareVFRepUdevRule(): failed to set permissions on representor name UDEV script")
+	return nil
+}
+
+// PrepareVFNameUdevRule creates a script which helps to configure the name of the VF netdev in legacy mode
+func (u *udev) PrepareVFNameUdevRule() error {
+	log.Log.V(2).Info("PrepareVFNameUdevRule()")
+	if err := u.installUdevScript(consts.UdevVfName); err != nil {
+		log.Log.Error(err, "PrepareVFNameUdevRule(): failed to install VF name UDEV script")
 		return err
 	}
 	return nil
@@ -110,6 +111,22 @@ func (u *udev) RemoveVfRepresentorUdevRule(pfPciAddress string) error {
 	return u.removeUdevRule(pfPciAddress, "20-switchdev")
 }
 
+// AddVfNameUdevRule adds udev rule that renames the VF netdevs of the concrete PF using the name template
+func (u *udev) AddVfNameUdevRule(pfPciAddress, pfName, template string) error {
+	log.Log.V(2).Info("AddVfNameUdevRule()", "device", pfPciAddress, "name", pfName, "template", template)
+	vfName := strings.NewReplacer(
+		sriovnetworkv1.VfNameTemplatePfName, pfName,
+		sriovnetworkv1.VfNameTemplateVfID, consts.UdevVfIDEnv).Replace(template)
+	udevRuleContent := fmt.Sprintf(consts.VfNameUdevRule, pfPciAddress, vfName)
+	return u.addUdevRule(pfPciAddress, "10-vf-name", udevRuleContent)
+}
+
+// RemoveVfNameUdevRule removes udev rule that renames the VF netdevs of the concrete PF
+func (u *udev) RemoveVfNameUdevRule(pfPciAddress string) error {
+	log.Log.V(2).Info("RemoveVfNameUdevRule()", "device", pfPciAddress)
+	return u.removeUdevRule(pfPciAddress, "10-vf-name")
+}
+
 // LoadUdevRules triggers udev rules for network subsystem
 func (u *udev) LoadUdevRules() error {
 	log.Log.V(2).Info("LoadUdevRules()")
@@ -139,6 +156,25 @@ func (u *udev) WaitUdevEventsProcessed(timeout int) error {
 	return nil
 }
 
+// installUdevScript copies the script from the bindata to the udev folder of the host
+func (u *udev) installUdevScript(scriptPath string) error {
+	targetPath := filepath.Join(vars.FilesystemRoot, consts.HostUdevFolder, filepath.Base(scriptPath))
+	data, err := os.ReadFile(filepath.Join(vars.FilesystemRoot, scriptPath))
+	if err != nil {
+		log.Log.Error(err, "installUdevScript(): failed to read source for UDEV script", "path", scriptPath)
+		return err
+	}
+	if err := os.WriteFile(targetPath, data, 0755); err != nil {
+		log.Log.Error(err, "installUdevScript(): failed to write UDEV script", "path", targetPath)
+		return err
+	}
+	if err := os.Chmod(targetPath, 0755); err != nil {
+		log.Log.Error(err, "installUdevScript(): failed to set permissions on UDEV script", "path", targetPath)
+		return err
+	}
+	return nil
+}
+
 func (u *udev) addUdevRule(pfPciAddress, ruleName, ruleContent string) error {
 	log.Log.V(2).Info("addUdevRule()", "device", pfPciAddress, "rule", ruleName)
 	rulePath := u.getRuleFolderPath()
@@ -3,7 +3,9 @@ package udev
 import (
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 
 	"go.uber.org/mock/gomock"
 
@@ -26,8 +28,38 @@ const (
 		`ATTRS{phys_switch_id}=="7cfe90ff2cc0", ` +
 		`ATTR{phys_port_name}=="pf0vf*", IMPORT{program}="/etc/udev/switchdev-vf-link-name.sh $attr{phys_port_name}", ` +
 		`NAME="enp216s0f0np0_$env{NUMBER}"`
+	testExpectedVfNameUdevRule = `SUBSYSTEM=="net", ACTION=="add", DRIVERS=="?*", ` +
+		`IMPORT{program}="/etc/udev/vf-link-name.sh $kernel", ` +
+		`ENV{SRIOV_VF_PF}=="0000:d8:00.0", ENV{SRIOV_VF_ID}=="?*", ` +
+		`NAME="enp216s0f0np0v$env{SRIOV_VF_ID}"`
 )
 
+// runDisableNMScript runs the disable-nm-sriov.sh script generated by udev-find-sriov-pf.sh for the netdev
+// against a fake sysfs, the netdev is a VF of the PF at physfn or a PF if physfn is empty
+func runDisableNMScript(netdev, physfn, pfPciAddress string) string {
+	generator, err := os.ReadFile("../../../../bindata/scripts/udev-find-sriov-pf.sh")
+	ExpectWithOffset(1, err).NotTo(HaveOccurred())
+	_, script, found := strings.Cut(string(generator), "<<'EOF' > /host/etc/udev/disable-nm-sriov.sh\n")
+	ExpectWithOffset(1, found).To(BeTrue())
+	script, _, found = strings.Cut(script, "\nEOF\n")
+	ExpectWithOffset(1, found).To(BeTrue())
+
+	sysfs := GinkgoT().TempDir()
+	script = strings.ReplaceAll(script, "/sys/class/net", filepath.Join(sysfs, "class/net"))
+	deviceDir := filepath.Join(sysfs, "class/net", netdev, "device")
+	ExpectWithOffset(1, os.MkdirAll(deviceDir, 0755)).To(Succeed())
+	if physfn != "" {
+		ExpectWithOffset(1, os.MkdirAll(filepath.Join(deviceDir, "..", physfn), 0755)).To(Succeed())
+		ExpectWithOffset(1, os.Symlink("../"+physfn, filepath.Join(deviceDir, "physfn"))).To(Succeed())
+	}
+	scriptPath := filepath.Join(sysfs, "disable-nm-sriov.sh")
+	ExpectWithOffset(1, os.WriteFile(scriptPath, []byte(script+"\n"), 0755)).To(Succeed())
+
+	out, err := exec.Command("bash", scriptPath, netdev, pfPciAddress).Output()
+	ExpectWithOffset(1, err).NotTo(HaveOccurred())
+	return string(out)
+}
+
 var _ = Describe("UDEV", func() {
 	var (
 		s         types.UdevInterface
@@ -87,6 +119,18 @@ var _ = Describe("UDEV", func() {
 			Expect(s.RemoveDisableNMUdevRule("0000:d8:00.0")).To(BeNil())
 		})
 	})
+	Context("disable-nm-sriov.sh", func() {
+		It("Sets the properties of the VFs of the PF", func() {
+			Expect(runDisableNMScript("enp216s0f0v0", "0000:d8:00.0", "0000:d8:00.0")).To(Equal(
+				"NM_UNMANAGED=1\nSRIOV_NETWORK_OPERATOR_PF=0000:d8:00.0\n"))
+		})
+		It("Skips the VFs of another PF", func() {
+			Expect(runDisableNMScript("enp216s0f1v0", "0000:d8:00.1", "0000:d8:00.0")).To(BeEmpty())
+		})
+		It("Skips the PFs", func() {
+			Expect(runDisableNMScript("enp216s0f0np0", "", "0000:d8:00.0")).To(BeEmpty())
+		})
+	})
 	Context("AddPersistPFNameUdevRule", func() {
 		It("Created", func() {
 			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{})
@@ -172,6 +216,53 @@ var _ = Describe("UDEV", func() {
 			Expect(s.RemoveVfRepresentorUdevRule("0000:d8:00.0")).To(BeNil())
 		})
 	})
+	Context("AddVfNameUdevRule", func() {
+		It("Created", func() {
+			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{})
+			Expect(s.AddVfNameUdevRule("0000:d8:00.0", "enp216s0f0np0", "{pfName}v{vfID}")).To(BeNil())
+			helpers.GinkgoAssertFileContentsEquals(
+				"/etc/udev/rules.d/10-vf-name-0000:d8:00.0.rules",
+				testExpectedVfNameUdevRule)
+		})
+	})
+	Context("RemoveVfNameUdevRule", func() {
+		It("Exist", func() {
+			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+				Dirs: []string{"/etc/udev/rules.d"},
+				Files: map[string][]byte{
+					"/etc/udev/rules.d/10-vf-name-0000:d8:00.0.rules": []byte(testExpectedVfNameUdevRule),
+				},
+			})
+			Expect(s.RemoveVfNameUdevRule("0000:d8:00.0")).To(BeNil())
+			_, err := os.Stat(filepath.Join(vars.FilesystemRoot,
+				"/etc/udev/rules.d/10-vf-name-0000:d8:00.0.rules"))
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+		It("Not found", func() {
+			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+				Dirs: []string{"/etc/udev/rules.d"},
+			})
+			Expect(s.RemoveVfNameUdevRule("0000:d8:00.0")).To(BeNil())
+		})
+	})
+	Context("PrepareVFNameUdevRule", func() {
+		It("Created", func() {
+			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+				Dirs: []string{"/host/etc/udev", "/bindata/scripts"},
+				Files: map[string][]byte{
+					"/bindata/scripts/vf-link-name.sh": []byte("script"),
+				},
+			})
+			Expect(s.PrepareVFNameUdevRule()).To(BeNil())
+			helpers.GinkgoAssertFileContentsEquals("/host/etc/udev/vf-link-name.sh", "script")
+		})
+		It("Fail - no script", func() {
+			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+				Dirs: []string{"/host/etc/udev"},
+			})
+			Expect(s.PrepareVFNameUdevRule()).NotTo(BeNil())
+		})
+	})
 	Context("PrepareVFRepUdevRule", func() {
 		It("Already Exist", func() {
 			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
@@ -10,6 +10,7 @@ import (
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/ghw"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/netlink"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/sriovnet"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/netmanager"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/network"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/service"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/sriov"
@@ -34,6 +35,7 @@ type HostManagerInterface interface {
 	types.BridgeInterface
 	types.CPUInfoProviderInterface
 	types.SystemdInterface
+	types.HostNetworkManagerInterface
 }
 
 type hostManager struct {
@@ -48,6 +50,7 @@ type hostManager struct {
 	types.BridgeInterface
 	types.CPUInfoProviderInterface
 	types.SystemdInterface
+	types.HostNetworkManagerInterface
 }
 
 func NewHostManager(utilsInterface utils.CmdInterface) (HostManagerInterface, error) {
@@ -65,8 +68,9 @@ func NewHostManager(utilsInterface utils.CmdInterface) (HostManagerInterface, er
 	if err != nil {
 		return nil, err
 	}
-	br := bridge.New()
-	sr := sriov.New(utilsInterface, k, n, u, v, ib, netlinkLib, dpUtils, sriovnetLib, ghwLib, br)
+	br := bridge.New(utilsInterface, netlinkLib, n, sriovnetLib)
+	nm := netmanager.New(utilsInterface)
+	sr := sriov.New(utilsInterface, k, n, u, v, ib, netlinkLib, dpUtils, sriovnetLib, ghwLib, br, nm)
 	cpuInfoProvider := cpu.New(ghwLib)
 	s := systemd.New()
 	return &hostManager{
@@ -81,5 +85,6 @@ func NewHostManager(utilsInterface utils.CmdInterface) (HostManagerInterface, er
 		br,
 		cpuInfoProvider,
 		s,
+		nm,
 	}, nil
 }
@@ -71,6 +71,34 @@ func (mr *MockHostManagerInterfaceMockRecorder) AddPersistPFNameUdevRule(pfPciAd
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPersistPFNameUdevRule", reflect.TypeOf((*MockHostManagerInterface)(nil).AddPersistPFNameUdevRule), pfPciAddress, pfName)
 }
 
+// AddUnmanagedVfsConfig mocks base method.
+func (m *MockHostManagerInterface) AddUnmanagedVfsConfig(pfPciAddress string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddUnmanagedVfsConfig", pfPciAddress)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddUnmanagedVfsConfig indicates an expected call of AddUnmanagedVfsConfig.
+func (mr *MockHostManagerInterfaceMockRecorder) AddUnmanagedVfsConfig(pfPciAddress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUnmanagedVfsConfig", reflect.TypeOf((*MockHostManagerInterface)(nil).AddUnmanagedVfsConfig), pfPciAddress)
+}
+
+// AddVfNameUdevRule mocks base method.
+func (m *MockHostManagerInterface) AddVfNameUdevRule(pfPciAddress, pfName, template string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddVfNameUdevRule", pfPciAddress, pfName, template)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddVfNameUdevRule indicates an expected call of AddVfNameUdevRule.
+func (mr *MockHostManagerInterfaceMockRecorder) AddVfNameUdevRule(pfPciAddress, pfName, template any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddVfNameUdevRule", reflect.TypeOf((*MockHostManagerInterface)(nil).AddVfNameUdevRule), pfPciAddress, pfName, template)
+}
+
 // AddVfRepresentorUdevRule mocks base method.
 func (m *MockHostManagerInterface) AddVfRepresentorUdevRule(pfPciAddress, pfName, pfSwitchID, pfSwitchPort string) error {
 	m.ctrl.T.Helper()
@@ -213,6 +241,20 @@ func (mr *MockHostManagerInterfaceMockRecorder) ConfigureBridges(bridgesSpec, br
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigureBridges", reflect.TypeOf((*MockHostManagerInterface)(nil).ConfigureBridges), bridgesSpec, bridgesStatus)
 }
 
+// ConfigureOVSHwOffload mocks base method.
+func (m *MockHostManagerInterface) ConfigureOVSHwOffload(conf *v1.OvsHardwareOffload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfigureOVSHwOffload", conf)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfigureOVSHwOffload indicates an expected call of ConfigureOVSHwOffload.
+func (mr *MockHostManagerInterfaceMockRecorder) ConfigureOVSHwOffload(conf any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigureOVSHwOffload", reflect.TypeOf((*MockHostManagerInterface)(nil).ConfigureOVSHwOffload), conf)
+}
+
 // ConfigureVfGUID mocks base method.
 func (m *MockHostManagerInterface) ConfigureVfGUID(vfAddr, pfAddr string, vfID int, pfLink netlink.Link) error {
 	m.ctrl.T.Helper()
@@ -356,6 +398,21 @@ func (mr *MockHostManagerInterfaceMockRecorder) EnableService(service any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableService", reflect.TypeOf((*MockHostManagerInterface)(nil).EnableService), service)
 }
 
+// GetBootID mocks base method.
+func (m *MockHostManagerInterface) GetBootID() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBootID")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBootID indicates an expected call of GetBootID.
+func (mr *MockHostManagerInterfaceMockRecorder) GetBootID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBootID", reflect.TypeOf((*MockHostManagerInterface)(nil).GetBootID))
+}
+
 // GetCPUVendor mocks base method.
 func (m *MockHostManagerInterface) GetCPUVendor() (types.CPUVendor, error) {
 	m.ctrl.T.Helper()
@@ -386,6 +443,21 @@ func (mr *MockHostManagerInterfaceMockRecorder) GetCurrentKernelArgs() *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentKernelArgs", reflect.TypeOf((*MockHostManagerInterface)(nil).GetCurrentKernelArgs))
 }
 
+// GetDeviceNumaNode mocks base method.
+func (m *MockHostManagerInterface) GetDeviceNumaNode(pciAddr string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeviceNumaNode", pciAddr)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeviceNumaNode indicates an expected call of GetDeviceNumaNode.
+func (mr *MockHostManagerInterfaceMockRecorder) GetDeviceNumaNode(pciAddr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeviceNumaNode", reflect.TypeOf((*MockHostManagerInterface)(nil).GetDeviceNumaNode), pciAddr)
+}
+
 // GetDevlinkDeviceParam mocks base method.
 func (m *MockHostManagerInterface) GetDevlinkDeviceParam(pciAddr, paramName string) (string, error) {
 	m.ctrl.T.Helper()
@@ -416,6 +488,21 @@ func (mr *MockHostManagerInterfaceMockRecorder) GetDriverByBusAndDevice(bus, dev
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDriverByBusAndDevice", reflect.TypeOf((*MockHostManagerInterface)(nil).GetDriverByBusAndDevice), bus, device)
 }
 
+// GetHugepages mocks base method.
+func (m *MockHostManagerInterface) GetHugepages() ([]v1.HugepagesStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHugepages")
+	ret0, _ := ret[0].([]v1.HugepagesStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHugepages indicates an expected call of GetHugepages.
+func (mr *MockHostManagerInterfaceMockRecorder) GetHugepages() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHugepages", reflect.TypeOf((*MockHostManagerInterface)(nil).GetHugepages))
+}
+
 // GetInterfaceIndex mocks base method.
 func (m *MockHostManagerInterface) GetInterfaceIndex(pciAddr string) (int, error) {
 	m.ctrl.T.Helper()
@@ -431,6 +518,21 @@ func (mr *MockHostManagerInterfaceMockRecorder) GetInterfaceIndex(pciAddr any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterfaceIndex", reflect.TypeOf((*MockHostManagerInterface)(nil).GetInterfaceIndex), pciAddr)
 }
 
+// GetKernelRelease mocks base method.
+func (m *MockHostManagerInterface) GetKernelRelease() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKernelRelease")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKernelRelease indicates an expected call of GetKernelRelease.
+func (mr *MockHostManagerInterfaceMockRecorder) GetKernelRelease() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKernelRelease", reflect.TypeOf((*MockHostManagerInterface)(nil).GetKernelRelease))
+}
+
 // GetLinkType mocks base method.
 func (m *MockHostManagerInterface) GetLinkType(name string) string {
 	m.ctrl.T.Helper()
@@ -529,6 +631,21 @@ func (mr *MockHostManagerInterfaceMockRecorder) GetNicSriovMode(pciAddr any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNicSriovMode", reflect.TypeOf((*MockHostManagerInterface)(nil).GetNicSriovMode), pciAddr)
 }
 
+// GetOVSHwOffload mocks base method.
+func (m *MockHostManagerInterface) GetOVSHwOffload() (*v1.OvsHardwareOffload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOVSHwOffload")
+	ret0, _ := ret[0].(*v1.OvsHardwareOffload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOVSHwOffload indicates an expected call of GetOVSHwOffload.
+func (mr *MockHostManagerInterfaceMockRecorder) GetOVSHwOffload() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOVSHwOffload", reflect.TypeOf((*MockHostManagerInterface)(nil).GetOVSHwOffload))
+}
+
 // GetPciAddressFromInterfaceName mocks base method.
 func (m *MockHostManagerInterface) GetPciAddressFromInterfaceName(interfaceName string) (string, error) {
 	m.ctrl.T.Helper()
@@ -632,6 +749,21 @@ func (mr *MockHostManagerInterfaceMockRecorder) IsKernelModuleLoaded(name any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsKernelModuleLoaded", reflect.TypeOf((*MockHostManagerInterface)(nil).IsKernelModuleLoaded), name)
 }
 
+// IsKernelModuleParamsSet mocks base method.
+func (m *MockHostManagerInterface) IsKernelModuleParamsSet(name string, params []string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsKernelModuleParamsSet", name, params)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsKernelModuleParamsSet indicates an expected call of IsKernelModuleParamsSet.
+func (mr *MockHostManagerInterfaceMockRecorder) IsKernelModuleParamsSet(name, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsKernelModuleParamsSet", reflect.TypeOf((*MockHostManagerInterface)(nil).IsKernelModuleParamsSet), name, params)
+}
+
 // IsServiceEnabled mocks base method.
 func (m *MockHostManagerInterface) IsServiceEnabled(servicePath string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -662,6 +794,20 @@ func (mr *MockHostManagerInterfaceMockRecorder) IsServiceExist(servicePath any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsServiceExist", reflect.TypeOf((*MockHostManagerInterface)(nil).IsServiceExist), servicePath)
 }
 
+// IsSriovNumVfsResizeSupported mocks base method.
+func (m *MockHostManagerInterface) IsSriovNumVfsResizeSupported(pciAddr string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsSriovNumVfsResizeSupported", pciAddr)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsSriovNumVfsResizeSupported indicates an expected call of IsSriovNumVfsResizeSupported.
+func (mr *MockHostManagerInterfaceMockRecorder) IsSriovNumVfsResizeSupported(pciAddr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSriovNumVfsResizeSupported", reflect.TypeOf((*MockHostManagerInterface)(nil).IsSriovNumVfsResizeSupported), pciAddr)
+}
+
 // IsSwitchdev mocks base method.
 func (m *MockHostManagerInterface) IsSwitchdev(name string) bool {
 	m.ctrl.T.Helper()
@@ -723,6 +869,20 @@ func (mr *MockHostManagerInterfaceMockRecorder) PrepareNMUdevRule(supportedVfIds
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrepareNMUdevRule", reflect.TypeOf((*MockHostManagerInterface)(nil).PrepareNMUdevRule), supportedVfIds)
 }
 
+// PrepareVFNameUdevRule mocks base method.
+func (m *MockHostManagerInterface) PrepareVFNameUdevRule() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PrepareVFNameUdevRule")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PrepareVFNameUdevRule indicates an expected call of PrepareVFNameUdevRule.
+func (mr *MockHostManagerInterfaceMockRecorder) PrepareVFNameUdevRule() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrepareVFNameUdevRule", reflect.TypeOf((*MockHostManagerInterface)(nil).PrepareVFNameUdevRule))
+}
+
 // PrepareVFRepUdevRule mocks base method.
 func (m *MockHostManagerInterface) PrepareVFRepUdevRule() error {
 	m.ctrl.T.Helper()
@@ -883,6 +1043,34 @@ func (mr *MockHostManagerInterfaceMockRecorder) RemoveSriovResult() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSriovResult", reflect.TypeOf((*MockHostManagerInterface)(nil).RemoveSriovResult))
 }
 
+// RemoveUnmanagedVfsConfig mocks base method.
+func (m *MockHostManagerInterface) RemoveUnmanagedVfsConfig(pfPciAddress string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveUnmanagedVfsConfig", pfPciAddress)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveUnmanagedVfsConfig indicates an expected call of RemoveUnmanagedVfsConfig.
+func (mr *MockHostManagerInterfaceMockRecorder) RemoveUnmanagedVfsConfig(pfPciAddress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUnmanagedVfsConfig", reflect.TypeOf((*MockHostManagerInterface)(nil).RemoveUnmanagedVfsConfig), pfPciAddress)
+}
+
+// RemoveVfNameUdevRule mocks base method.
+func (m *MockHostManagerInterface) RemoveVfNameUdevRule(pfPciAddress string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveVfNameUdevRule", pfPciAddress)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveVfNameUdevRule indicates an expected call of RemoveVfNameUdevRule.
+func (mr *MockHostManagerInterfaceMockRecorder) RemoveVfNameUdevRule(pfPciAddress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveVfNameUdevRule", reflect.TypeOf((*MockHostManagerInterface)(nil).RemoveVfNameUdevRule), pfPciAddress)
+}
+
 // RemoveVfRepresentorUdevRule mocks base method.
 func (m *MockHostManagerInterface) RemoveVfRepresentorUdevRule(pfPciAddress string) error {
 	m.ctrl.T.Helper()
@@ -925,6 +1113,34 @@ func (mr *MockHostManagerInterfaceMockRecorder) SetDevlinkDeviceParam(pciAddr, p
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDevlinkDeviceParam", reflect.TypeOf((*MockHostManagerInterface)(nil).SetDevlinkDeviceParam), pciAddr, paramName, value)
 }
 
+// SetHugepages mocks base method.
+func (m *MockHostManagerInterface) SetHugepages(numaNode int, size string, count int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHugepages", numaNode, size, count)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHugepages indicates an expected call of SetHugepages.
+func (mr *MockHostManagerInterfaceMockRecorder) SetHugepages(numaNode, size, count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHugepages", reflect.TypeOf((*MockHostManagerInterface)(nil).SetHugepages), numaNode, size, count)
+}
+
+// SetKernelModuleParams mocks base method.
+func (m *MockHostManagerInterface) SetKernelModuleParams(name string, params []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetKernelModuleParams", name, params)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetKernelModuleParams indicates an expected call of SetKernelModuleParams.
+func (mr *MockHostManagerInterfaceMockRecorder) SetKernelModuleParams(name, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetKernelModuleParams", reflect.TypeOf((*MockHostManagerInterface)(nil).SetKernelModuleParams), name, params)
+}
+
 // SetNetdevMTU mocks base method.
 func (m *MockHostManagerInterface) SetNetdevMTU(pciAddr string, mtu int) error {
 	m.ctrl.T.Helper()
@@ -13,6 +13,7 @@ import (
 	reflect "reflect"
 
 	v1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	store "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/store"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -69,6 +70,37 @@ func (mr *MockManagerInterfaceMockRecorder) GetCheckPointNodeState() *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCheckPointNodeState", reflect.TypeOf((*MockManagerInterface)(nil).GetCheckPointNodeState))
 }
 
+// LoadKernelArgsState mocks base method.
+func (m *MockManagerInterface) LoadKernelArgsState() (*store.KernelArgsState, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadKernelArgsState")
+	ret0, _ := ret[0].(*store.KernelArgsState)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// LoadKernelArgsState indicates an expected call of LoadKernelArgsState.
+func (mr *MockManagerInterfaceMockRecorder) LoadKernelArgsState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadKernelArgsState", reflect.TypeOf((*MockManagerInterface)(nil).LoadKernelArgsState))
+}
+
+// LoadManagedKernelConfig mocks base method.
+func (m *MockManagerInterface) LoadManagedKernelConfig() (*store.ManagedKernelConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadManagedKernelConfig")
+	ret0, _ := ret[0].(*store.ManagedKernelConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadManagedKernelConfig indicates an expected call of LoadManagedKernelConfig.
+func (mr *MockManagerInterfaceMockRecorder) LoadManagedKernelConfig() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadManagedKernelConfig", reflect.TypeOf((*MockManagerInterface)(nil).LoadManagedKernelConfig))
+}
+
 // LoadPfsStatus mocks base method.
 func (m *MockManagerInterface) LoadPfsStatus(pciAddress string) (*v1.Interface, bool, error) {
 	m.ctrl.T.Helper()
@@ -85,6 +117,20 @@ func (mr *MockManagerInterfaceMockRecorder) LoadPfsStatus(pciAddress any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadPfsStatus", reflect.TypeOf((*MockManagerInterface)(nil).LoadPfsStatus), pciAddress)
 }
 
+// RemoveKernelArgsState mocks base method.
+func (m *MockManagerInterface) RemoveKernelArgsState() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveKernelArgsState")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveKernelArgsState indicates an expected call of RemoveKernelArgsState.
+func (mr *MockManagerInterfaceMockRecorder) RemoveKernelArgsState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveKernelArgsState", reflect.TypeOf((*MockManagerInterface)(nil).RemoveKernelArgsState))
+}
+
 // RemovePfAppliedStatus mocks base method.
 func (m *MockManagerInterface) RemovePfAppliedStatus(pciAddress string) error {
 	m.ctrl.T.Helper()
@@ -99,6 +145,20 @@ func (mr *MockManagerInterfaceMockRecorder) RemovePfAppliedStatus(pciAddress any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePfAppliedStatus", reflect.TypeOf((*MockManagerInterface)(nil).RemovePfAppliedStatus), pciAddress)
 }
 
+// SaveKernelArgsState mocks base method.
+func (m *MockManagerInterface) SaveKernelArgsState(state *store.KernelArgsState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveKernelArgsState", state)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveKernelArgsState indicates an expected call of SaveKernelArgsState.
+func (mr *MockManagerInterfaceMockRecorder) SaveKernelArgsState(state any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveKernelArgsState", reflect.TypeOf((*MockManagerInterface)(nil).SaveKernelArgsState), state)
+}
+
 // SaveLastPfAppliedStatus mocks base method.
 func (m *MockManagerInterface) SaveLastPfAppliedStatus(PfInfo *v1.Interface) error {
 	m.ctrl.T.Helper()
@@ -113,6 +173,20 @@ func (mr *MockManagerInterfaceMockRecorder) SaveLastPfAppliedStatus(PfInfo any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveLastPfAppliedStatus", reflect.TypeOf((*MockManagerInterface)(nil).SaveLastPfAppliedStatus), PfInfo)
 }
 
+// SaveManagedKernelConfig mocks base method.
+func (m *MockManagerInterface) SaveManagedKernelConfig(config *store.ManagedKernelConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveManagedKernelConfig", config)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveManagedKernelConfig indicates an expected call of SaveManagedKernelConfig.
+func (mr *MockManagerInterfaceMockRecorder) SaveManagedKernelConfig(config any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveManagedKernelConfig", reflect.TypeOf((*MockManagerInterface)(nil).SaveManagedKernelConfig), config)
+}
+
 // WriteCheckpointFile mocks base method.
 func (m *MockManagerInterface) WriteCheckpointFile(arg0 *v1.SriovNetworkNodeState) error {
 	m.ctrl.T.Helper()
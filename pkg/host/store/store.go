@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -25,6 +26,66 @@ type ManagerInterface interface {
 
 	GetCheckPointNodeState() (*sriovnetworkv1.SriovNetworkNodeState, error)
 	WriteCheckpointFile(*sriovnetworkv1.SriovNetworkNodeState) error
+
+	SaveKernelArgsState(state *KernelArgsState) error
+	LoadKernelArgsState() (*KernelArgsState, bool, error)
+	RemoveKernelArgsState() error
+
+	SaveManagedKernelConfig(config *ManagedKernelConfig) error
+	LoadManagedKernelConfig() (*ManagedKernelConfig, error)
+}
+
+// KernelArgsState contains the kernel arguments the config-daemon expects
+// to find on the kernel command line after the node reboot
+type KernelArgsState struct {
+	// BootID of the host when the reboot was requested
+	BootID string `json:"bootID"`
+	// Generation of the SriovNetworkNodeState that requested the reboot
+	Generation int64 `json:"generation"`
+	// KernelArgs contains the desired state of the kernel arguments,
+	// true if the argument must be set and false if it must be removed
+	KernelArgs map[string]bool `json:"kernelArgs"`
+	// KernelModules contains the desired parameters of the kernel modules
+	KernelModules map[string][]string `json:"kernelModules,omitempty"`
+	// Mismatch contains the kernel arguments and the kernel module parameters (as <module>.<param>=<value>)
+	// that were not in the desired state after the reboot
+	Mismatch []string `json:"mismatch,omitempty"`
+}
+
+// ManagedKernelConfig contains the additional kernel arguments, the kernel modules
+// and the hugepages the operator configured on the host from the SriovNetworkPoolConfig
+type ManagedKernelConfig struct {
+	KernelArgs    []string `json:"kernelArgs,omitempty"`
+	KernelModules []string `json:"kernelModules,omitempty"`
+	// Hugepages describes the hugepages allocated at runtime
+	Hugepages *ManagedHugepages `json:"hugepages,omitempty"`
+}
+
+// ManagedHugepages contains the hugepages the operator allocated at runtime on top of the hugepages
+// that were already allocated on the NUMA nodes
+type ManagedHugepages struct {
+	Size      string `json:"size"`
+	Count     int    `json:"count"`
+	NumaNodes []int  `json:"numaNodes"`
+	// PreviousCounts contains the count of hugepages of each NUMA node before the allocation,
+	// the counts are restored when the hugepages are released
+	PreviousCounts map[int]int `json:"previousCounts,omitempty"`
+	// OnBoot is true if the kernel failed to allocate the hugepages at runtime,
+	// in this case they are allocated on boot with kernel arguments
+	OnBoot bool `json:"onBoot,omitempty"`
+	// KernelArgs contains the kernel arguments allocating the hugepages on boot on top of the previous counts
+	KernelArgs []string `json:"kernelArgs,omitempty"`
+	// BootID identifies the boot of the host the hugepages were allocated at runtime on,
+	// the runtime allocation is lost on reboot while the record is kept
+	BootID string `json:"bootID,omitempty"`
+}
+
+// Matches returns true if the hugepages have the same size and count on the same NUMA nodes
+func (h *ManagedHugepages) Matches(other *ManagedHugepages) bool {
+	if h == nil || other == nil {
+		return h == other
+	}
+	return h.Size == other.Size && h.Count == other.Count && slices.Equal(h.NumaNodes, other.NumaNodes)
 }
 
 type manager struct{}
@@ -189,3 +250,84 @@ func (s *manager) WriteCheckpointFile(ns *sriovnetworkv1.SriovNetworkNodeState)
 	}
 	return nil
 }
+
+// SaveKernelArgsState saves the expected kernel arguments state as a json into the /etc/sriov-operator/kernel-args-state.json
+func (s *manager) SaveKernelArgsState(state *KernelArgsState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		log.Log.Error(err, "failed to marshal kernel args state", "state", *state)
+		return err
+	}
+
+	pathFile := filepath.Join(utils.GetHostExtension(), consts.KernelArgsStatePath)
+	return os.WriteFile(pathFile, data, 0644)
+}
+
+// LoadKernelArgsState reads the /etc/sriov-operator/kernel-args-state.json file
+// returns false if the file doesn't exist.
+func (s *manager) LoadKernelArgsState() (*KernelArgsState, bool, error) {
+	pathFile := filepath.Join(utils.GetHostExtension(), consts.KernelArgsStatePath)
+	state := &KernelArgsState{}
+	data, err := os.ReadFile(pathFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		log.Log.Error(err, "failed to read kernel args state", "path", pathFile)
+		return nil, false, err
+	}
+
+	err = json.Unmarshal(data, state)
+	if err != nil {
+		log.Log.Error(err, "failed to unmarshal kernel args state", "data", string(data))
+		return nil, false, err
+	}
+
+	return state, true, nil
+}
+
+// RemoveKernelArgsState removes the /etc/sriov-operator/kernel-args-state.json file
+func (s *manager) RemoveKernelArgsState() error {
+	pathFile := filepath.Join(utils.GetHostExtension(), consts.KernelArgsStatePath)
+	err := os.RemoveAll(pathFile)
+	if err != nil {
+		log.Log.Error(err, "failed to remove kernel args state", "pathFile", pathFile)
+		return err
+	}
+	return nil
+}
+
+// SaveManagedKernelConfig saves the managed kernel configuration as a json into the /etc/sriov-operator/managed-kernel-config.json
+func (s *manager) SaveManagedKernelConfig(config *ManagedKernelConfig) error {
+	data, err := json.Marshal(config)
+	if err != nil {
+		log.Log.Error(err, "failed to marshal managed kernel config", "config", *config)
+		return err
+	}
+
+	pathFile := filepath.Join(utils.GetHostExtension(), consts.ManagedKernelConfigPath)
+	return os.WriteFile(pathFile, data, 0644)
+}
+
+// LoadManagedKernelConfig reads the /etc/sriov-operator/managed-kernel-config.json file
+// returns an empty configuration if the file doesn't exist.
+func (s *manager) LoadManagedKernelConfig() (*ManagedKernelConfig, error) {
+	pathFile := filepath.Join(utils.GetHostExtension(), consts.ManagedKernelConfigPath)
+	config := &ManagedKernelConfig{}
+	data, err := os.ReadFile(pathFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return config, nil
+		}
+		log.Log.Error(err, "failed to read managed kernel config", "path", pathFile)
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, config)
+	if err != nil {
+		log.Log.Error(err, "failed to unmarshal managed kernel config", "data", string(data))
+		return nil, err
+	}
+
+	return config, nil
+}
@@ -233,6 +233,72 @@ var _ = Describe("Store", func() {
 		})
 	})
 
+	Context("KernelArgsState", func() {
+		It("should return false if the file doesn't exist", func() {
+			err = m.RemoveKernelArgsState()
+			Expect(err).ToNot(HaveOccurred())
+
+			state, exist, err := m.LoadKernelArgsState()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exist).To(BeFalse())
+			Expect(state).To(BeNil())
+		})
+
+		It("should save, load and remove the state", func() {
+			state := &KernelArgsState{
+				BootID:     "b5e6e3a4-5b2c-4d0f-9a51-1e6a0f7d2c3e",
+				Generation: 3,
+				KernelArgs: map[string]bool{consts.KernelArgIommuPt: true, consts.KernelArgRdmaShared: false},
+				Mismatch:   []string{consts.KernelArgIommuPt},
+			}
+			err = m.SaveKernelArgsState(state)
+			Expect(err).ToNot(HaveOccurred())
+
+			loaded, exist, err := m.LoadKernelArgsState()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exist).To(BeTrue())
+			Expect(loaded).To(Equal(state))
+
+			err = m.RemoveKernelArgsState()
+			Expect(err).ToNot(HaveOccurred())
+			_, exist, err = m.LoadKernelArgsState()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exist).To(BeFalse())
+		})
+
+		It("should return error if not able to parse the file", func() {
+			err = os.WriteFile(utils.GetHostExtensionPath(consts.KernelArgsStatePath), []byte("test"), 0644)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, _, err = m.LoadKernelArgsState()
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("ManagedKernelConfig", func() {
+		It("should return an empty config if the file doesn't exist", func() {
+			err = os.RemoveAll(utils.GetHostExtensionPath(consts.ManagedKernelConfigPath))
+			Expect(err).ToNot(HaveOccurred())
+
+			config, err := m.LoadManagedKernelConfig()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config).To(Equal(&ManagedKernelConfig{}))
+		})
+
+		It("should save and load the config", func() {
+			config := &ManagedKernelConfig{
+				KernelArgs:    []string{"hugepages=16"},
+				KernelModules: []string{"mlx5_core"},
+			}
+			err = m.SaveManagedKernelConfig(config)
+			Expect(err).ToNot(HaveOccurred())
+
+			loaded, err := m.LoadManagedKernelConfig()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(loaded).To(Equal(config))
+		})
+	})
+
 	Context("GetCheckPointNodeState", func() {
 		It("should return not error and return empty struct if file doesn't exist", func() {
 			ns, err := m.GetCheckPointNodeState()
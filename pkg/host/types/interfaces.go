@@ -18,6 +18,10 @@ type KernelInterface interface {
 	GetCurrentKernelArgs() (string, error)
 	// IsKernelArgsSet check is the requested kernel arguments are set
 	IsKernelArgsSet(cmdLine, karg string) bool
+	// GetBootID reads the /proc/sys/kernel/random/boot_id to identify the current boot of the host
+	GetBootID() (string, error)
+	// GetKernelRelease reads the /proc/sys/kernel/osrelease to get the release of the running kernel, e.g. "5.14.0-427.el9"
+	GetKernelRelease() (string, error)
 	// Unbind unbinds a virtual function from is current driver
 	Unbind(pciAddr string) error
 	// BindDpdkDriver binds the virtual function to a DPDK driver
@@ -44,12 +48,25 @@ type KernelInterface interface {
 	UnbindDriverByBusAndDevice(bus, device string) error
 	// UnbindDriverIfNeeded unbinds the virtual function from a driver if needed
 	UnbindDriverIfNeeded(pciAddr string, isRdma bool) error
-	// LoadKernelModule loads a kernel module to the host
+	// LoadKernelModule loads a kernel module to the host,
+	// the module arguments are persisted in modprobe.d to be used on the next module load
 	LoadKernelModule(name string, args ...string) error
+	// SetKernelModuleParams persists the kernel module parameters in modprobe.d,
+	// the configuration file is removed if the list of parameters is empty
+	SetKernelModuleParams(name string, params []string) error
+	// IsKernelModuleParamsSet returns true if the loaded kernel module runs with the requested parameters,
+	// returns true if the module is not loaded
+	IsKernelModuleParamsSet(name string, params []string) (bool, error)
 	// IsKernelModuleLoaded returns try if the requested kernel module is loaded
 	IsKernelModuleLoaded(name string) (bool, error)
 	// IsKernelLockdownMode returns true if the kernel is in lockdown mode
 	IsKernelLockdownMode() bool
+	// GetDeviceNumaNode returns the NUMA node of the PCI device, 0 is returned if the host has no NUMA topology
+	GetDeviceNumaNode(pciAddr string) (int, error)
+	// GetHugepages returns the hugepages allocated on every NUMA node of the host
+	GetHugepages() ([]sriovnetworkv1.HugepagesStatus, error)
+	// SetHugepages requests the kernel to allocate the count of hugepages with the size ("2M", "1G") on the NUMA node
+	SetHugepages(numaNode int, size string, count int) error
 }
 
 type NetworkInterface interface {
@@ -119,6 +136,9 @@ type SriovInterface interface {
 	// SetSriovNumVfs changes the number of virtual functions allocated for a specific
 	// physical function base on pci address
 	SetSriovNumVfs(pciAddr string, numVfs int) error
+	// IsSriovNumVfsResizeSupported returns true if the driver of the physical function can change
+	// the number of virtual functions without resetting the virtual functions that are already allocated
+	IsSriovNumVfsResizeSupported(pciAddr string) bool
 	// VFIsReady returns the interface virtual function if the device is ready
 	VFIsReady(pciAddr string) (netlink.Link, error)
 	// SetVfAdminMac sets the virtual function administrative mac address via the physical function
@@ -150,6 +170,8 @@ type UdevInterface interface {
 	PrepareNMUdevRule(supportedVfIds []string) error
 	// PrepareVFRepUdevRule creates a script which helps to configure representor name for the VF
 	PrepareVFRepUdevRule() error
+	// PrepareVFNameUdevRule creates a script which helps to configure the name of the VF netdev in legacy mode
+	PrepareVFNameUdevRule() error
 	// AddDisableNMUdevRule adds udev rule that disables NetworkManager for VFs on the concrete PF:
 	AddDisableNMUdevRule(pfPciAddress string) error
 	// RemoveDisableNMUdevRule removes udev rule that disables NetworkManager for VFs on the concrete PF
@@ -162,6 +184,10 @@ type UdevInterface interface {
 	AddVfRepresentorUdevRule(pfPciAddress, pfName, pfSwitchID, pfSwitchPort string) error
 	// RemoveVfRepresentorUdevRule removes udev rule that renames VF representors on the concrete PF
 	RemoveVfRepresentorUdevRule(pfPciAddress string) error
+	// AddVfNameUdevRule adds udev rule that renames the VF netdevs of the concrete PF using the name template
+	AddVfNameUdevRule(pfPciAddress, pfName, template string) error
+	// RemoveVfNameUdevRule removes udev rule that renames the VF netdevs of the concrete PF
+	RemoveVfNameUdevRule(pfPciAddress string) error
 	// LoadUdevRules triggers udev rules for network subsystem
 	LoadUdevRules() error
 	// WaitUdevEventsProcessed calls `udevadm settle“ with provided timeout
@@ -169,6 +195,14 @@ type UdevInterface interface {
 	WaitUdevEventsProcessed(timeout int) error
 }
 
+type HostNetworkManagerInterface interface {
+	// AddUnmanagedVfsConfig writes the configuration that prevents the network managers detected on the host
+	// from managing or renaming the VFs of the PF
+	AddUnmanagedVfsConfig(pfPciAddress string) error
+	// RemoveUnmanagedVfsConfig removes the configuration written by AddUnmanagedVfsConfig for the PF
+	RemoveUnmanagedVfsConfig(pfPciAddress string) error
+}
+
 type VdpaInterface interface {
 	// CreateVDPADevice creates VDPA device for VF with required type
 	CreateVDPADevice(pciAddr, vdpaType string) error
@@ -188,6 +222,11 @@ type BridgeInterface interface {
 	// this step is required before applying some configurations to PF, e.g. changing of eSwitch mode.
 	// The function detach interface from managed bridges only.
 	DetachInterfaceFromManagedBridge(pciAddr string) error
+	// GetOVSHwOffload returns the OVS hardware offload settings of the host
+	GetOVSHwOffload() (*sriovnetworkv1.OvsHardwareOffload, error)
+	// ConfigureOVSHwOffload applies the OVS hardware offload settings,
+	// the OVS daemon is restarted if the settings were changed
+	ConfigureOVSHwOffload(conf *sriovnetworkv1.OvsHardwareOffload) error
 }
 
 type InfinibandInterface interface {
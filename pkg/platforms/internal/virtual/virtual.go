@@ -0,0 +1,98 @@
+// Package virtual contains the PCI device discovery shared by the virtual platforms,
+// where each device passed into the virtual machine is exposed as a PF with a single VF.
+package virtual
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/jaypipes/ghw"
+	"github.com/jaypipes/ghw/pkg/pci"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	dputils "github.com/k8snetworkplumbingwg/sriov-network-device-plugin/pkg/utils"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host"
+)
+
+// GetNetDevices returns the network class PCI devices of the virtual machine
+func GetNetDevices() ([]*pci.Device, error) {
+	pciInfo, err := ghw.PCI()
+	if err != nil {
+		return nil, fmt.Errorf("error getting PCI info: %v", err)
+	}
+
+	if len(pciInfo.Devices) == 0 {
+		return nil, fmt.Errorf("could not retrieve PCI devices")
+	}
+
+	netDevices := []*pci.Device{}
+	for _, device := range pciInfo.Devices {
+		devClass, err := strconv.ParseInt(device.Class.ID, 16, 64)
+		if err != nil {
+			log.Log.Error(err, "GetNetDevices(): unable to parse device class for device, skipping",
+				"device", device)
+			continue
+		}
+		if devClass != consts.NetClass {
+			// Not network device
+			continue
+		}
+		netDevices = append(netDevices, device)
+	}
+	return netDevices, nil
+}
+
+// GetDeviceMac returns the MAC address of the netdevice of the PCI device,
+// an empty string is returned if the netdevice or its MAC address is not found
+func GetDeviceMac(hostManager host.HostManagerInterface, pciAddr string) string {
+	name := hostManager.TryToGetVirtualInterfaceName(pciAddr)
+	if name == "" {
+		return ""
+	}
+	return hostManager.GetNetDevMac(name)
+}
+
+// NewInterfaceExt returns the status of the device as a PF with a single VF,
+// metaMac is used when the MAC address of the netdevice can't be read
+func NewInterfaceExt(hostManager host.HostManagerInterface, device *pci.Device, netFilter, metaMac string) (*sriovnetworkv1.InterfaceExt, error) {
+	driver, err := dputils.GetDriverName(device.Address)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse device driver for device %s: %v", device.Address, err)
+	}
+	iface := &sriovnetworkv1.InterfaceExt{
+		PciAddress: device.Address,
+		Driver:     driver,
+		Vendor:     device.Vendor.ID,
+		DeviceID:   device.Product.ID,
+		NetFilter:  netFilter,
+	}
+	if mtu := hostManager.GetNetdevMTU(device.Address); mtu > 0 {
+		iface.Mtu = mtu
+	}
+	if name := hostManager.TryToGetVirtualInterfaceName(device.Address); name != "" {
+		iface.Name = name
+		if iface.Mac = hostManager.GetNetDevMac(name); iface.Mac == "" {
+			iface.Mac = metaMac
+		}
+		iface.LinkSpeed = hostManager.GetNetDevLinkSpeed(name)
+		iface.LinkType = hostManager.GetLinkType(name)
+	}
+
+	iface.TotalVfs = 1
+	iface.NumVfs = 1
+
+	vf := sriovnetworkv1.VirtualFunction{
+		PciAddress: device.Address,
+		Driver:     driver,
+		VfID:       0,
+		Vendor:     iface.Vendor,
+		DeviceID:   iface.DeviceID,
+		Mtu:        iface.Mtu,
+		Mac:        iface.Mac,
+	}
+	iface.VFs = append(iface.VFs, vf)
+	return iface, nil
+}
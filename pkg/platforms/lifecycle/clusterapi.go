@@ -0,0 +1,125 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+)
+
+var machineGVK = schema.GroupVersionKind{Group: "cluster.x-k8s.io", Version: "v1beta1", Kind: "Machine"}
+
+// clusterAPICoordinator waits for Cluster API to finish the drain of the Machine of the node,
+// while the operator holds the node the pre-drain hook on the Machine stops Cluster API from draining it.
+// The kubeClient must be connected to the management cluster running Cluster API.
+type clusterAPICoordinator struct {
+	kubeClient client.Client
+}
+
+func newClusterAPICoordinator(kubeClient client.Client) NodeLifecycleCoordinatorInterface {
+	return &clusterAPICoordinator{kubeClient: kubeClient}
+}
+
+// getClusterAPIClient returns the client for the Cluster API management cluster,
+// it's built from the kubeconfig in the CLUSTER_API_KUBECONFIG environment variable if it's set
+// and the given client of the cluster of the operator is returned otherwise
+func getClusterAPIClient(kubeClient client.Client) (client.Client, error) {
+	kubeconfig, exist := os.LookupEnv("CLUSTER_API_KUBECONFIG")
+	if !exist || kubeconfig == "" {
+		return kubeClient, nil
+	}
+	config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the Cluster API management cluster kubeconfig %s: %w", kubeconfig, err)
+	}
+	managementClient, err := client.New(config, client.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the Cluster API management cluster client: %w", err)
+	}
+	return managementClient, nil
+}
+
+// getMachine returns the Cluster API Machine of the node, nil is returned if the node is not managed by Cluster API
+func (c *clusterAPICoordinator) getMachine(ctx context.Context, node *corev1.Node) (*unstructured.Unstructured, error) {
+	name, exist := node.Annotations[consts.ClusterAPIMachineAnnotation]
+	if !exist {
+		return nil, nil
+	}
+	namespace := node.Annotations[consts.ClusterAPIClusterNamespaceAnnotation]
+
+	machine := &unstructured.Unstructured{}
+	machine.SetGroupVersionKind(machineGVK)
+	err := c.kubeClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, machine)
+	if err != nil {
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			// the Machines usually live in the management cluster and not in the cluster of the operator
+			log.Log.Info("Cluster API Machine of the node not found, Cluster API drain coordination is inactive, "+
+				"set CLUSTER_API_KUBECONFIG to the kubeconfig of the management cluster to enable it",
+				"node", node.Name, "machine", namespace+"/"+name)
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get machine %s/%s of the node: %w", namespace, name, err)
+	}
+	return machine, nil
+}
+
+func (c *clusterAPICoordinator) AcquireNode(ctx context.Context, node *corev1.Node) (bool, error) {
+	machine, err := c.getMachine(ctx, node)
+	if err != nil || machine == nil {
+		return err == nil, err
+	}
+
+	if machine.GetDeletionTimestamp() != nil {
+		log.Log.Info("Cluster API is deleting the machine of the node", "node", node.Name, "machine", machine.GetName())
+		return false, nil
+	}
+
+	if _, exist := machine.GetAnnotations()[consts.ClusterAPIPreDrainHookAnnotation]; exist {
+		return true, nil
+	}
+	patch := client.MergeFrom(machine.DeepCopy())
+	annotations := machine.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[consts.ClusterAPIPreDrainHookAnnotation] = ""
+	machine.SetAnnotations(annotations)
+	if err := c.kubeClient.Patch(ctx, machine, patch); err != nil {
+		return false, fmt.Errorf("failed to add the pre-drain hook to machine %s: %w", machine.GetName(), err)
+	}
+	return true, nil
+}
+
+func (c *clusterAPICoordinator) ReleaseNode(ctx context.Context, node *corev1.Node) error {
+	machine, err := c.getMachine(ctx, node)
+	if err != nil || machine == nil {
+		return err
+	}
+
+	if _, exist := machine.GetAnnotations()[consts.ClusterAPIPreDrainHookAnnotation]; !exist {
+		return nil
+	}
+	patch := client.MergeFrom(machine.DeepCopy())
+	annotations := machine.GetAnnotations()
+	delete(annotations, consts.ClusterAPIPreDrainHookAnnotation)
+	machine.SetAnnotations(annotations)
+	if err := c.kubeClient.Patch(ctx, machine, patch); err != nil {
+		return fmt.Errorf("failed to remove the pre-drain hook from machine %s: %w", machine.GetName(), err)
+	}
+	return nil
+}
+
+func (c *clusterAPICoordinator) RenewNode(context.Context, *corev1.Node) error {
+	// the pre-drain hook stays on the Machine until the node is released
+	return nil
+}
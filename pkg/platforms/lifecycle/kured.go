@@ -0,0 +1,179 @@
+package lifecycle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+)
+
+// kuredCoordinator takes the kured reboot lock while the operator holds nodes,
+// so kured doesn't reboot any node while the operator is draining or rebooting one.
+// The lock is the annotation of the kured DaemonSet, it's taken with an optimistic update of the DaemonSet
+// like kured does, the nodes held by the operator are listed in the metadata of the lock.
+type kuredCoordinator struct {
+	kubeClient client.Client
+}
+
+// kuredLock is the value of the kured lock annotation
+type kuredLock struct {
+	NodeID   string          `json:"nodeID"`
+	Metadata json.RawMessage `json:"metadata,omitempty"`
+	Created  time.Time       `json:"created"`
+	TTL      time.Duration   `json:"TTL"`
+}
+
+// kuredLockMetadata is the metadata of the kured lock taken by the operator
+type kuredLockMetadata struct {
+	Nodes []string `json:"nodes"`
+}
+
+func newKuredCoordinator(kubeClient client.Client) NodeLifecycleCoordinatorInterface {
+	return &kuredCoordinator{kubeClient: kubeClient}
+}
+
+// getKuredDaemonSet returns the namespace and the name of the kured DaemonSet
+func getKuredDaemonSet() (string, string) {
+	if value, exist := os.LookupEnv("KURED_DAEMONSET"); exist {
+		if namespace, name, found := strings.Cut(value, "/"); found {
+			return namespace, name
+		}
+	}
+	return consts.KuredDefaultNamespace, consts.KuredDefaultDaemonSet
+}
+
+// getLock returns the kured DaemonSet and its lock, the DaemonSet is nil if kured is not deployed
+// and the lock is nil if nobody holds it
+func (k *kuredCoordinator) getLock(ctx context.Context) (*appsv1.DaemonSet, *kuredLock, error) {
+	namespace, name := getKuredDaemonSet()
+	ds := &appsv1.DaemonSet{}
+	err := k.kubeClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, ds)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("failed to get kured daemonset %s/%s: %w", namespace, name, err)
+	}
+
+	value, exist := ds.Annotations[consts.KuredNodeLockAnnotation]
+	if !exist || value == "" {
+		return ds, nil, nil
+	}
+	lock := &kuredLock{}
+	if err := json.Unmarshal([]byte(value), lock); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse kured lock annotation %q: %w", value, err)
+	}
+	// kured ignores the locks with an expired TTL
+	if lock.TTL > 0 && time.Now().After(lock.Created.Add(lock.TTL)) {
+		return ds, nil, nil
+	}
+	return ds, lock, nil
+}
+
+// getLockedNodes returns the nodes listed in the kured lock taken by the operator
+func getLockedNodes(lock *kuredLock) ([]string, error) {
+	if lock == nil || lock.NodeID != consts.NodeLifecycleLeaseHolder || len(lock.Metadata) == 0 {
+		return nil, nil
+	}
+	metadata := &kuredLockMetadata{}
+	if err := json.Unmarshal(lock.Metadata, metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse kured lock metadata %q: %w", string(lock.Metadata), err)
+	}
+	return metadata.Nodes, nil
+}
+
+// setLock updates the kured lock annotation with the given nodes, the annotation is removed if the list is empty.
+// The update fails with a conflict if the DaemonSet was changed since it was read.
+func (k *kuredCoordinator) setLock(ctx context.Context, ds *appsv1.DaemonSet, nodes []string) error {
+	patch := client.MergeFromWithOptions(ds.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	if len(nodes) == 0 {
+		delete(ds.Annotations, consts.KuredNodeLockAnnotation)
+	} else {
+		metadata, err := json.Marshal(&kuredLockMetadata{Nodes: nodes})
+		if err != nil {
+			return err
+		}
+		value, err := json.Marshal(&kuredLock{
+			NodeID:   consts.NodeLifecycleLeaseHolder,
+			Metadata: metadata,
+			Created:  time.Now().UTC(),
+		})
+		if err != nil {
+			return err
+		}
+		if ds.Annotations == nil {
+			ds.Annotations = map[string]string{}
+		}
+		ds.Annotations[consts.KuredNodeLockAnnotation] = string(value)
+	}
+	return k.kubeClient.Patch(ctx, ds, patch)
+}
+
+func (k *kuredCoordinator) AcquireNode(ctx context.Context, node *corev1.Node) (bool, error) {
+	if _, exist := node.Annotations[consts.KuredRebootInProgressAnnotation]; exist {
+		log.Log.Info("kured is rebooting the node", "node", node.Name)
+		return false, nil
+	}
+
+	ds, lock, err := k.getLock(ctx)
+	if err != nil || ds == nil {
+		// kured is not deployed if there is no error
+		return err == nil, err
+	}
+	if lock != nil && lock.NodeID != consts.NodeLifecycleLeaseHolder {
+		log.Log.Info("kured holds the reboot lock", "node", node.Name, "holder", lock.NodeID)
+		return false, nil
+	}
+
+	nodes, err := getLockedNodes(lock)
+	if err != nil {
+		return false, err
+	}
+	if slices.Contains(nodes, node.Name) {
+		return true, nil
+	}
+	if err := k.setLock(ctx, ds, append(nodes, node.Name)); err != nil {
+		if errors.IsConflict(err) {
+			// kured or another drain changed the lock in the meantime
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to take the kured lock for node %s: %w", node.Name, err)
+	}
+	return true, nil
+}
+
+func (k *kuredCoordinator) ReleaseNode(ctx context.Context, node *corev1.Node) error {
+	ds, lock, err := k.getLock(ctx)
+	if err != nil || ds == nil {
+		return err
+	}
+
+	nodes, err := getLockedNodes(lock)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(nodes, node.Name) {
+		return nil
+	}
+	nodes = slices.DeleteFunc(nodes, func(n string) bool { return n == node.Name })
+	if err := k.setLock(ctx, ds, nodes); err != nil {
+		return fmt.Errorf("failed to release the kured lock for node %s: %w", node.Name, err)
+	}
+	return nil
+}
+
+func (k *kuredCoordinator) RenewNode(context.Context, *corev1.Node) error {
+	// the kured lock taken by the operator has no TTL
+	return nil
+}
@@ -0,0 +1,129 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
+)
+
+// leaseDuration is the time the lease is valid without renew, the lease is renewed on each drain request
+// of the node and it covers the reboot of the node
+const leaseDuration = time.Hour
+
+// leaseCoordinator takes the coordination.k8s.io Lease of the node,
+// other node lifecycle managers can use the same lease to avoid rebooting the node at the same time
+type leaseCoordinator struct {
+	kubeClient client.Client
+}
+
+func newLeaseCoordinator(kubeClient client.Client) NodeLifecycleCoordinatorInterface {
+	return &leaseCoordinator{kubeClient: kubeClient}
+}
+
+func getLeaseName(node *corev1.Node) string {
+	return consts.NodeLifecycleLeasePrefix + node.Name
+}
+
+func (l *leaseCoordinator) AcquireNode(ctx context.Context, node *corev1.Node) (bool, error) {
+	now := metav1.NewMicroTime(time.Now())
+	lease := &coordinationv1.Lease{}
+	err := l.kubeClient.Get(ctx, client.ObjectKey{Name: getLeaseName(node), Namespace: vars.Namespace}, lease)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to get node lifecycle lease: %w", err)
+		}
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{Name: getLeaseName(node), Namespace: vars.Namespace},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       ptr.To(consts.NodeLifecycleLeaseHolder),
+				LeaseDurationSeconds: ptr.To(int32(leaseDuration.Seconds())),
+				AcquireTime:          &now,
+				RenewTime:            &now,
+			},
+		}
+		if err := l.kubeClient.Create(ctx, lease); err != nil {
+			if errors.IsAlreadyExists(err) {
+				// another actor took the lease in the meantime
+				return false, nil
+			}
+			return false, fmt.Errorf("failed to create node lifecycle lease: %w", err)
+		}
+		return true, nil
+	}
+
+	if ptr.Deref(lease.Spec.HolderIdentity, "") != consts.NodeLifecycleLeaseHolder && !isLeaseExpired(lease) {
+		log.Log.Info("node lifecycle lease is held by another actor",
+			"node", node.Name, "holder", ptr.Deref(lease.Spec.HolderIdentity, ""))
+		return false, nil
+	}
+
+	if ptr.Deref(lease.Spec.HolderIdentity, "") != consts.NodeLifecycleLeaseHolder {
+		lease.Spec.HolderIdentity = ptr.To(consts.NodeLifecycleLeaseHolder)
+		lease.Spec.AcquireTime = &now
+		lease.Spec.LeaseTransitions = ptr.To(ptr.Deref(lease.Spec.LeaseTransitions, 0) + 1)
+	}
+	lease.Spec.LeaseDurationSeconds = ptr.To(int32(leaseDuration.Seconds()))
+	lease.Spec.RenewTime = &now
+	// the update fails with a conflict if another actor changed the lease since we read it
+	if err := l.kubeClient.Update(ctx, lease); err != nil {
+		if errors.IsConflict(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to update node lifecycle lease: %w", err)
+	}
+	return true, nil
+}
+
+func (l *leaseCoordinator) ReleaseNode(ctx context.Context, node *corev1.Node) error {
+	lease := &coordinationv1.Lease{}
+	err := l.kubeClient.Get(ctx, client.ObjectKey{Name: getLeaseName(node), Namespace: vars.Namespace}, lease)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get node lifecycle lease: %w", err)
+	}
+	if ptr.Deref(lease.Spec.HolderIdentity, "") != consts.NodeLifecycleLeaseHolder {
+		return nil
+	}
+	if err := l.kubeClient.Delete(ctx, lease, client.Preconditions{ResourceVersion: &lease.ResourceVersion}); err != nil &&
+		!errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete node lifecycle lease: %w", err)
+	}
+	return nil
+}
+
+func (l *leaseCoordinator) RenewNode(ctx context.Context, node *corev1.Node) error {
+	// acquiring the lease again updates its renew time if the operator still holds it
+	acquired, err := l.AcquireNode(ctx, node)
+	if err != nil {
+		return err
+	}
+	if !acquired {
+		return fmt.Errorf("node lifecycle lease of node %s was taken by another actor", node.Name)
+	}
+	return nil
+}
+
+// isLeaseExpired returns true if the holder of the lease didn't renew it in time
+func isLeaseExpired(lease *coordinationv1.Lease) bool {
+	if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity == "" {
+		return true
+	}
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return false
+	}
+	expire := lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second)
+	return time.Now().After(expire)
+}
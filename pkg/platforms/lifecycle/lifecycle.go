@@ -0,0 +1,84 @@
+package lifecycle
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+//go:generate ../../../bin/mockgen -destination mock/mock_lifecycle.go -source lifecycle.go
+
+// NodeLifecycleCoordinatorInterface coordinates the drain and the reboot of the node
+// with the other node lifecycle managers running in the cluster
+type NodeLifecycleCoordinatorInterface interface {
+	// AcquireNode returns true if the operator is allowed to drain and reboot the node,
+	// false means another actor is working on the node and the request must be retried later
+	AcquireNode(context.Context, *corev1.Node) (bool, error)
+	// ReleaseNode releases the node after the operator completed the drain
+	ReleaseNode(context.Context, *corev1.Node) error
+	// RenewNode extends the hold of a node acquired by the operator,
+	// it's called on each drain request of the node while the drain is in progress
+	RenewNode(context.Context, *corev1.Node) error
+}
+
+// coordinators runs all the configured coordinators, the node is acquired only if all of them acquired it
+type coordinators struct {
+	coordinators []NodeLifecycleCoordinatorInterface
+}
+
+// New returns the coordinator which takes the node lifecycle lease and the kured reboot lock
+// and respects the Cluster API machine drain
+func New(kubeClient client.Client) (NodeLifecycleCoordinatorInterface, error) {
+	clusterAPIClient, err := getClusterAPIClient(kubeClient)
+	if err != nil {
+		return nil, err
+	}
+	return NewWithCoordinators(
+		newClusterAPICoordinator(clusterAPIClient),
+		newKuredCoordinator(kubeClient),
+		newLeaseCoordinator(kubeClient),
+	), nil
+}
+
+// NewWithCoordinators returns the coordinator that acquires the node with all the given coordinators in order
+func NewWithCoordinators(c ...NodeLifecycleCoordinatorInterface) NodeLifecycleCoordinatorInterface {
+	return &coordinators{coordinators: c}
+}
+
+func (c *coordinators) AcquireNode(ctx context.Context, node *corev1.Node) (bool, error) {
+	for i, coordinator := range c.coordinators {
+		acquired, err := coordinator.AcquireNode(ctx, node)
+		if err == nil && acquired {
+			continue
+		}
+		// release the node in the coordinators that already acquired it,
+		// so the other actors are not blocked while the operator is waiting
+		for j := i - 1; j >= 0; j-- {
+			if releaseErr := c.coordinators[j].ReleaseNode(ctx, node); releaseErr != nil {
+				log.Log.Error(releaseErr, "AcquireNode(): failed to release the node", "node", node.Name)
+			}
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (c *coordinators) ReleaseNode(ctx context.Context, node *corev1.Node) error {
+	for i := len(c.coordinators) - 1; i >= 0; i-- {
+		if err := c.coordinators[i].ReleaseNode(ctx, node); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *coordinators) RenewNode(ctx context.Context, node *corev1.Node) error {
+	for _, coordinator := range c.coordinators {
+		if err := coordinator.RenewNode(ctx, node); err != nil {
+			return err
+		}
+	}
+	return nil
+}
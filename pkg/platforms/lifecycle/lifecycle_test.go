@@ -0,0 +1,267 @@
+package lifecycle
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"go.uber.org/mock/gomock"
+	appsv1 "k8s.io/api/apps/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	mock_lifecycle "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/platforms/lifecycle/mock"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
+)
+
+func TestLifecycle(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Lifecycle")
+}
+
+func newFakeClient(objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	scheme.AddKnownTypeWithName(machineGVK, &unstructured.Unstructured{})
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func newNode(annotations map[string]string) *corev1.Node {
+	return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-0", Annotations: annotations}}
+}
+
+var _ = Describe("Lifecycle", func() {
+	var (
+		ctx context.Context
+	)
+	BeforeEach(func() {
+		ctx = context.Background()
+		origNamespace := vars.Namespace
+		vars.Namespace = "sriov-network-operator"
+		DeferCleanup(func() {
+			vars.Namespace = origNamespace
+		})
+	})
+
+	Context("coordinators", func() {
+		var (
+			testCtrl *gomock.Controller
+			first    *mock_lifecycle.MockNodeLifecycleCoordinatorInterface
+			second   *mock_lifecycle.MockNodeLifecycleCoordinatorInterface
+			c        NodeLifecycleCoordinatorInterface
+		)
+		BeforeEach(func() {
+			testCtrl = gomock.NewController(GinkgoT())
+			first = mock_lifecycle.NewMockNodeLifecycleCoordinatorInterface(testCtrl)
+			second = mock_lifecycle.NewMockNodeLifecycleCoordinatorInterface(testCtrl)
+			c = NewWithCoordinators(first, second)
+		})
+		It("acquired by all coordinators", func() {
+			node := newNode(nil)
+			first.EXPECT().AcquireNode(ctx, node).Return(true, nil)
+			second.EXPECT().AcquireNode(ctx, node).Return(true, nil)
+			Expect(c.AcquireNode(ctx, node)).To(BeTrue())
+		})
+		It("release the node if one of the coordinators didn't acquire it", func() {
+			node := newNode(nil)
+			first.EXPECT().AcquireNode(ctx, node).Return(true, nil)
+			second.EXPECT().AcquireNode(ctx, node).Return(false, nil)
+			first.EXPECT().ReleaseNode(ctx, node).Return(nil)
+			Expect(c.AcquireNode(ctx, node)).To(BeFalse())
+		})
+		It("release in the reverse order", func() {
+			node := newNode(nil)
+			gomock.InOrder(
+				second.EXPECT().ReleaseNode(ctx, node).Return(nil),
+				first.EXPECT().ReleaseNode(ctx, node).Return(nil),
+			)
+			Expect(c.ReleaseNode(ctx, node)).To(Succeed())
+		})
+	})
+
+	Context("lease", func() {
+		It("create and delete the lease", func() {
+			kubeClient := newFakeClient()
+			l := newLeaseCoordinator(kubeClient)
+			node := newNode(nil)
+			Expect(l.AcquireNode(ctx, node)).To(BeTrue())
+
+			lease := &coordinationv1.Lease{}
+			Expect(kubeClient.Get(ctx, client.ObjectKey{Name: "node-lifecycle-worker-0", Namespace: vars.Namespace}, lease)).To(Succeed())
+			Expect(*lease.Spec.HolderIdentity).To(Equal(consts.NodeLifecycleLeaseHolder))
+			// acquire again renews the lease
+			Expect(l.AcquireNode(ctx, node)).To(BeTrue())
+
+			Expect(l.ReleaseNode(ctx, node)).To(Succeed())
+			err := kubeClient.Get(ctx, client.ObjectKey{Name: "node-lifecycle-worker-0", Namespace: vars.Namespace}, lease)
+			Expect(err).To(HaveOccurred())
+		})
+		It("lease is held by another actor", func() {
+			now := metav1.NewMicroTime(time.Now())
+			lease := &coordinationv1.Lease{
+				ObjectMeta: metav1.ObjectMeta{Name: "node-lifecycle-worker-0", Namespace: vars.Namespace},
+				Spec: coordinationv1.LeaseSpec{
+					HolderIdentity:       ptr.To("other"),
+					LeaseDurationSeconds: ptr.To(int32(600)),
+					RenewTime:            &now,
+				},
+			}
+			kubeClient := newFakeClient(lease)
+			l := newLeaseCoordinator(kubeClient)
+			Expect(l.AcquireNode(ctx, newNode(nil))).To(BeFalse())
+			// the lease of the other actor is not removed
+			Expect(l.ReleaseNode(ctx, newNode(nil))).To(Succeed())
+			Expect(kubeClient.Get(ctx, client.ObjectKeyFromObject(lease), lease)).To(Succeed())
+		})
+		It("take over an expired lease", func() {
+			renewTime := metav1.NewMicroTime(time.Now().Add(-time.Hour))
+			lease := &coordinationv1.Lease{
+				ObjectMeta: metav1.ObjectMeta{Name: "node-lifecycle-worker-0", Namespace: vars.Namespace},
+				Spec: coordinationv1.LeaseSpec{
+					HolderIdentity:       ptr.To("other"),
+					LeaseDurationSeconds: ptr.To(int32(600)),
+					RenewTime:            &renewTime,
+				},
+			}
+			kubeClient := newFakeClient(lease)
+			l := newLeaseCoordinator(kubeClient)
+			Expect(l.AcquireNode(ctx, newNode(nil))).To(BeTrue())
+			Expect(kubeClient.Get(ctx, client.ObjectKeyFromObject(lease), lease)).To(Succeed())
+			Expect(*lease.Spec.HolderIdentity).To(Equal(consts.NodeLifecycleLeaseHolder))
+			Expect(*lease.Spec.LeaseTransitions).To(Equal(int32(1)))
+		})
+		It("renew the lease", func() {
+			renewTime := metav1.NewMicroTime(time.Now().Add(-30 * time.Minute))
+			lease := &coordinationv1.Lease{
+				ObjectMeta: metav1.ObjectMeta{Name: "node-lifecycle-worker-0", Namespace: vars.Namespace},
+				Spec: coordinationv1.LeaseSpec{
+					HolderIdentity:       ptr.To(consts.NodeLifecycleLeaseHolder),
+					LeaseDurationSeconds: ptr.To(int32(3600)),
+					RenewTime:            &renewTime,
+				},
+			}
+			kubeClient := newFakeClient(lease)
+			l := newLeaseCoordinator(kubeClient)
+			Expect(l.RenewNode(ctx, newNode(nil))).To(Succeed())
+			Expect(kubeClient.Get(ctx, client.ObjectKeyFromObject(lease), lease)).To(Succeed())
+			Expect(lease.Spec.RenewTime.Time).To(BeTemporally(">", renewTime.Time))
+		})
+		It("fail to renew a lease taken by another actor", func() {
+			now := metav1.NewMicroTime(time.Now())
+			lease := &coordinationv1.Lease{
+				ObjectMeta: metav1.ObjectMeta{Name: "node-lifecycle-worker-0", Namespace: vars.Namespace},
+				Spec: coordinationv1.LeaseSpec{
+					HolderIdentity:       ptr.To("other"),
+					LeaseDurationSeconds: ptr.To(int32(600)),
+					RenewTime:            &now,
+				},
+			}
+			l := newLeaseCoordinator(newFakeClient(lease))
+			Expect(l.RenewNode(ctx, newNode(nil))).ToNot(Succeed())
+		})
+	})
+
+	Context("kured", func() {
+		It("kured is not deployed", func() {
+			k := newKuredCoordinator(newFakeClient())
+			Expect(k.AcquireNode(ctx, newNode(nil))).To(BeTrue())
+		})
+		It("reboot in progress on the node", func() {
+			k := newKuredCoordinator(newFakeClient())
+			Expect(k.AcquireNode(ctx, newNode(map[string]string{consts.KuredRebootInProgressAnnotation: "true"}))).To(BeFalse())
+		})
+		It("kured holds the lock", func() {
+			ds := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{
+				Name: "kured", Namespace: "kube-system",
+				Annotations: map[string]string{consts.KuredNodeLockAnnotation: `{"nodeID":"worker-1","metadata":{"unschedulable":false},"TTL":0}`},
+			}}
+			k := newKuredCoordinator(newFakeClient(ds))
+			Expect(k.AcquireNode(ctx, newNode(nil))).To(BeFalse())
+		})
+		It("take over an expired kured lock", func() {
+			created := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+			ds := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{
+				Name: "kured", Namespace: "kube-system",
+				Annotations: map[string]string{consts.KuredNodeLockAnnotation: `{"nodeID":"worker-1","created":"` + created + `","TTL":60000000000}`},
+			}}
+			k := newKuredCoordinator(newFakeClient(ds))
+			Expect(k.AcquireNode(ctx, newNode(nil))).To(BeTrue())
+		})
+		It("take and release the kured lock", func() {
+			ds := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "kured", Namespace: "kube-system"}}
+			kubeClient := newFakeClient(ds)
+			k := newKuredCoordinator(kubeClient)
+			worker1 := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-1"}}
+			Expect(k.AcquireNode(ctx, newNode(nil))).To(BeTrue())
+			Expect(k.AcquireNode(ctx, worker1)).To(BeTrue())
+
+			Expect(kubeClient.Get(ctx, client.ObjectKeyFromObject(ds), ds)).To(Succeed())
+			lock := &kuredLock{}
+			Expect(json.Unmarshal([]byte(ds.Annotations[consts.KuredNodeLockAnnotation]), lock)).To(Succeed())
+			Expect(lock.NodeID).To(Equal(consts.NodeLifecycleLeaseHolder))
+			Expect(getLockedNodes(lock)).To(Equal([]string{"worker-0", "worker-1"}))
+
+			Expect(k.ReleaseNode(ctx, newNode(nil))).To(Succeed())
+			Expect(kubeClient.Get(ctx, client.ObjectKeyFromObject(ds), ds)).To(Succeed())
+			Expect(ds.Annotations).To(HaveKey(consts.KuredNodeLockAnnotation))
+
+			Expect(k.ReleaseNode(ctx, worker1)).To(Succeed())
+			Expect(kubeClient.Get(ctx, client.ObjectKeyFromObject(ds), ds)).To(Succeed())
+			Expect(ds.Annotations).ToNot(HaveKey(consts.KuredNodeLockAnnotation))
+		})
+	})
+
+	Context("Cluster API", func() {
+		newMachine := func() *unstructured.Unstructured {
+			machine := &unstructured.Unstructured{}
+			machine.SetGroupVersionKind(machineGVK)
+			machine.SetName("machine-0")
+			machine.SetNamespace("capi")
+			return machine
+		}
+		capiNode := func() *corev1.Node {
+			return newNode(map[string]string{
+				consts.ClusterAPIMachineAnnotation:          "machine-0",
+				consts.ClusterAPIClusterNamespaceAnnotation: "capi",
+			})
+		}
+		It("node is not managed by Cluster API", func() {
+			c := newClusterAPICoordinator(newFakeClient())
+			Expect(c.AcquireNode(ctx, newNode(nil))).To(BeTrue())
+			Expect(c.ReleaseNode(ctx, newNode(nil))).To(Succeed())
+		})
+		It("add and remove the pre-drain hook", func() {
+			kubeClient := newFakeClient(newMachine())
+			c := newClusterAPICoordinator(kubeClient)
+			Expect(c.AcquireNode(ctx, capiNode())).To(BeTrue())
+
+			machine := newMachine()
+			Expect(kubeClient.Get(ctx, client.ObjectKeyFromObject(machine), machine)).To(Succeed())
+			Expect(machine.GetAnnotations()).To(HaveKey(consts.ClusterAPIPreDrainHookAnnotation))
+
+			Expect(c.ReleaseNode(ctx, capiNode())).To(Succeed())
+			Expect(kubeClient.Get(ctx, client.ObjectKeyFromObject(machine), machine)).To(Succeed())
+			Expect(machine.GetAnnotations()).NotTo(HaveKey(consts.ClusterAPIPreDrainHookAnnotation))
+		})
+		It("machine is being deleted", func() {
+			machine := newMachine()
+			machine.SetFinalizers([]string{"machine.cluster.x-k8s.io"})
+			kubeClient := newFakeClient(machine)
+			Expect(kubeClient.Delete(ctx, machine)).To(Succeed())
+			c := newClusterAPICoordinator(kubeClient)
+			Expect(c.AcquireNode(ctx, capiNode())).To(BeFalse())
+		})
+	})
+})
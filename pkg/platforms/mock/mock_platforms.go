@@ -44,6 +44,21 @@ func (m *MockInterface) EXPECT() *MockInterfaceMockRecorder {
 	return m.recorder
 }
 
+// AcquireNode mocks base method.
+func (m *MockInterface) AcquireNode(arg0 context.Context, arg1 *v11.Node) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcquireNode", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcquireNode indicates an expected call of AcquireNode.
+func (mr *MockInterfaceMockRecorder) AcquireNode(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireNode", reflect.TypeOf((*MockInterface)(nil).AcquireNode), arg0, arg1)
+}
+
 // ChangeMachineConfigPoolPause mocks base method.
 func (m *MockInterface) ChangeMachineConfigPoolPause(arg0 context.Context, arg1 *v10.MachineConfigPool, arg2 bool) error {
 	m.ctrl.T.Helper()
@@ -58,30 +73,30 @@ func (mr *MockInterfaceMockRecorder) ChangeMachineConfigPoolPause(arg0, arg1, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeMachineConfigPoolPause", reflect.TypeOf((*MockInterface)(nil).ChangeMachineConfigPoolPause), arg0, arg1, arg2)
 }
 
-// CreateOpenstackDevicesInfo mocks base method.
-func (m *MockInterface) CreateOpenstackDevicesInfo() error {
+// CreateDevicesInfo mocks base method.
+func (m *MockInterface) CreateDevicesInfo() error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateOpenstackDevicesInfo")
+	ret := m.ctrl.Call(m, "CreateDevicesInfo")
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// CreateOpenstackDevicesInfo indicates an expected call of CreateOpenstackDevicesInfo.
-func (mr *MockInterfaceMockRecorder) CreateOpenstackDevicesInfo() *gomock.Call {
+// CreateDevicesInfo indicates an expected call of CreateDevicesInfo.
+func (mr *MockInterfaceMockRecorder) CreateDevicesInfo() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOpenstackDevicesInfo", reflect.TypeOf((*MockInterface)(nil).CreateOpenstackDevicesInfo))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDevicesInfo", reflect.TypeOf((*MockInterface)(nil).CreateDevicesInfo))
 }
 
-// CreateOpenstackDevicesInfoFromNodeStatus mocks base method.
-func (m *MockInterface) CreateOpenstackDevicesInfoFromNodeStatus(arg0 *v1.SriovNetworkNodeState) {
+// CreateDevicesInfoFromNodeStatus mocks base method.
+func (m *MockInterface) CreateDevicesInfoFromNodeStatus(arg0 *v1.SriovNetworkNodeState) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "CreateOpenstackDevicesInfoFromNodeStatus", arg0)
+	m.ctrl.Call(m, "CreateDevicesInfoFromNodeStatus", arg0)
 }
 
-// CreateOpenstackDevicesInfoFromNodeStatus indicates an expected call of CreateOpenstackDevicesInfoFromNodeStatus.
-func (mr *MockInterfaceMockRecorder) CreateOpenstackDevicesInfoFromNodeStatus(arg0 any) *gomock.Call {
+// CreateDevicesInfoFromNodeStatus indicates an expected call of CreateDevicesInfoFromNodeStatus.
+func (mr *MockInterfaceMockRecorder) CreateDevicesInfoFromNodeStatus(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOpenstackDevicesInfoFromNodeStatus", reflect.TypeOf((*MockInterface)(nil).CreateOpenstackDevicesInfoFromNodeStatus), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDevicesInfoFromNodeStatus", reflect.TypeOf((*MockInterface)(nil).CreateDevicesInfoFromNodeStatus), arg0)
 }
 
 // DiscoverSriovDevicesVirtual mocks base method.
@@ -185,3 +200,96 @@ func (mr *MockInterfaceMockRecorder) OpenshiftBeforeDrainNode(arg0, arg1 any) *g
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenshiftBeforeDrainNode", reflect.TypeOf((*MockInterface)(nil).OpenshiftBeforeDrainNode), arg0, arg1)
 }
+
+// ReleaseNode mocks base method.
+func (m *MockInterface) ReleaseNode(arg0 context.Context, arg1 *v11.Node) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseNode", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseNode indicates an expected call of ReleaseNode.
+func (mr *MockInterfaceMockRecorder) ReleaseNode(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseNode", reflect.TypeOf((*MockInterface)(nil).ReleaseNode), arg0, arg1)
+}
+
+// RenewNode mocks base method.
+func (m *MockInterface) RenewNode(arg0 context.Context, arg1 *v11.Node) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenewNode", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenewNode indicates an expected call of RenewNode.
+func (mr *MockInterfaceMockRecorder) RenewNode(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewNode", reflect.TypeOf((*MockInterface)(nil).RenewNode), arg0, arg1)
+}
+
+// MockVirtualPlatformInterface is a mock of VirtualPlatformInterface interface.
+type MockVirtualPlatformInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockVirtualPlatformInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockVirtualPlatformInterfaceMockRecorder is the mock recorder for MockVirtualPlatformInterface.
+type MockVirtualPlatformInterfaceMockRecorder struct {
+	mock *MockVirtualPlatformInterface
+}
+
+// NewMockVirtualPlatformInterface creates a new mock instance.
+func NewMockVirtualPlatformInterface(ctrl *gomock.Controller) *MockVirtualPlatformInterface {
+	mock := &MockVirtualPlatformInterface{ctrl: ctrl}
+	mock.recorder = &MockVirtualPlatformInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVirtualPlatformInterface) EXPECT() *MockVirtualPlatformInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateDevicesInfo mocks base method.
+func (m *MockVirtualPlatformInterface) CreateDevicesInfo() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDevicesInfo")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDevicesInfo indicates an expected call of CreateDevicesInfo.
+func (mr *MockVirtualPlatformInterfaceMockRecorder) CreateDevicesInfo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDevicesInfo", reflect.TypeOf((*MockVirtualPlatformInterface)(nil).CreateDevicesInfo))
+}
+
+// CreateDevicesInfoFromNodeStatus mocks base method.
+func (m *MockVirtualPlatformInterface) CreateDevicesInfoFromNodeStatus(arg0 *v1.SriovNetworkNodeState) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CreateDevicesInfoFromNodeStatus", arg0)
+}
+
+// CreateDevicesInfoFromNodeStatus indicates an expected call of CreateDevicesInfoFromNodeStatus.
+func (mr *MockVirtualPlatformInterfaceMockRecorder) CreateDevicesInfoFromNodeStatus(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDevicesInfoFromNodeStatus", reflect.TypeOf((*MockVirtualPlatformInterface)(nil).CreateDevicesInfoFromNodeStatus), arg0)
+}
+
+// DiscoverSriovDevicesVirtual mocks base method.
+func (m *MockVirtualPlatformInterface) DiscoverSriovDevicesVirtual() ([]v1.InterfaceExt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscoverSriovDevicesVirtual")
+	ret0, _ := ret[0].([]v1.InterfaceExt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiscoverSriovDevicesVirtual indicates an expected call of DiscoverSriovDevicesVirtual.
+func (mr *MockVirtualPlatformInterfaceMockRecorder) DiscoverSriovDevicesVirtual() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscoverSriovDevicesVirtual", reflect.TypeOf((*MockVirtualPlatformInterface)(nil).DiscoverSriovDevicesVirtual))
+}
@@ -0,0 +1,87 @@
+package openshift
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	mcoconsts "github.com/openshift/machine-config-operator/pkg/daemon/constants"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils"
+)
+
+// hypershiftBeforeDrainNode returns false while the NodePool in-place upgrade is touching the node,
+// otherwise the node is annotated with the advisory drain annotation until the drain is completed.
+// The management cluster doesn't read the annotation, the NodePool upgrade is not held by the operator.
+func (c *openshiftContext) hypershiftBeforeDrainNode(ctx context.Context, node *corev1.Node) (bool, error) {
+	logger := ctx.Value("logger").(logr.Logger).WithName("hypershiftBeforeDrainNode")
+
+	// get the latest version of the node to see the current state of the NodePool upgrade
+	currentNode := &corev1.Node{}
+	err := c.kubeClient.Get(ctx, client.ObjectKey{Name: node.Name}, currentNode)
+	if err != nil {
+		return false, err
+	}
+
+	if isNodePoolUpgradeInProgress(currentNode) {
+		logger.Info("NodePool in-place upgrade is in progress on the node, waiting for it to finish")
+		return false, c.clearDrainAdvisory(ctx, currentNode)
+	}
+
+	if utils.ObjectHasAnnotation(currentNode,
+		consts.NodeDrainAdvisoryAnnotation,
+		consts.NodeDrainAdvisoryAnnotationDraining) {
+		return true, nil
+	}
+
+	err = utils.AnnotateObject(ctx, currentNode,
+		consts.NodeDrainAdvisoryAnnotation,
+		consts.NodeDrainAdvisoryAnnotationDraining,
+		c.kubeClient)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// hypershiftAfterCompleteDrainNode clears the advisory drain annotation of the node
+func (c *openshiftContext) hypershiftAfterCompleteDrainNode(ctx context.Context, node *corev1.Node) (bool, error) {
+	currentNode := &corev1.Node{}
+	err := c.kubeClient.Get(ctx, client.ObjectKey{Name: node.Name}, currentNode)
+	if err != nil {
+		return false, err
+	}
+
+	if err := c.clearDrainAdvisory(ctx, currentNode); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// clearDrainAdvisory sets the advisory drain annotation of the node to idle if the operator drains the node
+func (c *openshiftContext) clearDrainAdvisory(ctx context.Context, node *corev1.Node) error {
+	if !utils.ObjectHasAnnotation(node,
+		consts.NodeDrainAdvisoryAnnotation,
+		consts.NodeDrainAdvisoryAnnotationDraining) {
+		return nil
+	}
+	return utils.AnnotateObject(ctx, node,
+		consts.NodeDrainAdvisoryAnnotation,
+		consts.NodeDrainAdvisoryAnnotationIdle,
+		c.kubeClient)
+}
+
+// isNodePoolUpgradeInProgress returns true if the NodePool in-place upgrade is applying a new configuration
+// to the node or is draining it. The in-place upgrade runs the machine-config-daemon on the node,
+// which uses the same node annotations as the MachineConfigOperator.
+func isNodePoolUpgradeInProgress(node *corev1.Node) bool {
+	annotations := node.GetAnnotations()
+	if annotations[mcoconsts.CurrentMachineConfigAnnotationKey] !=
+		annotations[mcoconsts.DesiredMachineConfigAnnotationKey] {
+		return true
+	}
+	return annotations[mcoconsts.DesiredDrainerAnnotationKey] !=
+		annotations[mcoconsts.LastAppliedDrainerAnnotationKey]
+}
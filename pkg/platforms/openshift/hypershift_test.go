@@ -0,0 +1,95 @@
+package openshift
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	mcoconsts "github.com/openshift/machine-config-operator/pkg/daemon/constants"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+)
+
+func TestIsNodePoolUpgradeInProgress(t *testing.T) {
+	table := []struct {
+		tname       string
+		annotations map[string]string
+		expected    bool
+	}{
+		{
+			tname: "no upgrade",
+		},
+		{
+			tname: "configuration applied",
+			annotations: map[string]string{
+				mcoconsts.CurrentMachineConfigAnnotationKey: "config-1",
+				mcoconsts.DesiredMachineConfigAnnotationKey: "config-1",
+				mcoconsts.DesiredDrainerAnnotationKey:       "uncordon-config-1",
+				mcoconsts.LastAppliedDrainerAnnotationKey:   "uncordon-config-1",
+			},
+		},
+		{
+			tname: "new configuration requested",
+			annotations: map[string]string{
+				mcoconsts.CurrentMachineConfigAnnotationKey: "config-1",
+				mcoconsts.DesiredMachineConfigAnnotationKey: "config-2",
+			},
+			expected: true,
+		},
+		{
+			tname: "drain requested",
+			annotations: map[string]string{
+				mcoconsts.CurrentMachineConfigAnnotationKey: "config-1",
+				mcoconsts.DesiredMachineConfigAnnotationKey: "config-1",
+				mcoconsts.DesiredDrainerAnnotationKey:       "drain-config-2",
+				mcoconsts.LastAppliedDrainerAnnotationKey:   "uncordon-config-1",
+			},
+			expected: true,
+		},
+	}
+	for _, tc := range table {
+		t.Run(tc.tname, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-0", Annotations: tc.annotations}}
+			g.Expect(isNodePoolUpgradeInProgress(node)).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestHypershiftBeforeDrainNode(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.WithValue(context.Background(), "logger", log.Log)
+	scheme := runtime.NewScheme()
+	utilruntime.Must(corev1.AddToScheme(scheme))
+
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-0"}}
+	c := &openshiftContext{
+		kubeClient:         fake.NewClientBuilder().WithScheme(scheme).WithObjects(node).Build(),
+		isOpenShiftCluster: true,
+		openshiftFlavor:    OpenshiftFlavorHypershift,
+	}
+
+	completed, err := c.hypershiftBeforeDrainNode(ctx, node)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(completed).To(BeTrue())
+	g.Expect(c.kubeClient.Get(ctx, client.ObjectKeyFromObject(node), node)).To(Succeed())
+	g.Expect(node.Annotations).To(HaveKeyWithValue(consts.NodeDrainAdvisoryAnnotation, consts.NodeDrainAdvisoryAnnotationDraining))
+
+	// the in-place upgrade started to drain the node
+	node.Annotations[mcoconsts.DesiredDrainerAnnotationKey] = "drain-config-2"
+	g.Expect(c.kubeClient.Update(ctx, node)).To(Succeed())
+
+	completed, err = c.hypershiftBeforeDrainNode(ctx, node)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(completed).To(BeFalse())
+	g.Expect(c.kubeClient.Get(ctx, client.ObjectKeyFromObject(node), node)).To(Succeed())
+	g.Expect(node.Annotations).To(HaveKeyWithValue(consts.NodeDrainAdvisoryAnnotation, consts.NodeDrainAdvisoryAnnotationIdle))
+}
@@ -108,10 +108,10 @@ func (c *openshiftContext) OpenshiftBeforeDrainNode(ctx context.Context, node *c
 		return true, nil
 	}
 
-	// if the operator is running on hypershift variation of openshift there is no machine config operator
-	// just return true here
+	// if the operator is running on hypershift variation of openshift there is no machine config operator,
+	// the drain waits for the NodePool in-place upgrade of the node instead
 	if c.IsHypershift() {
-		return true, nil
+		return c.hypershiftBeforeDrainNode(ctx, node)
 	}
 
 	// get the machine pool name for the requested node
@@ -209,10 +209,10 @@ func (c *openshiftContext) OpenshiftAfterCompleteDrainNode(ctx context.Context,
 		return true, nil
 	}
 
-	// if the operator is running on hypershift variation of openshift there is no machine config operator
-	// just return true here
+	// if the operator is running on hypershift variation of openshift there is no machine config operator,
+	// clear the advisory drain annotation of the node instead
 	if c.IsHypershift() {
-		return true, nil
+		return c.hypershiftAfterCompleteDrainNode(ctx, node)
 	}
 
 	// get the machine pool name for the requested node
@@ -378,6 +378,72 @@ var _ = Describe("Openshift Package", Ordered, func() {
 			})
 		})
 
+		Context("On Hypershift cluster", func() {
+			BeforeEach(func() {
+				infra := &configv1.Infrastructure{}
+				err := k8sClient.Get(context.TODO(), types.NamespacedName{Name: "cluster"}, infra)
+				Expect(err).ToNot(HaveOccurred())
+				infra.Status.ControlPlaneTopology = "External"
+				err = k8sClient.Status().Update(ctx, infra)
+				Expect(err).ToNot(HaveOccurred())
+				DeferCleanup(func() {
+					infra.Status.ControlPlaneTopology = "HighlyAvailable"
+					err = k8sClient.Status().Update(ctx, infra)
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				// recreate to update the topology
+				op, err = openshift.New()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("should set the advisory drain annotation while the node is drained", func() {
+				n := createNode("worker-0")
+				completed, err := op.OpenshiftBeforeDrainNode(ctx, n)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(completed).To(BeTrue())
+
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: n.Name}, n)).ToNot(HaveOccurred())
+				Expect(n.Annotations[constants.NodeDrainAdvisoryAnnotation]).To(Equal(constants.NodeDrainAdvisoryAnnotationDraining))
+
+				completed, err = op.OpenshiftAfterCompleteDrainNode(ctx, n)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(completed).To(BeTrue())
+
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: n.Name}, n)).ToNot(HaveOccurred())
+				Expect(n.Annotations[constants.NodeDrainAdvisoryAnnotation]).To(Equal(constants.NodeDrainAdvisoryAnnotationIdle))
+			})
+
+			It("should return false if the NodePool upgrade is applying a new configuration to the node", func() {
+				n := createNode("worker-0")
+				n.Annotations[mcoconsts.CurrentMachineConfigAnnotationKey] = "config-1"
+				n.Annotations[mcoconsts.DesiredMachineConfigAnnotationKey] = "config-2"
+				Expect(k8sClient.Update(ctx, n)).ToNot(HaveOccurred())
+
+				completed, err := op.OpenshiftBeforeDrainNode(ctx, n)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(completed).To(BeFalse())
+
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: n.Name}, n)).ToNot(HaveOccurred())
+				Expect(n.Annotations).ToNot(HaveKey(constants.NodeDrainAdvisoryAnnotation))
+			})
+
+			It("should clear the advisory drain annotation if the NodePool upgrade is draining the node", func() {
+				n := createNode("worker-0")
+				n.Annotations[constants.NodeDrainAdvisoryAnnotation] = constants.NodeDrainAdvisoryAnnotationDraining
+				n.Annotations[mcoconsts.DesiredDrainerAnnotationKey] = "drain-config-2"
+				n.Annotations[mcoconsts.LastAppliedDrainerAnnotationKey] = "uncordon-config-1"
+				Expect(k8sClient.Update(ctx, n)).ToNot(HaveOccurred())
+
+				completed, err := op.OpenshiftBeforeDrainNode(ctx, n)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(completed).To(BeFalse())
+
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: n.Name}, n)).ToNot(HaveOccurred())
+				Expect(n.Annotations[constants.NodeDrainAdvisoryAnnotation]).To(Equal(constants.NodeDrainAdvisoryAnnotationIdle))
+			})
+		})
+
 		Context("GetNodeMachinePoolName", func() {
 			It("should return error if the cluster is not openshift", func() {
 				vars.ClusterType = constants.ClusterTypeKubernetes
@@ -40,30 +40,30 @@ func (m *MockOpenstackInterface) EXPECT() *MockOpenstackInterfaceMockRecorder {
 	return m.recorder
 }
 
-// CreateOpenstackDevicesInfo mocks base method.
-func (m *MockOpenstackInterface) CreateOpenstackDevicesInfo() error {
+// CreateDevicesInfo mocks base method.
+func (m *MockOpenstackInterface) CreateDevicesInfo() error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateOpenstackDevicesInfo")
+	ret := m.ctrl.Call(m, "CreateDevicesInfo")
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// CreateOpenstackDevicesInfo indicates an expected call of CreateOpenstackDevicesInfo.
-func (mr *MockOpenstackInterfaceMockRecorder) CreateOpenstackDevicesInfo() *gomock.Call {
+// CreateDevicesInfo indicates an expected call of CreateDevicesInfo.
+func (mr *MockOpenstackInterfaceMockRecorder) CreateDevicesInfo() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOpenstackDevicesInfo", reflect.TypeOf((*MockOpenstackInterface)(nil).CreateOpenstackDevicesInfo))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDevicesInfo", reflect.TypeOf((*MockOpenstackInterface)(nil).CreateDevicesInfo))
 }
 
-// CreateOpenstackDevicesInfoFromNodeStatus mocks base method.
-func (m *MockOpenstackInterface) CreateOpenstackDevicesInfoFromNodeStatus(arg0 *v1.SriovNetworkNodeState) {
+// CreateDevicesInfoFromNodeStatus mocks base method.
+func (m *MockOpenstackInterface) CreateDevicesInfoFromNodeStatus(arg0 *v1.SriovNetworkNodeState) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "CreateOpenstackDevicesInfoFromNodeStatus", arg0)
+	m.ctrl.Call(m, "CreateDevicesInfoFromNodeStatus", arg0)
 }
 
-// CreateOpenstackDevicesInfoFromNodeStatus indicates an expected call of CreateOpenstackDevicesInfoFromNodeStatus.
-func (mr *MockOpenstackInterfaceMockRecorder) CreateOpenstackDevicesInfoFromNodeStatus(arg0 any) *gomock.Call {
+// CreateDevicesInfoFromNodeStatus indicates an expected call of CreateDevicesInfoFromNodeStatus.
+func (mr *MockOpenstackInterfaceMockRecorder) CreateDevicesInfoFromNodeStatus(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOpenstackDevicesInfoFromNodeStatus", reflect.TypeOf((*MockOpenstackInterface)(nil).CreateOpenstackDevicesInfoFromNodeStatus), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDevicesInfoFromNodeStatus", reflect.TypeOf((*MockOpenstackInterface)(nil).CreateDevicesInfoFromNodeStatus), arg0)
 }
 
 // DiscoverSriovDevicesVirtual mocks base method.
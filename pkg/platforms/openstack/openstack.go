@@ -9,17 +9,16 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
 	"github.com/jaypipes/ghw"
 	"github.com/jaypipes/ghw/pkg/net"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
-	dputils "github.com/k8snetworkplumbingwg/sriov-network-device-plugin/pkg/utils"
-
 	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
-	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/platforms/internal/virtual"
 )
 
 const (
@@ -40,18 +39,24 @@ const (
 var (
 	ospNetworkDataFile = ospMetaDataDir + "/" + ospNetworkDataJSON
 	ospMetaDataFile    = ospMetaDataDir + "/" + ospMetaDataJSON
+	// unknownDeviceRetryInterval is the time after which the metadata is read again
+	// for a device that was not found in it
+	unknownDeviceRetryInterval = 5 * time.Minute
 )
 
 //go:generate ../../../bin/mockgen -destination mock/mock_openstack.go -source openstack.go
 type OpenstackInterface interface {
-	CreateOpenstackDevicesInfo() error
-	CreateOpenstackDevicesInfoFromNodeStatus(*sriovnetworkv1.SriovNetworkNodeState)
+	CreateDevicesInfo() error
+	CreateDevicesInfoFromNodeStatus(*sriovnetworkv1.SriovNetworkNodeState)
 	DiscoverSriovDevicesVirtual() ([]sriovnetworkv1.InterfaceExt, error)
 }
 
 type openstackContext struct {
 	hostManager          host.HostManagerInterface
 	openStackDevicesInfo OSPDevicesInfo
+	// devices which were not found in the metadata after the refresh and when,
+	// used to avoid reading the metadata on each discovery
+	unknownDevices map[string]time.Time
 }
 
 // OSPMetaDataDevice -- Device structure within meta_data.json
@@ -91,6 +96,8 @@ type OSPNetwork struct {
 	Type      string `json:"type"`
 	Link      string `json:"link"`
 	NetworkID string `json:"network_id"`
+	// PhysicalNetwork is set only if the deployment exposes the physnet of the network in the network_data
+	PhysicalNetwork string `json:"physical_network,omitempty"`
 }
 
 // OSPNetworkData OSP Network metadata
@@ -105,6 +112,37 @@ type OSPDevicesInfo map[string]*OSPDeviceInfo
 type OSPDeviceInfo struct {
 	MacAddress string
 	NetworkID  string
+	// additional netFilter keys of the device: tags, physnet, VLAN and trusted mode
+	NetFilterTags []string
+	Vlan          int
+	Trusted       bool
+}
+
+// newOSPDeviceInfo returns the device info for the device attached to the network,
+// device is nil if the device is not described in the meta_data
+func newOSPDeviceInfo(macAddress string, device *OSPMetaDataDevice, network *OSPNetwork) *OSPDeviceInfo {
+	info := &OSPDeviceInfo{
+		MacAddress: macAddress,
+		NetworkID:  sriovnetworkv1.OpenstackNetworkID.String() + ":" + network.NetworkID,
+	}
+	if network.PhysicalNetwork != "" {
+		info.NetFilterTags = append(info.NetFilterTags, sriovnetworkv1.OpenstackPhysnet.String()+":"+network.PhysicalNetwork)
+	}
+	if device == nil {
+		return info
+	}
+	for _, tag := range device.Tags {
+		info.NetFilterTags = append(info.NetFilterTags, sriovnetworkv1.OpenstackTag.String()+":"+tag)
+	}
+	if device.Vlan > 0 {
+		info.Vlan = device.Vlan
+		info.NetFilterTags = append(info.NetFilterTags, sriovnetworkv1.OpenstackVlan.String()+":"+strconv.Itoa(device.Vlan))
+	}
+	if device.VfTrusted {
+		info.Trusted = true
+		info.NetFilterTags = append(info.NetFilterTags, sriovnetworkv1.OpenstackTrusted.String()+":true")
+	}
+	return info
 }
 
 func New(hostManager host.HostManagerInterface) OpenstackInterface {
@@ -123,7 +161,21 @@ func getOpenstackData(mountConfigDrive bool) (metaData *OSPMetaData, networkData
 			return metaData, networkData, fmt.Errorf("GetOpenStackData(): error getting OpenStack data: %w", err)
 		}
 	}
+	return metaData, networkData, updatePCIAddresses(metaData)
+}
+
+// getOpenstackDataFromMetadataServiceOnly gets the metadata and network_data from the metadata service,
+// the config drive is written when the instance is created and doesn't describe the hot-plugged ports
+func getOpenstackDataFromMetadataServiceOnly() (*OSPMetaData, *OSPNetworkData, error) {
+	metaData, networkData, err := getOpenstackDataFromMetadataService()
+	if err != nil {
+		return metaData, networkData, fmt.Errorf("GetOpenStackData(): error getting OpenStack data: %w", err)
+	}
+	return metaData, networkData, updatePCIAddresses(metaData)
+}
 
+// updatePCIAddresses replaces the PCI address of the devices in the metadata with the real PCI address
+func updatePCIAddresses(metaData *OSPMetaData) error {
 	// We can't rely on the PCI address from the metadata so we will lookup the real PCI address
 	// for the NIC that matches the MAC address.
 	//
@@ -135,7 +187,7 @@ func getOpenstackData(mountConfigDrive bool) (metaData *OSPMetaData, networkData
 	// we will lookup the real PCI address for the NIC that matches the MAC address.
 	netInfo, err := ghw.Network()
 	if err != nil {
-		return metaData, networkData, fmt.Errorf("GetOpenStackData(): error getting network info: %w", err)
+		return fmt.Errorf("GetOpenStackData(): error getting network info: %w", err)
 	}
 	for i, device := range metaData.Devices {
 		realPCIAddr, err := getPCIAddressFromMACAddress(device.Mac, netInfo.NICs)
@@ -145,7 +197,7 @@ func getOpenstackData(mountConfigDrive bool) (metaData *OSPMetaData, networkData
 			// allocated devices already.
 			log.Log.Error(err, "Warning GetOpenstackData(): error getting PCI address for device",
 				"device-mac", device.Mac)
-			return metaData, networkData, nil
+			return nil
 		}
 		if realPCIAddr != device.Address {
 			log.Log.V(2).Info("GetOpenstackData(): PCI address for device does not match Nova metadata value, it'll be overwritten",
@@ -155,8 +207,7 @@ func getOpenstackData(mountConfigDrive bool) (metaData *OSPMetaData, networkData
 			metaData.Devices[i].Address = realPCIAddr
 		}
 	}
-
-	return metaData, networkData, err
+	return nil
 }
 
 // getConfigDriveDevice returns the config drive device which was found
@@ -326,16 +377,31 @@ func getPCIAddressFromMACAddress(macAddress string, nics []*net.NIC) (string, er
 	return "", fmt.Errorf("no device found with MAC address %s", macAddress)
 }
 
-// CreateOpenstackDevicesInfo create the openstack device info map
-func (o *openstackContext) CreateOpenstackDevicesInfo() error {
-	log.Log.Info("CreateOpenstackDevicesInfo()")
-	devicesInfo := make(OSPDevicesInfo)
-
+// CreateDevicesInfo create the openstack device info map
+func (o *openstackContext) CreateDevicesInfo() error {
+	log.Log.Info("CreateDevicesInfo()")
 	metaData, networkData, err := getOpenstackData(true)
 	if err != nil {
 		log.Log.Error(err, "failed to read OpenStack data")
 		return err
 	}
+	return o.createDevicesInfo(metaData, networkData)
+}
+
+// refreshDevicesInfo re-creates the openstack device info map from the metadata service,
+// it's used to find the hot-plugged ports which are not in the config drive
+func (o *openstackContext) refreshDevicesInfo() error {
+	log.Log.Info("refreshDevicesInfo()")
+	metaData, networkData, err := getOpenstackDataFromMetadataServiceOnly()
+	if err != nil {
+		log.Log.Error(err, "failed to read OpenStack data from the metadata service")
+		return err
+	}
+	return o.createDevicesInfo(metaData, networkData)
+}
+
+func (o *openstackContext) createDevicesInfo(metaData *OSPMetaData, networkData *OSPNetworkData) error {
+	devicesInfo := make(OSPDevicesInfo)
 
 	if metaData == nil || networkData == nil {
 		o.openStackDevicesInfo = make(OSPDevicesInfo)
@@ -343,13 +409,12 @@ func (o *openstackContext) CreateOpenstackDevicesInfo() error {
 	}
 
 	// use this for hw pass throw interfaces
-	for _, device := range metaData.Devices {
+	for i, device := range metaData.Devices {
 		for _, link := range networkData.Links {
 			if device.Mac == link.EthernetMac {
-				for _, network := range networkData.Networks {
+				for j, network := range networkData.Networks {
 					if network.Link == link.ID {
-						networkID := sriovnetworkv1.OpenstackNetworkID.String() + ":" + network.NetworkID
-						devicesInfo[device.Address] = &OSPDeviceInfo{MacAddress: device.Mac, NetworkID: networkID}
+						devicesInfo[device.Address] = newOSPDeviceInfo(device.Mac, &metaData.Devices[i], &networkData.Networks[j])
 					}
 				}
 			}
@@ -357,14 +422,9 @@ func (o *openstackContext) CreateOpenstackDevicesInfo() error {
 	}
 
 	// for vhostuser interface type we check the interfaces on the node
-	pci, err := ghw.PCI()
+	devices, err := virtual.GetNetDevices()
 	if err != nil {
-		return fmt.Errorf("CreateOpenstackDevicesInfo(): error getting PCI info: %v", err)
-	}
-
-	devices := pci.Devices
-	if len(devices) == 0 {
-		return fmt.Errorf("CreateOpenstackDevicesInfo(): could not retrieve PCI devices")
+		return fmt.Errorf("CreateDevicesInfo(): %v", err)
 	}
 
 	for _, device := range devices {
@@ -373,23 +433,7 @@ func (o *openstackContext) CreateOpenstackDevicesInfo() error {
 			continue
 		}
 
-		devClass, err := strconv.ParseInt(device.Class.ID, 16, 64)
-		if err != nil {
-			log.Log.Error(err, "CreateOpenstackDevicesInfo(): unable to parse device class for device, skipping",
-				"device", device)
-			continue
-		}
-		if devClass != consts.NetClass {
-			// Not network device
-			continue
-		}
-
-		macAddress := ""
-		if name := o.hostManager.TryToGetVirtualInterfaceName(device.Address); name != "" {
-			if mac := o.hostManager.GetNetDevMac(name); mac != "" {
-				macAddress = mac
-			}
-		}
+		macAddress := virtual.GetDeviceMac(o.hostManager, device.Address)
 		if macAddress == "" {
 			// we didn't manage to find a mac address for the nic skipping
 			continue
@@ -397,10 +441,9 @@ func (o *openstackContext) CreateOpenstackDevicesInfo() error {
 
 		for _, link := range networkData.Links {
 			if macAddress == link.EthernetMac {
-				for _, network := range networkData.Networks {
+				for j, network := range networkData.Networks {
 					if network.Link == link.ID {
-						networkID := sriovnetworkv1.OpenstackNetworkID.String() + ":" + network.NetworkID
-						devicesInfo[device.Address] = &OSPDeviceInfo{MacAddress: macAddress, NetworkID: networkID}
+						devicesInfo[device.Address] = newOSPDeviceInfo(macAddress, nil, &networkData.Networks[j])
 					}
 				}
 			}
@@ -411,90 +454,80 @@ func (o *openstackContext) CreateOpenstackDevicesInfo() error {
 	return nil
 }
 
+// isUnknownDevice returns true if the device was not found in the metadata
+// during the last unknownDeviceRetryInterval, the expired entries are removed
+func (o *openstackContext) isUnknownDevice(pciAddr string) bool {
+	for addr, since := range o.unknownDevices {
+		if time.Since(since) >= unknownDeviceRetryInterval {
+			delete(o.unknownDevices, addr)
+		}
+	}
+	_, exist := o.unknownDevices[pciAddr]
+	return exist
+}
+
 // DiscoverSriovDevicesVirtual discovers VFs on a virtual platform
 func (o *openstackContext) DiscoverSriovDevicesVirtual() ([]sriovnetworkv1.InterfaceExt, error) {
 	log.Log.V(2).Info("DiscoverSriovDevicesVirtual()")
 	pfList := []sriovnetworkv1.InterfaceExt{}
 
-	pci, err := ghw.PCI()
+	devices, err := virtual.GetNetDevices()
 	if err != nil {
-		return nil, fmt.Errorf("DiscoverSriovDevicesVirtual(): error getting PCI info: %v", err)
-	}
-
-	devices := pci.Devices
-	if len(devices) == 0 {
-		return nil, fmt.Errorf("DiscoverSriovDevicesVirtual(): could not retrieve PCI devices")
+		return nil, fmt.Errorf("DiscoverSriovDevicesVirtual(): %v", err)
 	}
 
+	// the metadata is re-read once if a device was hot-plugged after the devices info was created
+	refreshed, refreshFailed := false, false
 	for _, device := range devices {
-		devClass, err := strconv.ParseInt(device.Class.ID, 16, 64)
-		if err != nil {
-			log.Log.Error(err, "DiscoverSriovDevicesVirtual(): unable to parse device class for device, skipping",
-				"device", device)
-			continue
-		}
-		if devClass != consts.NetClass {
-			// Not network device
-			continue
-		}
-
 		deviceInfo, exist := o.openStackDevicesInfo[device.Address]
+		if !exist && !refreshed && !o.isUnknownDevice(device.Address) {
+			log.Log.Info("DiscoverSriovDevicesVirtual(): device not found in devicesInfo list, refresh OpenStack data",
+				"device", device.Address)
+			refreshed = true
+			if err := o.refreshDevicesInfo(); err != nil {
+				log.Log.Error(err, "DiscoverSriovDevicesVirtual(): failed to refresh OpenStack data")
+				refreshFailed = true
+			}
+			deviceInfo, exist = o.openStackDevicesInfo[device.Address]
+		}
 		if !exist {
+			if refreshed && !refreshFailed {
+				if o.unknownDevices == nil {
+					o.unknownDevices = map[string]time.Time{}
+				}
+				o.unknownDevices[device.Address] = time.Now()
+			}
 			log.Log.Error(nil, "DiscoverSriovDevicesVirtual(): unable to find device in devicesInfo list, skipping",
 				"device", device.Address)
 			continue
 		}
-		netFilter := deviceInfo.NetworkID
-		metaMac := deviceInfo.MacAddress
-
-		driver, err := dputils.GetDriverName(device.Address)
+		iface, err := virtual.NewInterfaceExt(o.hostManager, device, deviceInfo.NetworkID, deviceInfo.MacAddress)
 		if err != nil {
-			log.Log.Error(err, "DiscoverSriovDevicesVirtual(): unable to parse device driver for device, skipping",
+			log.Log.Error(err, "DiscoverSriovDevicesVirtual(): failed to discover device, skipping",
 				"device", device)
 			continue
 		}
-		iface := sriovnetworkv1.InterfaceExt{
-			PciAddress: device.Address,
-			Driver:     driver,
-			Vendor:     device.Vendor.ID,
-			DeviceID:   device.Product.ID,
-			NetFilter:  netFilter,
-		}
-		if mtu := o.hostManager.GetNetdevMTU(device.Address); mtu > 0 {
-			iface.Mtu = mtu
-		}
-		if name := o.hostManager.TryToGetVirtualInterfaceName(device.Address); name != "" {
-			iface.Name = name
-			if iface.Mac = o.hostManager.GetNetDevMac(name); iface.Mac == "" {
-				iface.Mac = metaMac
-			}
-			iface.LinkSpeed = o.hostManager.GetNetDevLinkSpeed(name)
-			iface.LinkType = o.hostManager.GetLinkType(name)
-		}
-
-		iface.TotalVfs = 1
-		iface.NumVfs = 1
-
-		vf := sriovnetworkv1.VirtualFunction{
-			PciAddress: device.Address,
-			Driver:     driver,
-			VfID:       0,
-			Vendor:     iface.Vendor,
-			DeviceID:   iface.DeviceID,
-			Mtu:        iface.Mtu,
-			Mac:        iface.Mac,
-		}
-		iface.VFs = append(iface.VFs, vf)
+		iface.NetFilterTags = deviceInfo.NetFilterTags
+		iface.Vlan = deviceInfo.Vlan
+		iface.Trusted = deviceInfo.Trusted
+		iface.VFs[0].Vlan = deviceInfo.Vlan
 
-		pfList = append(pfList, iface)
+		pfList = append(pfList, *iface)
 	}
 	return pfList, nil
 }
 
-func (o *openstackContext) CreateOpenstackDevicesInfoFromNodeStatus(networkState *sriovnetworkv1.SriovNetworkNodeState) {
+func (o *openstackContext) CreateDevicesInfoFromNodeStatus(networkState *sriovnetworkv1.SriovNetworkNodeState) {
 	devicesInfo := make(OSPDevicesInfo)
 	for _, iface := range networkState.Status.Interfaces {
-		devicesInfo[iface.PciAddress] = &OSPDeviceInfo{MacAddress: iface.Mac, NetworkID: iface.NetFilter}
+		deviceInfo := &OSPDeviceInfo{
+			MacAddress:    iface.Mac,
+			NetworkID:     iface.NetFilter,
+			NetFilterTags: iface.NetFilterTags,
+			Vlan:          iface.Vlan,
+			Trusted:       iface.Trusted,
+		}
+		devicesInfo[iface.PciAddress] = deviceInfo
 	}
 
 	o.openStackDevicesInfo = devicesInfo
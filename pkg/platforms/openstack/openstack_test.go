@@ -2,6 +2,7 @@ package openstack
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -11,6 +12,8 @@ import (
 	"github.com/jaypipes/ghw"
 	"github.com/jaypipes/ghw/pkg/net"
 	"github.com/jaypipes/ghw/pkg/option"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 )
 
 func TestUtilsVirtual(t *testing.T) {
@@ -56,4 +59,61 @@ var _ = Describe("Virtual", func() {
 
 		})
 	})
+
+	Context("newOSPDeviceInfo", func() {
+		It("exposes tags, physnet, VLAN and trusted mode as netFilter keys", func() {
+			info := newOSPDeviceInfo("fa:16:3e:00:00:00",
+				&OSPMetaDataDevice{Vlan: 177, VfTrusted: true, Tags: []string{"dpdk0"}},
+				&OSPNetwork{NetworkID: "5765e37b-0a13-49d2-a598-537178ce254f", PhysicalNetwork: "physnet1"})
+			Expect(info.NetworkID).To(Equal("openstack/NetworkID:5765e37b-0a13-49d2-a598-537178ce254f"))
+			Expect(info.Vlan).To(Equal(177))
+			Expect(info.Trusted).To(BeTrue())
+			Expect(info.NetFilterTags).To(Equal([]string{
+				"openstack/Physnet:physnet1", "openstack/Tag:dpdk0", "openstack/Vlan:177", "openstack/Trusted:true"}))
+		})
+		It("device without metadata", func() {
+			info := newOSPDeviceInfo("fa:16:3e:00:00:00", nil, &OSPNetwork{NetworkID: "5765e37b-0a13-49d2-a598-537178ce254f"})
+			Expect(info.NetFilterTags).To(BeEmpty())
+			Expect(info.Vlan).To(BeZero())
+			Expect(info.Trusted).To(BeFalse())
+		})
+	})
+
+	Context("CreateDevicesInfoFromNodeStatus", func() {
+		It("restores the VLAN and the trusted mode of the devices", func() {
+			o := &openstackContext{}
+			o.CreateDevicesInfoFromNodeStatus(&sriovnetworkv1.SriovNetworkNodeState{
+				Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+					Interfaces: sriovnetworkv1.InterfaceExts{{
+						PciAddress:    "0000:04:00.0",
+						Mac:           "fa:16:3e:00:00:00",
+						NetFilter:     "openstack/NetworkID:5765e37b-0a13-49d2-a598-537178ce254f",
+						NetFilterTags: []string{"openstack/Vlan:177", "openstack/Trusted:true"},
+						Vlan:          177,
+						Trusted:       true,
+					}},
+				},
+			})
+			Expect(o.openStackDevicesInfo).To(HaveKeyWithValue("0000:04:00.0", &OSPDeviceInfo{
+				MacAddress:    "fa:16:3e:00:00:00",
+				NetworkID:     "openstack/NetworkID:5765e37b-0a13-49d2-a598-537178ce254f",
+				NetFilterTags: []string{"openstack/Vlan:177", "openstack/Trusted:true"},
+				Vlan:          177,
+				Trusted:       true,
+			}))
+		})
+	})
+
+	Context("isUnknownDevice", func() {
+		It("retries the unknown devices after the retry interval", func() {
+			o := &openstackContext{unknownDevices: map[string]time.Time{
+				"0000:04:00.0": time.Now(),
+				"0000:05:00.0": time.Now().Add(-unknownDeviceRetryInterval),
+			}}
+			Expect(o.isUnknownDevice("0000:04:00.0")).To(BeTrue())
+			Expect(o.isUnknownDevice("0000:05:00.0")).To(BeFalse())
+			Expect(o.isUnknownDevice("0000:06:00.0")).To(BeFalse())
+			Expect(o.unknownDevices).To(HaveLen(1))
+		})
+	})
 })
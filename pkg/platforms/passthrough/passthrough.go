@@ -0,0 +1,180 @@
+package passthrough
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/platforms/internal/virtual"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils"
+)
+
+const (
+	// local file on the host with the metadata of the passthrough devices
+	localMetaDataFile = "/etc/sriov-network-operator/virtual-devices.json"
+)
+
+var (
+	// meta-data files of the cloud-init NoCloud datasource
+	noCloudMetaDataFiles = []string{
+		"/var/lib/cloud/seed/nocloud/meta-data",
+		"/var/lib/cloud/seed/nocloud-net/meta-data",
+	}
+)
+
+//go:generate ../../../bin/mockgen -destination mock/mock_passthrough.go -source passthrough.go
+type PassthroughInterface interface {
+	CreateDevicesInfo() error
+	CreateDevicesInfoFromNodeStatus(*sriovnetworkv1.SriovNetworkNodeState)
+	DiscoverSriovDevicesVirtual() ([]sriovnetworkv1.InterfaceExt, error)
+}
+
+type passthroughContext struct {
+	hostManager host.HostManagerInterface
+	devicesInfo DevicesInfo
+}
+
+// DeviceMetaData describes the VF passed into the virtual machine
+type DeviceMetaData struct {
+	// MAC address of the VF, the device is matched by the MAC address if set
+	Mac string `json:"mac,omitempty" yaml:"mac,omitempty"`
+	// PCI address of the VF in the virtual machine, used when the MAC address is not set
+	Address string `json:"address,omitempty" yaml:"address,omitempty"`
+	// Name of the network the VF is attached to, exposed as the "generic/Network:<name>" netFilter
+	Network string `json:"network" yaml:"network"`
+}
+
+// MetaData is the format of the local metadata file
+type MetaData struct {
+	Devices []DeviceMetaData `json:"devices,omitempty" yaml:"devices,omitempty"`
+}
+
+// noCloudMetaData contains the devices from the "sriov-devices" key of the NoCloud meta-data
+type noCloudMetaData struct {
+	SriovDevices []DeviceMetaData `yaml:"sriov-devices,omitempty"`
+}
+
+type DevicesInfo map[string]*DeviceInfo
+
+type DeviceInfo struct {
+	MacAddress string
+	NetworkID  string
+}
+
+func New(hostManager host.HostManagerInterface) PassthroughInterface {
+	return &passthroughContext{
+		hostManager: hostManager,
+	}
+}
+
+// getMetaData reads the metadata from the local file,
+// the NoCloud datasource is used if the local file doesn't exist
+func getMetaData() (*MetaData, error) {
+	localFile := utils.GetHostExtensionPath(localMetaDataFile)
+	data, err := os.ReadFile(localFile)
+	if err == nil {
+		log.Log.Info("reading devices metadata from local file", "path", localFile)
+		metaData := &MetaData{}
+		if err := json.Unmarshal(data, metaData); err != nil {
+			return nil, fmt.Errorf("error unmarshalling metadata from file %s: %w", localFile, err)
+		}
+		return metaData, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("error reading file %s: %w", localFile, err)
+	}
+	for _, f := range noCloudMetaDataFiles {
+		noCloudFile := utils.GetHostExtensionPath(f)
+		data, err := os.ReadFile(noCloudFile)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, fmt.Errorf("error reading file %s: %w", noCloudFile, err)
+		}
+		log.Log.Info("reading devices metadata from NoCloud datasource", "path", noCloudFile)
+		noCloud := &noCloudMetaData{}
+		if err := yaml.Unmarshal(data, noCloud); err != nil {
+			return nil, fmt.Errorf("error unmarshalling metadata from file %s: %w", noCloudFile, err)
+		}
+		return &MetaData{Devices: noCloud.SriovDevices}, nil
+	}
+	log.Log.Info("no devices metadata found for the virtual machine")
+	return &MetaData{}, nil
+}
+
+// CreateDevicesInfo create the device info map from the metadata
+func (p *passthroughContext) CreateDevicesInfo() error {
+	log.Log.Info("CreateDevicesInfo()")
+	devicesInfo := make(DevicesInfo)
+
+	metaData, err := getMetaData()
+	if err != nil {
+		log.Log.Error(err, "failed to read devices metadata")
+		return err
+	}
+
+	devices, err := virtual.GetNetDevices()
+	if err != nil {
+		return fmt.Errorf("CreateDevicesInfo(): %v", err)
+	}
+
+	for _, device := range devices {
+		macAddress := virtual.GetDeviceMac(p.hostManager, device.Address)
+		for _, meta := range metaData.Devices {
+			if (meta.Mac != "" && strings.EqualFold(meta.Mac, macAddress)) ||
+				(meta.Mac == "" && meta.Address == device.Address) {
+				networkID := sriovnetworkv1.GenericNetworkName.String() + ":" + meta.Network
+				devicesInfo[device.Address] = &DeviceInfo{MacAddress: macAddress, NetworkID: networkID}
+				break
+			}
+		}
+	}
+
+	p.devicesInfo = devicesInfo
+	return nil
+}
+
+// DiscoverSriovDevicesVirtual discovers VFs passed into the virtual machine
+func (p *passthroughContext) DiscoverSriovDevicesVirtual() ([]sriovnetworkv1.InterfaceExt, error) {
+	log.Log.V(2).Info("DiscoverSriovDevicesVirtual()")
+	pfList := []sriovnetworkv1.InterfaceExt{}
+
+	devices, err := virtual.GetNetDevices()
+	if err != nil {
+		return nil, fmt.Errorf("DiscoverSriovDevicesVirtual(): %v", err)
+	}
+
+	for _, device := range devices {
+		deviceInfo, exist := p.devicesInfo[device.Address]
+		if !exist {
+			// device is not described in the metadata
+			continue
+		}
+
+		iface, err := virtual.NewInterfaceExt(p.hostManager, device, deviceInfo.NetworkID, deviceInfo.MacAddress)
+		if err != nil {
+			log.Log.Error(err, "DiscoverSriovDevicesVirtual(): failed to discover device, skipping",
+				"device", device)
+			continue
+		}
+		pfList = append(pfList, *iface)
+	}
+	return pfList, nil
+}
+
+func (p *passthroughContext) CreateDevicesInfoFromNodeStatus(networkState *sriovnetworkv1.SriovNetworkNodeState) {
+	devicesInfo := make(DevicesInfo)
+	for _, iface := range networkState.Status.Interfaces {
+		devicesInfo[iface.PciAddress] = &DeviceInfo{MacAddress: iface.Mac, NetworkID: iface.NetFilter}
+	}
+
+	p.devicesInfo = devicesInfo
+}
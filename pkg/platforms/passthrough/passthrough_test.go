@@ -0,0 +1,95 @@
+package passthrough
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/test/util/fakefilesystem"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/test/util/helpers"
+)
+
+func TestPassthrough(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Passthrough")
+}
+
+var _ = Describe("Passthrough", func() {
+	BeforeEach(func() {
+		origInChroot := vars.InChroot
+		vars.InChroot = true
+		DeferCleanup(func() {
+			vars.InChroot = origInChroot
+		})
+	})
+
+	Context("getMetaData", func() {
+		It("no metadata", func() {
+			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{})
+			metaData, err := getMetaData()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(metaData.Devices).To(BeEmpty())
+		})
+		It("local file", func() {
+			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+				Dirs: []string{"/etc/sriov-network-operator", "/var/lib/cloud/seed/nocloud"},
+				Files: map[string][]byte{
+					"/etc/sriov-network-operator/virtual-devices.json": []byte(
+						`{"devices": [{"mac": "52:54:00:00:00:01", "network": "net1"}, {"address": "0000:07:00.0", "network": "net2"}]}`),
+					"/var/lib/cloud/seed/nocloud/meta-data": []byte("instance-id: vm1\n"),
+				},
+			})
+			metaData, err := getMetaData()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(metaData.Devices).To(Equal([]DeviceMetaData{
+				{Mac: "52:54:00:00:00:01", Network: "net1"},
+				{Address: "0000:07:00.0", Network: "net2"},
+			}))
+		})
+		It("NoCloud datasource", func() {
+			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+				Dirs: []string{"/var/lib/cloud/seed/nocloud-net"},
+				Files: map[string][]byte{
+					"/var/lib/cloud/seed/nocloud-net/meta-data": []byte(`instance-id: vm1
+local-hostname: worker-0
+sriov-devices:
+- mac: 52:54:00:00:00:01
+  network: net1
+`),
+				},
+			})
+			metaData, err := getMetaData()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(metaData.Devices).To(Equal([]DeviceMetaData{{Mac: "52:54:00:00:00:01", Network: "net1"}}))
+		})
+		It("invalid local file", func() {
+			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+				Dirs:  []string{"/etc/sriov-network-operator"},
+				Files: map[string][]byte{"/etc/sriov-network-operator/virtual-devices.json": []byte("{")},
+			})
+			_, err := getMetaData()
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("CreateDevicesInfoFromNodeStatus", func() {
+		It("restores devices info", func() {
+			p := &passthroughContext{}
+			p.CreateDevicesInfoFromNodeStatus(&sriovnetworkv1.SriovNetworkNodeState{
+				Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+					Interfaces: sriovnetworkv1.InterfaceExts{{
+						PciAddress: "0000:07:00.0",
+						Mac:        "52:54:00:00:00:01",
+						NetFilter:  "generic/Network:net1",
+					}},
+				},
+			})
+			Expect(p.devicesInfo).To(Equal(DevicesInfo{
+				"0000:07:00.0": {MacAddress: "52:54:00:00:00:01", NetworkID: "generic/Network:net1"},
+			}))
+		})
+	})
+})
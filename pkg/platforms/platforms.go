@@ -1,23 +1,44 @@
 package platforms
 
 import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/platforms/lifecycle"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/platforms/openshift"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/platforms/openstack"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/platforms/passthrough"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
 )
 
 //go:generate ../../bin/mockgen -destination mock/mock_platforms.go -source platforms.go
 type Interface interface {
 	openshift.OpenshiftContextInterface
-	openstack.OpenstackInterface
+	VirtualPlatformInterface
+	lifecycle.NodeLifecycleCoordinatorInterface
+}
+
+// VirtualPlatformInterface is implemented by the providers of the devices information for the virtual platforms,
+// the provider for the current platform is selected by vars.PlatformType
+type VirtualPlatformInterface interface {
+	// CreateDevicesInfo reads the devices information from the platform metadata
+	CreateDevicesInfo() error
+	// CreateDevicesInfoFromNodeStatus restores the devices information from the status of the node state
+	CreateDevicesInfoFromNodeStatus(*sriovnetworkv1.SriovNetworkNodeState)
+	// DiscoverSriovDevicesVirtual discovers VFs on the virtual platform
+	DiscoverSriovDevicesVirtual() ([]sriovnetworkv1.InterfaceExt, error)
 }
 
 type platformHelper struct {
 	openshift.OpenshiftContextInterface
-	openstack.OpenstackInterface
+	lifecycle.NodeLifecycleCoordinatorInterface
+	virtualPlatforms map[consts.PlatformTypes]VirtualPlatformInterface
 }
 
 func NewDefaultPlatformHelper() (Interface, error) {
@@ -31,10 +52,61 @@ func NewDefaultPlatformHelper() (Interface, error) {
 		log.Log.Error(err, "failed to create host manager")
 		return nil, err
 	}
-	openstackContext := openstack.New(hostManager)
+
+	// the node lifecycle is coordinated only when the kubernetes API is available,
+	// e.g. the config daemon running as a systemd service doesn't drain nodes
+	lifecycleCoordinator := lifecycle.NewWithCoordinators()
+	if vars.Config != nil {
+		kubeClient, err := client.New(vars.Config, client.Options{Scheme: vars.Scheme})
+		if err != nil {
+			return nil, err
+		}
+		lifecycleCoordinator, err = lifecycle.New(kubeClient)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	return &platformHelper{
-		openshiftContext,
-		openstackContext,
+		OpenshiftContextInterface:         openshiftContext,
+		NodeLifecycleCoordinatorInterface: lifecycleCoordinator,
+		virtualPlatforms: map[consts.PlatformTypes]VirtualPlatformInterface{
+			consts.VirtualOpenStack: openstack.New(hostManager),
+			consts.VirtualGeneric:   passthrough.New(hostManager),
+		},
 	}, nil
 }
+
+// getVirtualPlatform returns the provider for the current platform
+func (p *platformHelper) getVirtualPlatform() (VirtualPlatformInterface, error) {
+	provider, exist := p.virtualPlatforms[vars.PlatformType]
+	if !exist {
+		return nil, fmt.Errorf("no virtual platform provider for platform %s", vars.PlatformType)
+	}
+	return provider, nil
+}
+
+func (p *platformHelper) CreateDevicesInfo() error {
+	provider, err := p.getVirtualPlatform()
+	if err != nil {
+		return err
+	}
+	return provider.CreateDevicesInfo()
+}
+
+func (p *platformHelper) CreateDevicesInfoFromNodeStatus(networkState *sriovnetworkv1.SriovNetworkNodeState) {
+	provider, err := p.getVirtualPlatform()
+	if err != nil {
+		log.Log.Error(err, "CreateDevicesInfoFromNodeStatus(): failed to get virtual platform provider")
+		return
+	}
+	provider.CreateDevicesInfoFromNodeStatus(networkState)
+}
+
+func (p *platformHelper) DiscoverSriovDevicesVirtual() ([]sriovnetworkv1.InterfaceExt, error) {
+	provider, err := p.getVirtualPlatform()
+	if err != nil {
+		return nil, err
+	}
+	return provider.DiscoverSriovDevicesVirtual()
+}
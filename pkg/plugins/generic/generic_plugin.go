@@ -3,6 +3,10 @@ package generic
 import (
 	"errors"
 	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
 	"syscall"
 
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -10,6 +14,7 @@ import (
 	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/helper"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/store"
 	hostTypes "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/types"
 	plugin "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/plugins"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils"
@@ -23,13 +28,23 @@ const (
 	Vfio = iota
 	VirtioVdpa
 	VhostVdpa
+	UioPciGeneric
+	IgbUio
 )
 
 // driver name
 const (
-	vfioPciDriver    = "vfio_pci"
-	virtioVdpaDriver = "virtio_vdpa"
-	vhostVdpaDriver  = "vhost_vdpa"
+	vfioPciDriver       = "vfio_pci"
+	virtioVdpaDriver    = "virtio_vdpa"
+	vhostVdpaDriver     = "vhost_vdpa"
+	uioPciGenericDriver = "uio_pci_generic"
+	igbUioDriver        = "igb_uio"
+)
+
+// vfio module and the parameter to enable the no-IOMMU mode
+const (
+	vfioModule           = "vfio"
+	vfioNoIommuModeParam = "enable_unsafe_noiommu_mode=1"
 )
 
 // function type for determining if a given driver has to be loaded in the kernel
@@ -47,6 +62,16 @@ type DriverStateMapType map[uint]*DriverState
 
 type KargStateMapType map[string]bool
 
+// builtinKernelArgs contains the kernel arguments the plugin configures based on the node state,
+// they are not removed when they are dropped from the additional kernel arguments of the SriovNetworkPoolConfig
+var builtinKernelArgs = []string{
+	consts.KernelArgPciRealloc,
+	consts.KernelArgIntelIommu,
+	consts.KernelArgIommuPt,
+	consts.KernelArgRdmaShared,
+	consts.KernelArgRdmaExclusive,
+}
+
 type GenericPlugin struct {
 	PluginName              string
 	DesireState             *sriovnetworkv1.SriovNetworkNodeState
@@ -55,6 +80,8 @@ type GenericPlugin struct {
 	helpers                 helper.HostHelpersInterface
 	skipVFConfiguration     bool
 	skipBridgeConfiguration bool
+	// hugepages to allocate at runtime by Apply
+	desiredHugepages *store.ManagedHugepages
 }
 
 type Option = func(c *genericPluginOptions)
@@ -110,6 +137,20 @@ func NewGenericPlugin(helpers helper.HostHelpersInterface, options ...Option) (p
 		NeedDriverFunc: needDriverCheckVdpaType,
 		DriverLoaded:   false,
 	}
+	driverStateMap[UioPciGeneric] = &DriverState{
+		DriverName:     uioPciGenericDriver,
+		DeviceType:     consts.DeviceTypeUioPciGeneric,
+		VdpaType:       "",
+		NeedDriverFunc: needDriverCheckDeviceType,
+		DriverLoaded:   false,
+	}
+	driverStateMap[IgbUio] = &DriverState{
+		DriverName:     igbUioDriver,
+		DeviceType:     consts.DeviceTypeIgbUio,
+		VdpaType:       "",
+		NeedDriverFunc: needDriverCheckDeviceType,
+		DriverLoaded:   false,
+	}
 
 	// To maintain backward compatibility we don't remove the intel_iommu, iommu and pcirealloc
 	// kernel args if they are configured
@@ -186,6 +227,21 @@ func (p *GenericPlugin) CheckStatusChanges(current *sriovnetworkv1.SriovNetworkN
 		}
 	}
 
+	if p.needToUpdateOVSHwOffload(current.Spec, current.Status) {
+		log.Log.Info("CheckStatusChanges(): OVS hardware offload configuration needs to be updated")
+		return true, nil
+	}
+
+	hugepagesChanged, err := p.needToUpdateHugepages()
+	if err != nil {
+		log.Log.Error(err, "generic-plugin CheckStatusChanges(): failed to verify the allocated hugepages")
+		return false, err
+	}
+	if hugepagesChanged {
+		log.Log.Info("CheckStatusChanges(): hugepages need to be allocated")
+		return true, nil
+	}
+
 	shouldUpdate, err := p.shouldUpdateKernelArgs()
 	if err != nil {
 		log.Log.Error(err, "generic-plugin CheckStatusChanges(): failed to verify missing kernel arguments")
@@ -217,6 +273,10 @@ func (p *GenericPlugin) Apply() error {
 		return err
 	}
 
+	if err := p.applyHugepages(); err != nil {
+		return err
+	}
+
 	// When calling from systemd do not try to chroot
 	if !vars.UsingSystemdMode {
 		exit, err := p.helpers.Chroot(consts.Host)
@@ -241,9 +301,26 @@ func (p *GenericPlugin) Apply() error {
 		}
 	}
 
+	if p.needToUpdateOVSHwOffload(p.DesireState.Spec, p.DesireState.Status) {
+		if err := p.helpers.ConfigureOVSHwOffload(p.DesireState.Spec.System.OvsHardwareOffload); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// needToUpdateOVSHwOffload returns true if the OVS hardware offload settings reported in the status
+// don't match the settings requested by the SriovNetworkPoolConfig.
+// OVS is not available in the pre phase of the systemd mode, the settings are applied in the post phase.
+func (p *GenericPlugin) needToUpdateOVSHwOffload(desired sriovnetworkv1.SriovNetworkNodeStateSpec,
+	current sriovnetworkv1.SriovNetworkNodeStateStatus) bool {
+	if p.skipBridgeConfiguration {
+		return false
+	}
+	return sriovnetworkv1.NeedToUpdateOVSHwOffload(desired.System.OvsHardwareOffload, current.System.OvsHardwareOffload)
+}
+
 func needDriverCheckDeviceType(state *sriovnetworkv1.SriovNetworkNodeState, driverState *DriverState) bool {
 	for _, iface := range state.Spec.Interfaces {
 		for i := range iface.VfGroups {
@@ -361,6 +438,12 @@ func (p *GenericPlugin) needDrainNode(desired sriovnetworkv1.SriovNetworkNodeSta
 			return true
 		}
 	}
+
+	// ovs-vswitchd is restarted to apply the OVS hardware offload settings
+	if p.needToUpdateOVSHwOffload(desired, current) {
+		log.Log.V(2).Info("generic plugin needDrainNode(): need drain since OVS hardware offload configuration needs to be updated")
+		return true
+	}
 	return false
 }
 
@@ -376,6 +459,11 @@ func (p *GenericPlugin) needToUpdateVFs(desired sriovnetworkv1.SriovNetworkNodeS
 					break
 				}
 				if sriovnetworkv1.NeedToUpdateSriov(&iface, &ifaceStatus) {
+					if p.isVfResizeWithoutDrain(&iface, &ifaceStatus) {
+						log.Log.V(2).Info("generic plugin needToUpdateVFs(): no need drain, only VFs outside of the in-use groups change",
+							"address", iface.PciAddress, "expected-vfs", iface.NumVfs, "current-vfs", ifaceStatus.NumVfs)
+						break
+					}
 					log.Log.V(2).Info("generic plugin needToUpdateVFs(): need drain, for PCI address request update",
 						"address", iface.PciAddress)
 					return true
@@ -415,11 +503,48 @@ func (p *GenericPlugin) needToUpdateVFs(desired sriovnetworkv1.SriovNetworkNodeS
 	return false
 }
 
+// isVfResizeWithoutDrain returns true if the number of VFs is the only change on the PF, the PF driver can change it
+// without resetting the allocated VFs and none of the removed VFs belong to a VF group applied on the PF before
+func (p *GenericPlugin) isVfResizeWithoutDrain(iface *sriovnetworkv1.Interface, ifaceStatus *sriovnetworkv1.InterfaceExt) bool {
+	if iface.NumVfs == 0 || ifaceStatus.NumVfs == 0 || iface.NumVfs == ifaceStatus.NumVfs ||
+		sriovnetworkv1.GetEswitchModeFromSpec(iface) != sriovnetworkv1.ESwithModeLegacy {
+		return false
+	}
+	if !p.helpers.IsSriovNumVfsResizeSupported(iface.PciAddress) {
+		return false
+	}
+
+	if iface.NumVfs < ifaceStatus.NumVfs {
+		pfStatus, exist, err := p.helpers.LoadPfsStatus(iface.PciAddress)
+		if err != nil || !exist {
+			log.Log.V(2).Info("generic plugin isVfResizeWithoutDrain(): can't load the applied VF groups for the PF",
+				"address", iface.PciAddress, "error", err)
+			return false
+		}
+		for vfID := iface.NumVfs; vfID < ifaceStatus.NumVfs; vfID++ {
+			for _, group := range pfStatus.VfGroups {
+				if sriovnetworkv1.IndexInRange(vfID, group.VfRange) {
+					log.Log.V(2).Info("generic plugin isVfResizeWithoutDrain(): removed VF belongs to an in-use group",
+						"address", iface.PciAddress, "vf", vfID, "resource", group.ResourceName)
+					return false
+				}
+			}
+		}
+	}
+
+	// the VFs that are kept must not require any other change
+	return !sriovnetworkv1.NeedToUpdateExistingVFs(iface, ifaceStatus)
+}
+
 func (p *GenericPlugin) shouldConfigureBridges() bool {
 	return vars.ManageSoftwareBridges && !p.skipBridgeConfiguration
 }
 
 func (p *GenericPlugin) addVfioDesiredKernelArg(state *sriovnetworkv1.SriovNetworkNodeState) {
+	// the IOMMU is not used by the vfio driver in no-IOMMU mode
+	if state.Spec.System.VfioNoIommuMode {
+		return
+	}
 	driverState := p.DriverStateMap[Vfio]
 
 	kernelArgFnByCPUVendor := map[hostTypes.CPUVendor]func(){
@@ -468,24 +593,513 @@ func (p *GenericPlugin) configRdmaKernelArg(state *sriovnetworkv1.SriovNetworkNo
 func (p *GenericPlugin) needRebootNode(state *sriovnetworkv1.SriovNetworkNodeState) (bool, error) {
 	needReboot := false
 
+	// the node reboot is handled by the config daemon, the systemd service only applies the configuration
+	trackKernelArgs := !vars.InChroot
+	if trackKernelArgs {
+		if err := p.verifyKernelArgsAfterReboot(state); err != nil {
+			return false, err
+		}
+	}
+
 	p.addVfioDesiredKernelArg(state)
 	err := p.configRdmaKernelArg(state)
 	if err != nil {
 		return false, err
 	}
 
+	managedKernelConfig, err := p.helpers.LoadManagedKernelConfig()
+	if err != nil {
+		log.Log.Error(err, "generic-plugin needRebootNode(): failed to load the managed kernel configuration")
+		return false, err
+	}
+	desiredKernelConfig := getManagedKernelConfig(state)
+	if err := p.configHugepages(state, managedKernelConfig, desiredKernelConfig); err != nil {
+		log.Log.Error(err, "generic-plugin needRebootNode(): failed to configure the hugepages")
+		return false, err
+	}
+	p.configExtraKernelArgs(desiredKernelConfig.KernelArgs, managedKernelConfig)
+
 	needReboot, err = p.syncDesiredKernelArgs()
 	if err != nil {
 		log.Log.Error(err, "generic-plugin needRebootNode(): failed to set the desired kernel arguments")
 		return false, err
 	}
+
+	needRebootForModules, err := p.configKernelModules(state, managedKernelConfig)
+	if err != nil {
+		log.Log.Error(err, "generic-plugin needRebootNode(): failed to set the kernel modules parameters")
+		return false, err
+	}
+	needReboot = needReboot || needRebootForModules
+
+	err = p.helpers.SaveManagedKernelConfig(desiredKernelConfig)
+	if err != nil {
+		log.Log.Error(err, "generic-plugin needRebootNode(): failed to save the managed kernel configuration")
+		return false, err
+	}
+
 	if needReboot {
 		log.Log.V(2).Info("generic-plugin needRebootNode(): need reboot for updating kernel arguments")
+		if trackKernelArgs {
+			if err := p.saveKernelArgsState(state); err != nil {
+				log.Log.Error(err, "generic-plugin needRebootNode(): failed to save the desired kernel arguments state")
+				return false, err
+			}
+		}
+	}
+
+	return needReboot, nil
+}
+
+// configExtraKernelArgs marks the additional kernel arguments from the SriovNetworkPoolConfig as desired.
+// Arguments configured before that are no longer requested are marked for removal, unless the plugin manages them.
+func (p *GenericPlugin) configExtraKernelArgs(kargs []string, managed *store.ManagedKernelConfig) {
+	for _, karg := range managed.KernelArgs {
+		if !sriovnetworkv1.StringInArray(karg, kargs) &&
+			!sriovnetworkv1.StringInArray(karg, builtinKernelArgs) {
+			p.disableDesiredKernelArgs(karg)
+		}
+	}
+	for _, karg := range kargs {
+		p.enableDesiredKernelArgs(karg)
+	}
+}
+
+// configHugepages computes the hugepages from the SriovNetworkPoolConfig to allocate on the NUMA nodes of the PFs used
+// by userspace VF groups. The hugepages are allocated at runtime by Apply, if the kernel couldn't allocate all of them,
+// e.g. because of memory fragmentation, the kernel arguments computed by Apply to allocate them on boot are added to
+// the desired kernel configuration.
+func (p *GenericPlugin) configHugepages(state *sriovnetworkv1.SriovNetworkNodeState, managed, desired *store.ManagedKernelConfig) error {
+	p.desiredHugepages = nil
+	// the runtime allocation is only changed by Apply
+	desired.Hugepages = managed.Hugepages
+	hugepages := state.Spec.System.Hugepages
+	if hugepages == nil || hugepages.Count == 0 {
+		if managed.Hugepages != nil && managed.Hugepages.OnBoot {
+			desired.Hugepages = nil
+		}
+		return nil
+	}
+	numaNodes, err := p.getUserspacePfsNumaNodes(state)
+	if err != nil {
+		return err
+	}
+	if len(numaNodes) == 0 {
+		return nil
+	}
+
+	requested := &store.ManagedHugepages{Size: hugepages.Size, Count: hugepages.Count, NumaNodes: numaNodes}
+	if managed.Hugepages != nil && managed.Hugepages.OnBoot {
+		if managed.Hugepages.Matches(requested) {
+			log.Log.V(2).Info("generic-plugin configHugepages(): hugepages are allocated on boot",
+				"kargs", managed.Hugepages.KernelArgs)
+			desired.KernelArgs = append(desired.KernelArgs, managed.Hugepages.KernelArgs...)
+			return nil
+		}
+		desired.Hugepages = nil
+	}
+	p.desiredHugepages = requested
+	return nil
+}
+
+// applyHugepages allocates at runtime the hugepages computed by configHugepages on top of the hugepages already
+// allocated on the NUMA nodes, and restores the previous count of hugepages of the NUMA nodes when the hugepages
+// allocated before that are no longer requested. If the kernel fails to allocate all the hugepages, the previous
+// counts are restored and the kernel arguments allocating the same total of hugepages on boot are stored,
+// CheckStatusChanges then reports the missing kernel arguments and the next sync requests the reboot of the node.
+func (p *GenericPlugin) applyHugepages() error {
+	managed, err := p.helpers.LoadManagedKernelConfig()
+	if err != nil {
+		return err
+	}
+	allocated := managed.Hugepages
+	if allocated != nil && allocated.OnBoot {
+		allocated = nil
+	}
+	bootID, err := p.helpers.GetBootID()
+	if err != nil {
+		return err
+	}
+	if allocated != nil && allocated.BootID != bootID {
+		// the hugepages allocated at runtime were released by the reboot of the node,
+		// the previous counts are stale and must not be restored
+		log.Log.Info("generic-plugin applyHugepages(): hugepages allocated at runtime were released by a reboot",
+			"size", allocated.Size, "count", allocated.Count, "numaNodes", allocated.NumaNodes)
+		allocated = nil
+		managed.Hugepages = nil
+		if err := p.helpers.SaveManagedKernelConfig(managed); err != nil {
+			return err
+		}
+	}
+	if allocated.Matches(p.desiredHugepages) {
+		return nil
+	}
+
+	if allocated != nil {
+		if err := p.restoreHugepages(allocated); err != nil {
+			return err
+		}
+		managed.Hugepages = nil
+		if err := p.helpers.SaveManagedKernelConfig(managed); err != nil {
+			return err
+		}
+	}
+	if p.desiredHugepages == nil {
+		return nil
+	}
+
+	requested := *p.desiredHugepages
+	requested.BootID = bootID
+	current, err := p.helpers.GetHugepages()
+	if err != nil {
+		return err
+	}
+	requested.PreviousCounts = make(map[int]int, len(requested.NumaNodes))
+	for _, numaNode := range requested.NumaNodes {
+		requested.PreviousCounts[numaNode] = getHugepagesTotal(current, numaNode, requested.Size)
+	}
+	// store the previous counts before changing them, so they can be restored if the allocation fails midway
+	managed.Hugepages = &requested
+	if err := p.helpers.SaveManagedKernelConfig(managed); err != nil {
+		return err
+	}
+	for _, numaNode := range requested.NumaNodes {
+		if err := p.helpers.SetHugepages(numaNode, requested.Size, requested.PreviousCounts[numaNode]+requested.Count); err != nil {
+			return err
+		}
+	}
+
+	allocatedPages, err := p.helpers.GetHugepages()
+	if err != nil {
+		return err
+	}
+	if isHugepagesAllocated(allocatedPages, &requested) {
+		return nil
+	}
+
+	if err := p.restoreHugepages(&requested); err != nil {
+		return err
+	}
+	kargs, err := p.getHugepagesKernelArgs(&requested, current)
+	if err != nil {
+		return err
+	}
+	log.Log.Info("generic-plugin applyHugepages(): failed to allocate the hugepages at runtime, allocate them on boot",
+		"size", requested.Size, "count", requested.Count, "numaNodes", requested.NumaNodes, "kargs", kargs)
+	requested.OnBoot = true
+	requested.KernelArgs = kargs
+	requested.BootID = ""
+	return p.helpers.SaveManagedKernelConfig(managed)
+}
+
+// needToUpdateHugepages returns true if the hugepages allocated at runtime were released by a reboot of the node,
+// or if the hugepages must be allocated on boot and the kernel arguments allocating them are not set on the running kernel
+func (p *GenericPlugin) needToUpdateHugepages() (bool, error) {
+	managed, err := p.helpers.LoadManagedKernelConfig()
+	if err != nil {
+		return false, err
+	}
+	if managed.Hugepages == nil {
+		return false, nil
+	}
+	if !managed.Hugepages.OnBoot {
+		bootID, err := p.helpers.GetBootID()
+		if err != nil {
+			return false, err
+		}
+		if managed.Hugepages.BootID != bootID {
+			log.Log.V(2).Info("generic-plugin needToUpdateHugepages(): hugepages allocated at runtime were released by a reboot")
+			return true, nil
+		}
+		return false, nil
+	}
+	cmdline, err := p.helpers.GetCurrentKernelArgs()
+	if err != nil {
+		return false, err
+	}
+	for _, karg := range managed.Hugepages.KernelArgs {
+		if !p.helpers.IsKernelArgsSet(cmdline, karg) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// restoreHugepages restores the count of hugepages of the NUMA nodes from before the runtime allocation
+func (p *GenericPlugin) restoreHugepages(allocated *store.ManagedHugepages) error {
+	for _, numaNode := range allocated.NumaNodes {
+		previous, ok := allocated.PreviousCounts[numaNode]
+		if !ok {
+			continue
+		}
+		if err := p.helpers.SetHugepages(numaNode, allocated.Size, previous); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getUserspacePfsNumaNodes returns the sorted list of the NUMA nodes of the PFs with VF groups using a userspace driver
+func (p *GenericPlugin) getUserspacePfsNumaNodes(state *sriovnetworkv1.SriovNetworkNodeState) ([]int, error) {
+	numaNodes := []int{}
+	for _, iface := range state.Spec.Interfaces {
+		userspace := false
+		for _, group := range iface.VfGroups {
+			if sriovnetworkv1.StringInArray(group.DeviceType, vars.DpdkDrivers) {
+				userspace = true
+				break
+			}
+		}
+		if !userspace {
+			continue
+		}
+		numaNode, err := p.helpers.GetDeviceNumaNode(iface.PciAddress)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(numaNodes, numaNode) {
+			numaNodes = append(numaNodes, numaNode)
+		}
+	}
+	sort.Ints(numaNodes)
+	return numaNodes, nil
+}
+
+// getHugepagesKernelArgs returns the kernel arguments to allocate on boot the requested hugepages on top of the previous
+// counts, e.g. "hugepagesz=1G hugepages=0:10,1:8". The count of the pages is set per NUMA node if the kernel supports it
+// (5.16 and newer), older kernels only accept the total count of the pages that they spread over all the NUMA nodes.
+func (p *GenericPlugin) getHugepagesKernelArgs(requested *store.ManagedHugepages, current []sriovnetworkv1.HugepagesStatus) ([]string, error) {
+	release, err := p.helpers.GetKernelRelease()
+	if err != nil {
+		return nil, err
+	}
+
+	count := ""
+	if isKernelReleaseAtLeast(release, 5, 16) {
+		perNode := make([]string, 0, len(requested.NumaNodes))
+		for _, numaNode := range requested.NumaNodes {
+			perNode = append(perNode, fmt.Sprintf("%d:%d", numaNode, requested.PreviousCounts[numaNode]+requested.Count))
+		}
+		count = strings.Join(perNode, ",")
+	} else {
+		total := requested.Count * len(requested.NumaNodes)
+		for _, status := range current {
+			if status.Size == requested.Size {
+				total += status.Total
+			}
+		}
+		log.Log.Info("generic-plugin getHugepagesKernelArgs(): the kernel doesn't support allocating hugepages per NUMA node, "+
+			"the hugepages are spread over all the NUMA nodes", "release", release, "total", total)
+		count = strconv.Itoa(total)
+	}
+	return []string{
+		consts.KernelArgHugepagesSize + requested.Size,
+		consts.KernelArgHugepages + count,
+	}, nil
+}
+
+// isKernelReleaseAtLeast returns true if the major and minor version of the kernel release, e.g. "5.14.0-427.el9",
+// are equal or higher than the given version. False is returned if the release can't be parsed.
+func isKernelReleaseAtLeast(release string, major, minor int) bool {
+	parts := strings.SplitN(release, ".", 3)
+	if len(parts) < 2 {
+		return false
+	}
+	releaseMajor, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return false
+	}
+	// the minor version can be followed by a suffix, e.g. "6.4-rc1"
+	minorDigits := strings.IndexFunc(parts[1], func(r rune) bool { return r < '0' || r > '9' })
+	if minorDigits >= 0 {
+		parts[1] = parts[1][:minorDigits]
+	}
+	releaseMinor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return false
+	}
+	return releaseMajor > major || (releaseMajor == major && releaseMinor >= minor)
+}
+
+// getHugepagesTotal returns the count of hugepages of the size allocated on the NUMA node
+func getHugepagesTotal(allocated []sriovnetworkv1.HugepagesStatus, numaNode int, size string) int {
+	for _, status := range allocated {
+		if status.NumaNode == numaNode && status.Size == size {
+			return status.Total
+		}
+	}
+	return 0
+}
+
+// isHugepagesAllocated returns true if the requested count of hugepages is allocated on top of the previous count
+// on all the NUMA nodes
+func isHugepagesAllocated(allocated []sriovnetworkv1.HugepagesStatus, requested *store.ManagedHugepages) bool {
+	for _, numaNode := range requested.NumaNodes {
+		if getHugepagesTotal(allocated, numaNode, requested.Size) < requested.PreviousCounts[numaNode]+requested.Count {
+			return false
+		}
+	}
+	return true
+}
+
+// configKernelModules persists the kernel modules parameters from the SriovNetworkPoolConfig and removes the
+// configuration of the modules that are no longer requested. Returns true if a loaded kernel module doesn't run
+// with the requested parameters and the node must be rebooted.
+func (p *GenericPlugin) configKernelModules(state *sriovnetworkv1.SriovNetworkNodeState, managed *store.ManagedKernelConfig) (bool, error) {
+	needReboot := false
+	modules := getDesiredKernelModules(state)
+	requested := make([]string, 0, len(modules))
+	for _, module := range modules {
+		requested = append(requested, module.Name)
+		if err := p.helpers.SetKernelModuleParams(module.Name, module.Params); err != nil {
+			return false, err
+		}
+		set, err := p.helpers.IsKernelModuleParamsSet(module.Name, module.Params)
+		if err != nil {
+			return false, err
+		}
+		if !set {
+			log.Log.V(2).Info("generic-plugin configKernelModules(): need reboot for updating kernel module parameters",
+				"module", module.Name, "params", module.Params)
+			needReboot = true
+		}
 	}
 
+	for _, name := range managed.KernelModules {
+		if sriovnetworkv1.StringInArray(name, requested) {
+			continue
+		}
+		// the module keeps running with the current parameters until it's reloaded
+		if err := p.helpers.SetKernelModuleParams(name, nil); err != nil {
+			return false, err
+		}
+	}
 	return needReboot, nil
 }
 
+// getDesiredKernelModules returns the kernel modules parameters requested in the node state,
+// including the vfio parameters for the no-IOMMU mode
+func getDesiredKernelModules(state *sriovnetworkv1.SriovNetworkNodeState) []sriovnetworkv1.KernelModule {
+	if !state.Spec.System.VfioNoIommuMode {
+		return state.Spec.System.KernelModules
+	}
+
+	modules := make([]sriovnetworkv1.KernelModule, 0, len(state.Spec.System.KernelModules)+1)
+	vfioFound := false
+	for _, module := range state.Spec.System.KernelModules {
+		if module.Name == vfioModule {
+			vfioFound = true
+			module.Params = sriovnetworkv1.UniqueAppend(append([]string{}, module.Params...), vfioNoIommuModeParam)
+		}
+		modules = append(modules, module)
+	}
+	if !vfioFound {
+		modules = append(modules, sriovnetworkv1.KernelModule{Name: vfioModule, Params: []string{vfioNoIommuModeParam}})
+	}
+	return modules
+}
+
+// getManagedKernelConfig returns the additional kernel arguments and kernel modules requested in the node state
+func getManagedKernelConfig(state *sriovnetworkv1.SriovNetworkNodeState) *store.ManagedKernelConfig {
+	config := &store.ManagedKernelConfig{KernelArgs: slices.Clone(state.Spec.System.KernelArgs)}
+	for _, module := range getDesiredKernelModules(state) {
+		config.KernelModules = append(config.KernelModules, module.Name)
+	}
+	return config
+}
+
+// saveKernelArgsState stores the desired kernel arguments together with the current boot ID on the host,
+// so they can be verified after the node reboots
+func (p *GenericPlugin) saveKernelArgsState(state *sriovnetworkv1.SriovNetworkNodeState) error {
+	bootID, err := p.helpers.GetBootID()
+	if err != nil {
+		return err
+	}
+
+	kargs := make(map[string]bool, len(p.DesiredKernelArgs))
+	for karg, kargState := range p.DesiredKernelArgs {
+		kargs[karg] = kargState
+	}
+	var modules map[string][]string
+	if desiredModules := getDesiredKernelModules(state); len(desiredModules) > 0 {
+		modules = make(map[string][]string, len(desiredModules))
+		for _, module := range desiredModules {
+			modules[module.Name] = module.Params
+		}
+	}
+	return p.helpers.SaveKernelArgsState(&store.KernelArgsState{
+		BootID:        bootID,
+		Generation:    state.Generation,
+		KernelArgs:    kargs,
+		KernelModules: modules,
+	})
+}
+
+// verifyKernelArgsAfterReboot checks that the kernel arguments and the kernel modules parameters requested before
+// the last reboot are in the desired state on the running kernel. The error is returned for as long as the node state
+// generation doesn't change, this prevents the node from rebooting in a loop if the kernel arguments can't be applied.
+func (p *GenericPlugin) verifyKernelArgsAfterReboot(state *sriovnetworkv1.SriovNetworkNodeState) error {
+	kargsState, exist, err := p.helpers.LoadKernelArgsState()
+	if err != nil {
+		log.Log.Error(err, "generic-plugin verifyKernelArgsAfterReboot(): failed to load the kernel arguments state")
+		return err
+	}
+	if !exist {
+		return nil
+	}
+
+	if kargsState.Generation != state.Generation {
+		log.Log.V(2).Info("generic-plugin verifyKernelArgsAfterReboot(): node state changed, drop kernel arguments state",
+			"saved-generation", kargsState.Generation, "generation", state.Generation)
+		return p.helpers.RemoveKernelArgsState()
+	}
+
+	if len(kargsState.Mismatch) == 0 {
+		bootID, err := p.helpers.GetBootID()
+		if err != nil {
+			return err
+		}
+		if bootID == kargsState.BootID {
+			// the node was not rebooted yet
+			return nil
+		}
+
+		kargs, err := p.helpers.GetCurrentKernelArgs()
+		if err != nil {
+			return err
+		}
+		for karg, kargState := range kargsState.KernelArgs {
+			if kargState != p.helpers.IsKernelArgsSet(kargs, karg) {
+				kargsState.Mismatch = append(kargsState.Mismatch, karg)
+			}
+		}
+		for module, params := range kargsState.KernelModules {
+			for _, param := range params {
+				set, err := p.helpers.IsKernelModuleParamsSet(module, []string{param})
+				if err != nil {
+					return err
+				}
+				if !set {
+					kargsState.Mismatch = append(kargsState.Mismatch, module+"."+param)
+				}
+			}
+		}
+		if len(kargsState.Mismatch) == 0 {
+			log.Log.Info("generic-plugin verifyKernelArgsAfterReboot(): kernel arguments applied after reboot")
+			return p.helpers.RemoveKernelArgsState()
+		}
+
+		sort.Strings(kargsState.Mismatch)
+		if err := p.helpers.SaveKernelArgsState(kargsState); err != nil {
+			return err
+		}
+	}
+
+	err = fmt.Errorf("kernel arguments or module parameters %v are not in the desired state after the node reboot", kargsState.Mismatch)
+	log.Log.Error(err, "generic-plugin verifyKernelArgsAfterReboot(): kernel arguments were not applied")
+	return err
+}
+
 // ////////////// for testing purposes only ///////////////////////
 func (p *GenericPlugin) getDriverStateMap() DriverStateMapType {
 	return p.DriverStateMap
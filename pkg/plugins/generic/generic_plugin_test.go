@@ -10,6 +10,7 @@ import (
 	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
 	mock_helper "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/helper/mock"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/store"
 	hostTypes "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/types"
 	plugin "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/plugins"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
@@ -43,6 +44,12 @@ var _ = Describe("Generic plugin", func() {
 		hostHelper.EXPECT().IsKernelArgsSet("", consts.KernelArgRdmaShared).Return(false).AnyTimes()
 
 		hostHelper.EXPECT().RunCommand(gomock.Any(), gomock.Any()).Return("", "", nil).AnyTimes()
+		hostHelper.EXPECT().LoadKernelArgsState().Return(nil, false, nil).AnyTimes()
+		hostHelper.EXPECT().GetBootID().Return("boot-id", nil).AnyTimes()
+		hostHelper.EXPECT().SaveKernelArgsState(gomock.Any()).Return(nil).AnyTimes()
+		hostHelper.EXPECT().LoadManagedKernelConfig().Return(&store.ManagedKernelConfig{}, nil).AnyTimes()
+		hostHelper.EXPECT().SaveManagedKernelConfig(gomock.Any()).Return(nil).AnyTimes()
+		hostHelper.EXPECT().IsSriovNumVfsResizeSupported(gomock.Any()).Return(false).AnyTimes()
 
 		genericPlugin, err = NewGenericPlugin(hostHelper)
 		Expect(err).ToNot(HaveOccurred())
@@ -974,6 +981,475 @@ var _ = Describe("Generic plugin", func() {
 			})
 		})
 
+		Context("Kernel Args verification after reboot", func() {
+			var (
+				kargsState *store.KernelArgsState
+				nodeState  *sriovnetworkv1.SriovNetworkNodeState
+			)
+
+			BeforeEach(func() {
+				// use a dedicated mock to validate the kernel args state calls
+				hostHelper = mock_helper.NewMockHostHelpersInterface(ctrl)
+				hostHelper.EXPECT().SetRDMASubsystem("").Return(nil).AnyTimes()
+				genericPlugin.(*GenericPlugin).helpers = hostHelper
+
+				nodeState = &sriovnetworkv1.SriovNetworkNodeState{}
+				nodeState.Generation = 2
+				kargsState = &store.KernelArgsState{
+					BootID:     "old-boot-id",
+					Generation: 2,
+					KernelArgs: map[string]bool{consts.KernelArgIntelIommu: true, consts.KernelArgIommuPt: true},
+				}
+			})
+
+			It("should save the desired kernel args when a reboot is needed", func() {
+				hostHelper.EXPECT().LoadKernelArgsState().Return(nil, false, nil)
+				hostHelper.EXPECT().LoadManagedKernelConfig().Return(&store.ManagedKernelConfig{}, nil)
+				hostHelper.EXPECT().SaveManagedKernelConfig(&store.ManagedKernelConfig{}).Return(nil)
+				hostHelper.EXPECT().RunCommand(gomock.Any(), gomock.Any()).Return("", "", nil).AnyTimes()
+				hostHelper.EXPECT().GetCurrentKernelArgs().Return("", nil)
+				hostHelper.EXPECT().IsKernelArgsSet("", gomock.Any()).Return(false).AnyTimes()
+				hostHelper.EXPECT().GetBootID().Return("old-boot-id", nil)
+				hostHelper.EXPECT().SaveKernelArgsState(&store.KernelArgsState{
+					BootID:     "old-boot-id",
+					Generation: 2,
+					KernelArgs: map[string]bool{
+						consts.KernelArgPciRealloc:    false,
+						consts.KernelArgIntelIommu:    true,
+						consts.KernelArgIommuPt:       false,
+						consts.KernelArgRdmaExclusive: false,
+						consts.KernelArgRdmaShared:    false,
+					},
+				}).Return(nil)
+
+				genericPlugin.(*GenericPlugin).enableDesiredKernelArgs(consts.KernelArgIntelIommu)
+				needReboot, err := genericPlugin.(*GenericPlugin).needRebootNode(nodeState)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(needReboot).To(BeTrue())
+			})
+
+			It("should not verify the kernel args if the node was not rebooted yet", func() {
+				hostHelper.EXPECT().LoadKernelArgsState().Return(kargsState, true, nil)
+				hostHelper.EXPECT().GetBootID().Return("old-boot-id", nil)
+
+				Expect(genericPlugin.(*GenericPlugin).verifyKernelArgsAfterReboot(nodeState)).To(Succeed())
+			})
+
+			It("should remove the state if the kernel args are applied after reboot", func() {
+				hostHelper.EXPECT().LoadKernelArgsState().Return(kargsState, true, nil)
+				hostHelper.EXPECT().GetBootID().Return("new-boot-id", nil)
+				hostHelper.EXPECT().GetCurrentKernelArgs().Return("intel_iommu=on iommu=pt", nil)
+				hostHelper.EXPECT().IsKernelArgsSet("intel_iommu=on iommu=pt", consts.KernelArgIntelIommu).Return(true)
+				hostHelper.EXPECT().IsKernelArgsSet("intel_iommu=on iommu=pt", consts.KernelArgIommuPt).Return(true)
+				hostHelper.EXPECT().RemoveKernelArgsState().Return(nil)
+
+				Expect(genericPlugin.(*GenericPlugin).verifyKernelArgsAfterReboot(nodeState)).To(Succeed())
+			})
+
+			It("should fail if the kernel args are missing after reboot", func() {
+				hostHelper.EXPECT().LoadKernelArgsState().Return(kargsState, true, nil)
+				hostHelper.EXPECT().GetBootID().Return("new-boot-id", nil)
+				hostHelper.EXPECT().GetCurrentKernelArgs().Return("intel_iommu=on", nil)
+				hostHelper.EXPECT().IsKernelArgsSet("intel_iommu=on", consts.KernelArgIntelIommu).Return(true)
+				hostHelper.EXPECT().IsKernelArgsSet("intel_iommu=on", consts.KernelArgIommuPt).Return(false)
+				hostHelper.EXPECT().SaveKernelArgsState(gomock.Any()).DoAndReturn(func(state *store.KernelArgsState) error {
+					Expect(state.Mismatch).To(Equal([]string{consts.KernelArgIommuPt}))
+					return nil
+				})
+
+				err := genericPlugin.(*GenericPlugin).verifyKernelArgsAfterReboot(nodeState)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(consts.KernelArgIommuPt))
+			})
+
+			It("should keep failing without rebooting again until the node state changes", func() {
+				kargsState.Mismatch = []string{consts.KernelArgIommuPt}
+				hostHelper.EXPECT().LoadKernelArgsState().Return(kargsState, true, nil)
+
+				_, err := genericPlugin.(*GenericPlugin).needRebootNode(nodeState)
+				Expect(err).To(HaveOccurred())
+			})
+
+			It("should drop the state if the node state generation changed", func() {
+				kargsState.Mismatch = []string{consts.KernelArgIommuPt}
+				nodeState.Generation = 3
+				hostHelper.EXPECT().LoadKernelArgsState().Return(kargsState, true, nil)
+				hostHelper.EXPECT().RemoveKernelArgsState().Return(nil)
+
+				Expect(genericPlugin.(*GenericPlugin).verifyKernelArgsAfterReboot(nodeState)).To(Succeed())
+			})
+		})
+
+		Context("Extra kernel configuration", func() {
+			var nodeState *sriovnetworkv1.SriovNetworkNodeState
+
+			BeforeEach(func() {
+				hostHelper = mock_helper.NewMockHostHelpersInterface(ctrl)
+				hostHelper.EXPECT().SetRDMASubsystem("").Return(nil).AnyTimes()
+				hostHelper.EXPECT().RunCommand(gomock.Any(), gomock.Any()).Return("", "", nil).AnyTimes()
+				hostHelper.EXPECT().GetCurrentKernelArgs().Return("", nil).AnyTimes()
+				hostHelper.EXPECT().IsKernelArgsSet("", gomock.Any()).Return(false).AnyTimes()
+				hostHelper.EXPECT().LoadKernelArgsState().Return(nil, false, nil).AnyTimes()
+				hostHelper.EXPECT().GetBootID().Return("boot-id", nil).AnyTimes()
+				hostHelper.EXPECT().SaveKernelArgsState(gomock.Any()).Return(nil).AnyTimes()
+				genericPlugin.(*GenericPlugin).helpers = hostHelper
+
+				nodeState = &sriovnetworkv1.SriovNetworkNodeState{
+					Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{System: sriovnetworkv1.System{
+						KernelArgs:    []string{"hugepagesz=1G", "hugepages=16"},
+						KernelModules: []sriovnetworkv1.KernelModule{{Name: "mlx5_core", Params: []string{"prof_sel=2"}}},
+					}},
+				}
+			})
+
+			It("should configure the additional kernel args and kernel module parameters", func() {
+				hostHelper.EXPECT().LoadManagedKernelConfig().Return(&store.ManagedKernelConfig{}, nil)
+				hostHelper.EXPECT().SetKernelModuleParams("mlx5_core", []string{"prof_sel=2"}).Return(nil)
+				hostHelper.EXPECT().IsKernelModuleParamsSet("mlx5_core", []string{"prof_sel=2"}).Return(true, nil)
+				hostHelper.EXPECT().SaveManagedKernelConfig(&store.ManagedKernelConfig{
+					KernelArgs:    []string{"hugepagesz=1G", "hugepages=16"},
+					KernelModules: []string{"mlx5_core"},
+				}).Return(nil)
+
+				needReboot, err := genericPlugin.(*GenericPlugin).needRebootNode(nodeState)
+				Expect(err).ToNot(HaveOccurred())
+				// the kernel args are not set in the current cmdline
+				Expect(needReboot).To(BeTrue())
+				Expect(genericPlugin.(*GenericPlugin).DesiredKernelArgs["hugepagesz=1G"]).To(BeTrue())
+				Expect(genericPlugin.(*GenericPlugin).DesiredKernelArgs["hugepages=16"]).To(BeTrue())
+			})
+
+			It("should request a reboot if a loaded module doesn't run with the requested parameters", func() {
+				nodeState.Spec.System.KernelArgs = nil
+				hostHelper.EXPECT().LoadManagedKernelConfig().Return(&store.ManagedKernelConfig{}, nil)
+				hostHelper.EXPECT().SetKernelModuleParams("mlx5_core", []string{"prof_sel=2"}).Return(nil)
+				hostHelper.EXPECT().IsKernelModuleParamsSet("mlx5_core", []string{"prof_sel=2"}).Return(false, nil)
+				hostHelper.EXPECT().SaveManagedKernelConfig(gomock.Any()).Return(nil)
+
+				needReboot, err := genericPlugin.(*GenericPlugin).needRebootNode(nodeState)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(needReboot).To(BeTrue())
+			})
+
+			It("should remove the configuration that is no longer requested", func() {
+				nodeState.Spec.System = sriovnetworkv1.System{}
+				hostHelper.EXPECT().LoadManagedKernelConfig().Return(&store.ManagedKernelConfig{
+					KernelArgs:    []string{"hugepages=16", consts.KernelArgIommuPt},
+					KernelModules: []string{"ice"},
+				}, nil)
+				hostHelper.EXPECT().SetKernelModuleParams("ice", nil).Return(nil)
+				hostHelper.EXPECT().SaveManagedKernelConfig(&store.ManagedKernelConfig{}).Return(nil)
+				genericPlugin.(*GenericPlugin).enableDesiredKernelArgs("hugepages=16")
+				genericPlugin.(*GenericPlugin).enableDesiredKernelArgs(consts.KernelArgIommuPt)
+
+				_, err := genericPlugin.(*GenericPlugin).needRebootNode(nodeState)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(genericPlugin.(*GenericPlugin).DesiredKernelArgs["hugepages=16"]).To(BeFalse())
+				// kernel args managed by the plugin are not removed
+				Expect(genericPlugin.(*GenericPlugin).DesiredKernelArgs[consts.KernelArgIommuPt]).To(BeTrue())
+			})
+
+			It("should configure vfio in no-IOMMU mode without the IOMMU kernel args", func() {
+				nodeState.Spec.System = sriovnetworkv1.System{VfioNoIommuMode: true}
+				nodeState.Spec.Interfaces = sriovnetworkv1.Interfaces{{
+					PciAddress: "0000:00:00.0",
+					NumVfs:     1,
+					VfGroups: []sriovnetworkv1.VfGroup{{
+						DeviceType:   consts.DeviceTypeVfioPci,
+						ResourceName: "resource-1",
+						VfRange:      "0-0",
+					}}}}
+				hostHelper.EXPECT().LoadManagedKernelConfig().Return(&store.ManagedKernelConfig{}, nil)
+				hostHelper.EXPECT().SetKernelModuleParams("vfio", []string{"enable_unsafe_noiommu_mode=1"}).Return(nil)
+				hostHelper.EXPECT().IsKernelModuleParamsSet("vfio", []string{"enable_unsafe_noiommu_mode=1"}).Return(true, nil)
+				hostHelper.EXPECT().SaveManagedKernelConfig(&store.ManagedKernelConfig{KernelModules: []string{"vfio"}}).Return(nil)
+
+				needReboot, err := genericPlugin.(*GenericPlugin).needRebootNode(nodeState)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(needReboot).To(BeFalse())
+				Expect(genericPlugin.(*GenericPlugin).DesiredKernelArgs[consts.KernelArgIntelIommu]).To(BeFalse())
+				Expect(genericPlugin.(*GenericPlugin).DesiredKernelArgs[consts.KernelArgIommuPt]).To(BeFalse())
+			})
+
+			It("should merge the vfio no-IOMMU mode with the requested vfio parameters", func() {
+				nodeState.Spec.System = sriovnetworkv1.System{
+					VfioNoIommuMode: true,
+					KernelModules:   []sriovnetworkv1.KernelModule{{Name: "vfio", Params: []string{"dma_entry_limit=65535"}}},
+				}
+				Expect(getDesiredKernelModules(nodeState)).To(Equal([]sriovnetworkv1.KernelModule{
+					{Name: "vfio", Params: []string{"dma_entry_limit=65535", "enable_unsafe_noiommu_mode=1"}},
+				}))
+				// the node state is not modified
+				Expect(nodeState.Spec.System.KernelModules[0].Params).To(Equal([]string{"dma_entry_limit=65535"}))
+			})
+		})
+
+		Context("Live VF resize", func() {
+			var (
+				iface       *sriovnetworkv1.Interface
+				ifaceStatus *sriovnetworkv1.InterfaceExt
+			)
+
+			BeforeEach(func() {
+				hostHelper = mock_helper.NewMockHostHelpersInterface(ctrl)
+				genericPlugin.(*GenericPlugin).helpers = hostHelper
+
+				iface = &sriovnetworkv1.Interface{
+					PciAddress: "0000:00:00.0",
+					NumVfs:     4,
+					VfGroups: []sriovnetworkv1.VfGroup{{
+						DeviceType:   "netdevice",
+						ResourceName: "resource-1",
+						VfRange:      "0-1",
+					}}}
+				ifaceStatus = &sriovnetworkv1.InterfaceExt{
+					PciAddress:     "0000:00:00.0",
+					NumVfs:         2,
+					EswitchMode:    "legacy",
+					LinkType:       "ETH",
+					LinkAdminState: "up",
+					VFs: []sriovnetworkv1.VirtualFunction{
+						{VfID: 0, Driver: "mlx5_core"},
+						{VfID: 1, Driver: "mlx5_core"},
+					},
+				}
+			})
+
+			It("should not drain when VFs are added on a PF with resize support", func() {
+				hostHelper.EXPECT().IsSriovNumVfsResizeSupported("0000:00:00.0").Return(true)
+				Expect(genericPlugin.(*GenericPlugin).isVfResizeWithoutDrain(iface, ifaceStatus)).To(BeTrue())
+			})
+
+			It("should drain when the PF doesn't support resize", func() {
+				hostHelper.EXPECT().IsSriovNumVfsResizeSupported("0000:00:00.0").Return(false)
+				Expect(genericPlugin.(*GenericPlugin).isVfResizeWithoutDrain(iface, ifaceStatus)).To(BeFalse())
+			})
+
+			It("should drain when a kept VF needs an update", func() {
+				ifaceStatus.VFs[1].Driver = "vfio-pci"
+				hostHelper.EXPECT().IsSriovNumVfsResizeSupported("0000:00:00.0").Return(true)
+				Expect(genericPlugin.(*GenericPlugin).isVfResizeWithoutDrain(iface, ifaceStatus)).To(BeFalse())
+			})
+
+			It("should not drain when the removed VFs are not part of an in-use group", func() {
+				iface.NumVfs = 2
+				ifaceStatus.NumVfs = 4
+				hostHelper.EXPECT().IsSriovNumVfsResizeSupported("0000:00:00.0").Return(true)
+				hostHelper.EXPECT().LoadPfsStatus("0000:00:00.0").Return(&sriovnetworkv1.Interface{
+					VfGroups: []sriovnetworkv1.VfGroup{{ResourceName: "resource-1", VfRange: "0-1"}},
+				}, true, nil)
+				Expect(genericPlugin.(*GenericPlugin).isVfResizeWithoutDrain(iface, ifaceStatus)).To(BeTrue())
+			})
+
+			It("should drain when a removed VF is part of an in-use group", func() {
+				iface.NumVfs = 2
+				ifaceStatus.NumVfs = 4
+				hostHelper.EXPECT().IsSriovNumVfsResizeSupported("0000:00:00.0").Return(true)
+				hostHelper.EXPECT().LoadPfsStatus("0000:00:00.0").Return(&sriovnetworkv1.Interface{
+					VfGroups: []sriovnetworkv1.VfGroup{{ResourceName: "resource-1", VfRange: "0-3"}},
+				}, true, nil)
+				Expect(genericPlugin.(*GenericPlugin).isVfResizeWithoutDrain(iface, ifaceStatus)).To(BeFalse())
+			})
+		})
+
+		Context("Hugepages", func() {
+			var nodeState *sriovnetworkv1.SriovNetworkNodeState
+
+			BeforeEach(func() {
+				hostHelper = mock_helper.NewMockHostHelpersInterface(ctrl)
+				hostHelper.EXPECT().SetRDMASubsystem("").Return(nil).AnyTimes()
+				hostHelper.EXPECT().RunCommand(gomock.Any(), gomock.Any()).Return("", "", nil).AnyTimes()
+				hostHelper.EXPECT().GetCurrentKernelArgs().Return("", nil).AnyTimes()
+				hostHelper.EXPECT().IsKernelArgsSet("", gomock.Any()).Return(false).AnyTimes()
+				hostHelper.EXPECT().LoadKernelArgsState().Return(nil, false, nil).AnyTimes()
+				hostHelper.EXPECT().GetBootID().Return("boot-id", nil).AnyTimes()
+				hostHelper.EXPECT().SaveKernelArgsState(gomock.Any()).Return(nil).AnyTimes()
+				hostHelper.EXPECT().GetCPUVendor().Return(hostTypes.CPUVendorIntel, nil).AnyTimes()
+				hostHelper.EXPECT().GetDeviceNumaNode("0000:00:00.0").Return(0, nil).AnyTimes()
+				hostHelper.EXPECT().GetDeviceNumaNode("0000:00:00.1").Return(0, nil).AnyTimes()
+				hostHelper.EXPECT().GetDeviceNumaNode("0000:80:00.0").Return(1, nil).AnyTimes()
+				genericPlugin.(*GenericPlugin).helpers = hostHelper
+
+				newIface := func(pciAddress, deviceType string) sriovnetworkv1.Interface {
+					return sriovnetworkv1.Interface{
+						PciAddress: pciAddress,
+						NumVfs:     1,
+						VfGroups: []sriovnetworkv1.VfGroup{{
+							DeviceType:   deviceType,
+							ResourceName: "resource-1",
+							VfRange:      "0-0",
+						}}}
+				}
+				nodeState = &sriovnetworkv1.SriovNetworkNodeState{
+					Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+						Interfaces: sriovnetworkv1.Interfaces{
+							newIface("0000:00:00.0", consts.DeviceTypeVfioPci),
+							newIface("0000:00:00.1", consts.DeviceTypeNetDevice),
+							newIface("0000:80:00.0", consts.DeviceTypeVfioPci),
+						},
+						System: sriovnetworkv1.System{
+							Hugepages: &sriovnetworkv1.HugepagesConfig{Size: "1G", Count: 4},
+						}},
+				}
+			})
+
+			It("should allocate the hugepages at runtime on top of the existing hugepages", func() {
+				hostHelper.EXPECT().LoadManagedKernelConfig().Return(&store.ManagedKernelConfig{}, nil).Times(2)
+				// needRebootNode doesn't change the hugepages
+				hostHelper.EXPECT().SaveManagedKernelConfig(&store.ManagedKernelConfig{}).Return(nil)
+				_, err := genericPlugin.(*GenericPlugin).needRebootNode(nodeState)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(genericPlugin.(*GenericPlugin).DesiredKernelArgs).ToNot(HaveKey("hugepagesz=1G"))
+
+				allocated := &store.ManagedHugepages{Size: "1G", Count: 4, NumaNodes: []int{0, 1},
+					PreviousCounts: map[int]int{0: 2, 1: 0}, BootID: "boot-id"}
+				gomock.InOrder(
+					hostHelper.EXPECT().GetHugepages().Return([]sriovnetworkv1.HugepagesStatus{
+						{NumaNode: 0, Size: "2M", Total: 16},
+						{NumaNode: 0, Size: "1G", Total: 2, Free: 2},
+						{NumaNode: 1, Size: "1G", Total: 0, Free: 0},
+					}, nil),
+					hostHelper.EXPECT().SaveManagedKernelConfig(&store.ManagedKernelConfig{Hugepages: allocated}).Return(nil),
+					hostHelper.EXPECT().SetHugepages(0, "1G", 6).Return(nil),
+					hostHelper.EXPECT().SetHugepages(1, "1G", 4).Return(nil),
+					hostHelper.EXPECT().GetHugepages().Return([]sriovnetworkv1.HugepagesStatus{
+						{NumaNode: 0, Size: "2M", Total: 16},
+						{NumaNode: 0, Size: "1G", Total: 6, Free: 6},
+						{NumaNode: 1, Size: "1G", Total: 4, Free: 4},
+					}, nil),
+				)
+				Expect(genericPlugin.(*GenericPlugin).applyHugepages()).To(Succeed())
+			})
+
+			It("should not change the hugepages already allocated at runtime", func() {
+				allocated := &store.ManagedHugepages{Size: "1G", Count: 4, NumaNodes: []int{0, 1},
+					PreviousCounts: map[int]int{0: 2, 1: 0}, BootID: "boot-id"}
+				hostHelper.EXPECT().LoadManagedKernelConfig().Return(&store.ManagedKernelConfig{Hugepages: allocated}, nil).Times(2)
+				hostHelper.EXPECT().SaveManagedKernelConfig(&store.ManagedKernelConfig{Hugepages: allocated}).Return(nil)
+
+				_, err := genericPlugin.(*GenericPlugin).needRebootNode(nodeState)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(genericPlugin.(*GenericPlugin).applyHugepages()).To(Succeed())
+			})
+
+			It("should allocate again the hugepages released by a reboot", func() {
+				// the record of the runtime allocation is kept on the host after the reboot
+				released := &store.ManagedHugepages{Size: "1G", Count: 4, NumaNodes: []int{0, 1},
+					PreviousCounts: map[int]int{0: 2, 1: 0}, BootID: "old-boot-id"}
+				hostHelper.EXPECT().LoadManagedKernelConfig().Return(&store.ManagedKernelConfig{Hugepages: released}, nil).Times(2)
+				Expect(genericPlugin.(*GenericPlugin).needToUpdateHugepages()).To(BeTrue())
+
+				allocated := &store.ManagedHugepages{Size: "1G", Count: 4, NumaNodes: []int{0, 1},
+					PreviousCounts: map[int]int{0: 1, 1: 0}, BootID: "boot-id"}
+				gomock.InOrder(
+					// the stale previous counts are not restored
+					hostHelper.EXPECT().SaveManagedKernelConfig(&store.ManagedKernelConfig{}).Return(nil),
+					hostHelper.EXPECT().GetHugepages().Return([]sriovnetworkv1.HugepagesStatus{
+						{NumaNode: 0, Size: "1G", Total: 1, Free: 1},
+						{NumaNode: 1, Size: "1G", Total: 0, Free: 0},
+					}, nil),
+					hostHelper.EXPECT().SaveManagedKernelConfig(&store.ManagedKernelConfig{Hugepages: allocated}).Return(nil),
+					hostHelper.EXPECT().SetHugepages(0, "1G", 5).Return(nil),
+					hostHelper.EXPECT().SetHugepages(1, "1G", 4).Return(nil),
+					hostHelper.EXPECT().GetHugepages().Return([]sriovnetworkv1.HugepagesStatus{
+						{NumaNode: 0, Size: "1G", Total: 5, Free: 5},
+						{NumaNode: 1, Size: "1G", Total: 4, Free: 4},
+					}, nil),
+				)
+				genericPlugin.(*GenericPlugin).desiredHugepages = &store.ManagedHugepages{Size: "1G", Count: 4, NumaNodes: []int{0, 1}}
+				Expect(genericPlugin.(*GenericPlugin).applyHugepages()).To(Succeed())
+
+				hostHelper.EXPECT().LoadManagedKernelConfig().Return(&store.ManagedKernelConfig{Hugepages: allocated}, nil)
+				Expect(genericPlugin.(*GenericPlugin).needToUpdateHugepages()).To(BeFalse())
+			})
+
+			It("should allocate the hugepages on boot on top of the existing ones if the runtime allocation fails", func() {
+				hostHelper.EXPECT().LoadManagedKernelConfig().Return(&store.ManagedKernelConfig{}, nil)
+				current := []sriovnetworkv1.HugepagesStatus{
+					{NumaNode: 0, Size: "1G", Total: 2},
+					{NumaNode: 1, Size: "1G", Total: 0},
+				}
+				hostHelper.EXPECT().GetHugepages().Return(current, nil)
+				hostHelper.EXPECT().SaveManagedKernelConfig(gomock.Any()).Return(nil)
+				hostHelper.EXPECT().SetHugepages(0, "1G", 6).Return(nil)
+				hostHelper.EXPECT().SetHugepages(1, "1G", 4).Return(nil)
+				hostHelper.EXPECT().GetHugepages().Return([]sriovnetworkv1.HugepagesStatus{
+					{NumaNode: 0, Size: "1G", Total: 6, Free: 6},
+					{NumaNode: 1, Size: "1G", Total: 1, Free: 1},
+				}, nil)
+				// the previous counts are restored
+				hostHelper.EXPECT().SetHugepages(0, "1G", 2).Return(nil)
+				hostHelper.EXPECT().SetHugepages(1, "1G", 0).Return(nil)
+				hostHelper.EXPECT().GetKernelRelease().Return("5.14.0-427.el9.x86_64", nil)
+				onBoot := &store.ManagedHugepages{Size: "1G", Count: 4, NumaNodes: []int{0, 1},
+					PreviousCounts: map[int]int{0: 2, 1: 0}, OnBoot: true,
+					KernelArgs: []string{"hugepagesz=1G", "hugepages=10"}}
+				hostHelper.EXPECT().SaveManagedKernelConfig(&store.ManagedKernelConfig{Hugepages: onBoot}).Return(nil)
+
+				genericPlugin.(*GenericPlugin).desiredHugepages = &store.ManagedHugepages{Size: "1G", Count: 4, NumaNodes: []int{0, 1}}
+				Expect(genericPlugin.(*GenericPlugin).applyHugepages()).To(Succeed())
+
+				// the missing kernel arguments are reported as a status change
+				hostHelper.EXPECT().LoadManagedKernelConfig().Return(&store.ManagedKernelConfig{Hugepages: onBoot}, nil)
+				Expect(genericPlugin.(*GenericPlugin).needToUpdateHugepages()).To(BeTrue())
+
+				// the next sync requests the kernel arguments
+				hostHelper.EXPECT().LoadManagedKernelConfig().Return(&store.ManagedKernelConfig{Hugepages: onBoot}, nil)
+				hostHelper.EXPECT().SaveManagedKernelConfig(&store.ManagedKernelConfig{
+					KernelArgs: []string{"hugepagesz=1G", "hugepages=10"},
+					Hugepages:  onBoot,
+				}).Return(nil)
+				needReboot, err := genericPlugin.(*GenericPlugin).needRebootNode(nodeState)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(needReboot).To(BeTrue())
+				Expect(genericPlugin.(*GenericPlugin).DesiredKernelArgs["hugepagesz=1G"]).To(BeTrue())
+				Expect(genericPlugin.(*GenericPlugin).DesiredKernelArgs["hugepages=10"]).To(BeTrue())
+				Expect(genericPlugin.(*GenericPlugin).desiredHugepages).To(BeNil())
+			})
+
+			It("should allocate the hugepages on boot per NUMA node on recent kernels", func() {
+				hostHelper.EXPECT().GetKernelRelease().Return("6.4-rc1", nil)
+				requested := &store.ManagedHugepages{Size: "1G", Count: 4, NumaNodes: []int{0, 1},
+					PreviousCounts: map[int]int{0: 2, 1: 0}}
+				Expect(genericPlugin.(*GenericPlugin).getHugepagesKernelArgs(requested, nil)).To(
+					Equal([]string{"hugepagesz=1G", "hugepages=0:6,1:4"}))
+			})
+
+			It("should keep the hugepages allocated on boot", func() {
+				onBoot := &store.ManagedHugepages{Size: "1G", Count: 4, NumaNodes: []int{0, 1},
+					PreviousCounts: map[int]int{0: 2, 1: 0}, OnBoot: true,
+					KernelArgs: []string{"hugepagesz=1G", "hugepages=0:6,1:4"}}
+				hostHelper.EXPECT().LoadManagedKernelConfig().Return(&store.ManagedKernelConfig{
+					KernelArgs: []string{"hugepagesz=1G", "hugepages=0:6,1:4"},
+					Hugepages:  onBoot,
+				}, nil)
+				hostHelper.EXPECT().SaveManagedKernelConfig(&store.ManagedKernelConfig{
+					KernelArgs: []string{"hugepagesz=1G", "hugepages=0:6,1:4"},
+					Hugepages:  onBoot,
+				}).Return(nil)
+
+				_, err := genericPlugin.(*GenericPlugin).needRebootNode(nodeState)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(genericPlugin.(*GenericPlugin).DesiredKernelArgs["hugepages=0:6,1:4"]).To(BeTrue())
+				Expect(genericPlugin.(*GenericPlugin).desiredHugepages).To(BeNil())
+			})
+
+			It("should restore the previous hugepages when they are no longer requested", func() {
+				nodeState.Spec.System.Hugepages = nil
+				allocated := &store.ManagedHugepages{Size: "2M", Count: 512, NumaNodes: []int{0, 1},
+					PreviousCounts: map[int]int{0: 128, 1: 0}, BootID: "boot-id"}
+				hostHelper.EXPECT().LoadManagedKernelConfig().Return(&store.ManagedKernelConfig{Hugepages: allocated}, nil).Times(2)
+				hostHelper.EXPECT().SaveManagedKernelConfig(&store.ManagedKernelConfig{Hugepages: allocated}).Return(nil)
+				_, err := genericPlugin.(*GenericPlugin).needRebootNode(nodeState)
+				Expect(err).ToNot(HaveOccurred())
+
+				hostHelper.EXPECT().SetHugepages(0, "2M", 128).Return(nil)
+				hostHelper.EXPECT().SetHugepages(1, "2M", 0).Return(nil)
+				hostHelper.EXPECT().SaveManagedKernelConfig(&store.ManagedKernelConfig{}).Return(nil)
+				Expect(genericPlugin.(*GenericPlugin).applyHugepages()).To(Succeed())
+			})
+		})
+
 		It("should load vfio_pci driver", func() {
 			networkNodeState := &sriovnetworkv1.SriovNetworkNodeState{
 				Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
@@ -1030,6 +1506,29 @@ var _ = Describe("Generic plugin", func() {
 			Expect(driverState.DriverLoaded).To(BeTrue())
 		})
 
+		It("should load uio_pci_generic driver", func() {
+			networkNodeState := &sriovnetworkv1.SriovNetworkNodeState{
+				Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+					Interfaces: sriovnetworkv1.Interfaces{{
+						PciAddress: "0000:00:00.0",
+						NumVfs:     1,
+						VfGroups: []sriovnetworkv1.VfGroup{{
+							DeviceType:   consts.DeviceTypeUioPciGeneric,
+							PolicyName:   "policy-1",
+							ResourceName: "resource-1",
+							VfRange:      "0-0",
+						}}}},
+				},
+			}
+
+			concretePlugin := genericPlugin.(*GenericPlugin)
+			driverStateMap := concretePlugin.getDriverStateMap()
+			concretePlugin.loadDriverForTests(networkNodeState)
+			Expect(driverStateMap[UioPciGeneric].DriverLoaded).To(BeTrue())
+			Expect(driverStateMap[IgbUio].DriverLoaded).To(BeFalse())
+			Expect(driverStateMap[Vfio].DriverLoaded).To(BeFalse())
+		})
+
 		It("should load virtio_vdpa driver", func() {
 			networkNodeState := &sriovnetworkv1.SriovNetworkNodeState{
 				Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
@@ -1278,6 +1777,33 @@ var _ = Describe("Generic plugin", func() {
 		Expect(needReboot).To(BeFalse())
 		Expect(needDrain).To(BeTrue())
 	})
+	It("should drain - OVS hardware offload mismatch", func() {
+		networkNodeState := &sriovnetworkv1.SriovNetworkNodeState{
+			Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+				System: sriovnetworkv1.System{
+					OvsHardwareOffload: &sriovnetworkv1.OvsHardwareOffload{Enabled: false},
+				}},
+			Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+				System: sriovnetworkv1.System{
+					OvsHardwareOffload: &sriovnetworkv1.OvsHardwareOffload{Enabled: true, TcPolicy: "skip_sw"},
+				}},
+		}
+		needDrain, needReboot, err := genericPlugin.OnNodeStateChange(networkNodeState)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(needReboot).To(BeFalse())
+		Expect(needDrain).To(BeTrue())
+
+		networkNodeState.Status.System.OvsHardwareOffload.Enabled = false
+		needDrain, _, err = genericPlugin.OnNodeStateChange(networkNodeState)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(needDrain).To(BeFalse())
+
+		// nothing to disable if OVS doesn't run on the node
+		networkNodeState.Status.System.OvsHardwareOffload = nil
+		needDrain, _, err = genericPlugin.OnNodeStateChange(networkNodeState)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(needDrain).To(BeFalse())
+	})
 	It("check status - bridge config mismatch", func() {
 		networkNodeState := &sriovnetworkv1.SriovNetworkNodeState{
 			Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
@@ -73,6 +73,10 @@ func (u *k8sUpdateTarget) String() string {
 }
 
 func (u *k8sUpdateTarget) needReboot() bool {
+	// in hybrid mode the configuration is applied live, the sriov services only restore it on the next boot
+	if vars.UsingHybridMode {
+		return u.openVSwitch.NeedReboot()
+	}
 	return u.sriovScript.NeedReboot() || u.sriovPostNetworkScript.NeedReboot() || u.openVSwitch.NeedReboot()
 }
 
@@ -117,7 +121,7 @@ func (p *K8sPlugin) OnNodeStateChange(new *sriovnetworkv1.SriovNetworkNodeState)
 	p.updateTarget.reset()
 	// TODO add check for enableOvsOffload in OperatorConfig later
 	// Update services if switchdev required
-	if !vars.UsingSystemdMode && !sriovnetworkv1.IsSwitchdevModeSpec(new.Spec) {
+	if !useSriovServices() && !sriovnetworkv1.IsSwitchdevModeSpec(new.Spec) {
 		return
 	}
 
@@ -130,7 +134,7 @@ func (p *K8sPlugin) OnNodeStateChange(new *sriovnetworkv1.SriovNetworkNodeState)
 		}
 	}
 
-	if vars.UsingSystemdMode {
+	if useSriovServices() {
 		// Check sriov service
 		err = p.sriovServicesStateUpdate()
 		if err != nil {
@@ -157,7 +161,7 @@ func (p *K8sPlugin) CheckStatusChanges(*sriovnetworkv1.SriovNetworkNodeState) (b
 // Apply config change
 func (p *K8sPlugin) Apply() error {
 	log.Log.Info("k8s plugin Apply()")
-	if vars.UsingSystemdMode {
+	if useSriovServices() {
 		if err := p.updateSriovServices(); err != nil {
 			return err
 		}
@@ -317,3 +321,8 @@ func (p *K8sPlugin) isOVSHwOffloadingEnabled() bool {
 	}
 	return false
 }
+
+// useSriovServices returns true if the sriov-config systemd services must be installed on the host
+func useSriovServices() bool {
+	return vars.UsingSystemdMode || vars.UsingHybridMode
+}
@@ -47,6 +47,14 @@ func setIsSystemdMode(val bool) {
 	vars.UsingSystemdMode = val
 }
 
+func setIsHybridMode(val bool) {
+	origUsingHybridMode := vars.UsingHybridMode
+	DeferCleanup(func() {
+		vars.UsingHybridMode = origUsingHybridMode
+	})
+	vars.UsingHybridMode = val
+}
+
 func newServiceNameMatcher(name string) gomock.Matcher {
 	return &serviceNameMatcher{name: name}
 }
@@ -124,6 +132,21 @@ var _ = Describe("K8s plugin", func() {
 		Expect(needDrain).To(BeTrue())
 		Expect(k8sPlugin.Apply()).NotTo(HaveOccurred())
 	})
+	It("hybrid, created without reboot", func() {
+		setIsSystemdMode(false)
+		setIsHybridMode(true)
+
+		hostHelper.EXPECT().IsServiceEnabled("/etc/systemd/system/sriov-config.service").Return(false, nil)
+		hostHelper.EXPECT().IsServiceEnabled("/etc/systemd/system/sriov-config-post-network.service").Return(false, nil)
+		hostHelper.EXPECT().EnableService(newServiceNameMatcher("sriov-config.service")).Return(nil)
+		hostHelper.EXPECT().EnableService(newServiceNameMatcher("sriov-config-post-network.service")).Return(nil)
+
+		needDrain, needReboot, err := k8sPlugin.OnNodeStateChange(&sriovnetworkv1.SriovNetworkNodeState{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(needReboot).To(BeFalse())
+		Expect(needDrain).To(BeFalse())
+		Expect(k8sPlugin.Apply()).NotTo(HaveOccurred())
+	})
 	It("systemd, already configured", func() {
 		setIsSystemdMode(true)
 
@@ -87,6 +87,16 @@ func (p *VirtualPlugin) Apply() error {
 		p.LoadVfioDriver = loaded
 	}
 
+	for _, driver := range []string{consts.DeviceTypeUioPciGeneric, consts.DeviceTypeIgbUio} {
+		if !needDriver(p.DesireState, driver) {
+			continue
+		}
+		if err := p.helpers.LoadKernelModule(driver); err != nil {
+			log.Log.Error(err, "virtual plugin Apply(): fail to load kmod", "name", driver)
+			return err
+		}
+	}
+
 	if p.LastState != nil {
 		log.Log.Info("virtual plugin Apply()", "last-state", p.LastState.Spec)
 		if equality.Semantic.DeepEqual(p.LastState.Spec.Interfaces, p.DesireState.Spec.Interfaces) {
@@ -109,9 +119,14 @@ func (p *VirtualPlugin) Apply() error {
 }
 
 func needVfioDriver(state *sriovnetworkv1.SriovNetworkNodeState) bool {
+	return needDriver(state, consts.DeviceTypeVfioPci)
+}
+
+// needDriver returns true if a VF group of the desired state uses the device type
+func needDriver(state *sriovnetworkv1.SriovNetworkNodeState, deviceType string) bool {
 	for _, iface := range state.Spec.Interfaces {
 		for i := range iface.VfGroups {
-			if iface.VfGroups[i].DeviceType == consts.DeviceTypeVfioPci {
+			if iface.VfGroups[i].DeviceType == deviceType {
 				return true
 			}
 		}
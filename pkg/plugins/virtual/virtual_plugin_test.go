@@ -154,6 +154,28 @@ var _ = Describe("SRIOV", Ordered, func() {
 			Expect(v.LoadVfioDriver).To(Equal(uint(loaded)))
 		})
 
+		It("should load the uio kernel module if needed", func() {
+			h.EXPECT().LoadKernelModule(consts.DeviceTypeUioPciGeneric).Return(nil)
+			sriovNetworkNodeState.Spec.Interfaces = sriovnetworkv1.Interfaces{
+				{Name: "eno1",
+					NumVfs:     1,
+					PciAddress: "0000:d8:00.0", VfGroups: []sriovnetworkv1.VfGroup{
+						{
+							ResourceName: "test",
+							PolicyName:   "test",
+							VfRange:      "eno1#0-0",
+							DeviceType:   consts.DeviceTypeUioPciGeneric,
+						},
+					},
+				},
+			}
+
+			v.LastState = sriovNetworkNodeState
+			v.DesireState = sriovNetworkNodeState
+			err := v.Apply()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
 		It("should return error if not able to load the vfio kernel module", func() {
 			h.EXPECT().LoadKernelModule("vfio", "enable_unsafe_noiommu_mode=1").Return(fmt.Errorf("failed to load kernel module"))
 			v.LoadVfioDriver = loading
@@ -2,12 +2,18 @@ package utils
 
 import (
 	"context"
+	"encoding/json"
+	"strings"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
 )
 
 // ObjectHasAnnotationKey checks if a kubernetes object already contains annotation
@@ -140,3 +146,233 @@ func RemoveLabelFromNode(ctx context.Context, nodeName string, key string, c cli
 
 	return removeLabelObject(ctx, node, key, c)
 }
+
+const (
+	// PodNodeNameIndex is the pod index on the name of the node
+	PodNodeNameIndex = "spec.nodeName"
+	// PodSriovResourceIndex is the pod index on the SR-IOV resources requested by the pod
+	PodSriovResourceIndex = "sriovResource"
+	// PodNetworkIndex is the pod index on the <namespace>/<name> of the networks attached to the pod
+	PodNetworkIndex = "sriovNetwork"
+)
+
+// PodIndexers returns the functions of the indexes used to list the pods from the cache
+func PodIndexers() map[string]client.IndexerFunc {
+	return map[string]client.IndexerFunc{
+		PodNodeNameIndex: func(o client.Object) []string {
+			return []string{o.(*corev1.Pod).Spec.NodeName}
+		},
+		PodSriovResourceIndex: func(o client.Object) []string {
+			return getPodSriovResources(o.(*corev1.Pod), vars.ResourcePrefix+"/")
+		},
+		PodNetworkIndex: func(o client.Object) []string {
+			pod := o.(*corev1.Pod)
+			networks := []string{}
+			for _, network := range getPodNetworks(pod) {
+				networkNamespace := network.Namespace
+				if networkNamespace == "" {
+					networkNamespace = pod.Namespace
+				}
+				networks = append(networks, networkNamespace+"/"+network.Name)
+			}
+			return networks
+		},
+	}
+}
+
+// SetupPodIndexes adds the indexes used to list the pods from the cache
+func SetupPodIndexes(ctx context.Context, indexer client.FieldIndexer) error {
+	for name, indexerFunc := range PodIndexers() {
+		if err := indexer.IndexField(ctx, &corev1.Pod{}, name, indexerFunc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ResourceUsage contains the number of running pods requesting each SR-IOV resource per node,
+// the resource names don't include the resource prefix
+type ResourceUsage map[string]map[string]int
+
+// GetResourceUsage returns the usage of the SR-IOV resources from the resource requests of the running pods.
+// If nodes is not nil only the pods of the nodes are counted, they are listed for each node with a spec.nodeName
+// field selector so the pods of the other nodes are not read, a cached reader needs the PodNodeNameIndex.
+func GetResourceUsage(ctx context.Context, c client.Reader, nodes map[string]bool) (ResourceUsage, error) {
+	usage := ResourceUsage{}
+	if nodes == nil {
+		return usage, addResourceUsage(ctx, c, usage)
+	}
+	for nodeName := range nodes {
+		if err := addResourceUsage(ctx, c, usage, client.MatchingFields{PodNodeNameIndex: nodeName}); err != nil {
+			return nil, err
+		}
+	}
+	return usage, nil
+}
+
+// GetResourceUsageOf returns the usage of the SR-IOV resources of the running pods requesting the resource,
+// the reader needs the PodSriovResourceIndex
+func GetResourceUsageOf(ctx context.Context, c client.Reader, resourceName string) (ResourceUsage, error) {
+	usage := ResourceUsage{}
+	return usage, addResourceUsage(ctx, c, usage, client.MatchingFields{PodSriovResourceIndex: resourceName})
+}
+
+// addResourceUsage adds to the usage the SR-IOV resources of the running pods listed with the options
+func addResourceUsage(ctx context.Context, c client.Reader, usage ResourceUsage, opts ...client.ListOption) error {
+	podList := &corev1.PodList{}
+	if err := c.List(ctx, podList, opts...); err != nil {
+		return err
+	}
+	prefix := vars.ResourcePrefix + "/"
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if !isPodRunning(pod) {
+			continue
+		}
+		for _, resourceName := range getPodSriovResources(pod, prefix) {
+			if usage[resourceName] == nil {
+				usage[resourceName] = map[string]int{}
+			}
+			usage[resourceName][pod.Spec.NodeName]++
+		}
+	}
+	return nil
+}
+
+// Pods returns the number of pods using the resource on the nodes, the pods on all the nodes are counted if nodes is nil
+func (u ResourceUsage) Pods(resourceName string, nodes map[string]bool) int {
+	count := 0
+	for nodeName, pods := range u[resourceName] {
+		if nodes == nil || nodes[nodeName] {
+			count += pods
+		}
+	}
+	return count
+}
+
+// RemovedResourcePods returns the number of running pods using the resource of the removed policy on the nodes
+// where none of the remaining policies provides the same resource
+func (u ResourceUsage) RemovedResourcePods(removed *sriovnetworkv1.SriovNetworkNodePolicy,
+	remaining []sriovnetworkv1.SriovNetworkNodePolicy, nodes []corev1.Node) int {
+	if len(u[removed.Spec.ResourceName]) == 0 {
+		return 0
+	}
+	return u.Pods(removed.Spec.ResourceName, RemovedResourceNodes(removed, remaining, nodes))
+}
+
+// RemovedResourceNodes returns the nodes selected by the removed policy where none of the remaining policies
+// provides the same resource
+func RemovedResourceNodes(removed *sriovnetworkv1.SriovNetworkNodePolicy,
+	remaining []sriovnetworkv1.SriovNetworkNodePolicy, nodes []corev1.Node) map[string]bool {
+	removedNodes := map[string]bool{}
+	for i := range nodes {
+		node := &nodes[i]
+		if !removed.Selected(node) {
+			continue
+		}
+		provided := false
+		for j := range remaining {
+			if remaining[j].Spec.ResourceName == removed.Spec.ResourceName && remaining[j].Selected(node) {
+				provided = true
+				break
+			}
+		}
+		if !provided {
+			removedNodes[node.Name] = true
+		}
+	}
+	return removedNodes
+}
+
+// GetPodsUsingNetwork returns the <namespace>/<name> of the running pods attached to the network
+// of the namespace, the pods can reference the network from any namespace.
+// The options restrict the listed pods, e.g. with the PodNetworkIndex of a cached reader.
+func GetPodsUsingNetwork(ctx context.Context, c client.Reader, networkName, namespace string, opts ...client.ListOption) ([]string, error) {
+	podList := &corev1.PodList{}
+	if err := c.List(ctx, podList, opts...); err != nil {
+		return nil, err
+	}
+
+	var pods []string
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if !isPodRunning(pod) {
+			continue
+		}
+		for _, network := range getPodNetworks(pod) {
+			networkNamespace := network.Namespace
+			if networkNamespace == "" {
+				networkNamespace = pod.Namespace
+			}
+			if network.Name == networkName && networkNamespace == namespace {
+				pods = append(pods, pod.Namespace+"/"+pod.Name)
+				break
+			}
+		}
+	}
+	return pods, nil
+}
+
+// podNetwork is an element of the networks annotation of the pod
+type podNetwork struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// getPodNetworks parses the k8s.v1.cni.cncf.io/networks annotation of the pod,
+// the annotation is a JSON list or a comma separated list of <namespace>/<name>@<interface>
+func getPodNetworks(pod *corev1.Pod) []podNetwork {
+	value := strings.TrimSpace(pod.Annotations[consts.PodNetworksAnnotation])
+	if value == "" {
+		return nil
+	}
+
+	networks := []podNetwork{}
+	if strings.HasPrefix(value, "[") {
+		if err := json.Unmarshal([]byte(value), &networks); err != nil {
+			log.Log.V(2).Info("getPodNetworks(): invalid networks annotation", "pod", pod.Name, "error", err)
+			return nil
+		}
+		return networks
+	}
+
+	for _, item := range strings.Split(value, ",") {
+		item, _, _ = strings.Cut(strings.TrimSpace(item), "@")
+		namespace, name, found := strings.Cut(item, "/")
+		if !found {
+			namespace, name = "", item
+		}
+		networks = append(networks, podNetwork{Name: name, Namespace: namespace})
+	}
+	return networks
+}
+
+// isPodRunning returns true if the pod is scheduled on a node and is not terminated,
+// the pending pods without a node don't have devices allocated yet
+func isPodRunning(pod *corev1.Pod) bool {
+	return pod.Spec.NodeName != "" &&
+		pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed
+}
+
+// getPodSriovResources returns the SR-IOV resources requested by the containers of the pod without the prefix
+func getPodSriovResources(pod *corev1.Pod, prefix string) []string {
+	resources := map[string]bool{}
+	containers := make([]corev1.Container, 0, len(pod.Spec.InitContainers)+len(pod.Spec.Containers))
+	containers = append(containers, pod.Spec.InitContainers...)
+	containers = append(containers, pod.Spec.Containers...)
+	for _, c := range containers {
+		for _, list := range []corev1.ResourceList{c.Resources.Requests, c.Resources.Limits} {
+			for name := range list {
+				if resourceName, found := strings.CutPrefix(string(name), prefix); found {
+					resources[resourceName] = true
+				}
+			}
+		}
+	}
+
+	names := make([]string, 0, len(resources))
+	for name := range resources {
+		names = append(names, name)
+	}
+	return names
+}
@@ -51,6 +51,25 @@ func updateFinalizers(c client.Client) {
 		}
 	}
 
+	policyList := &sriovnetworkv1.SriovNetworkNodePolicyList{}
+	err = c.List(context.TODO(), policyList, &client.ListOptions{Namespace: vars.Namespace})
+	if err != nil {
+		shutdownLog.Error(err, "Failed to list SriovNetworkNodePolicies")
+		return
+	}
+
+	for _, instance := range policyList.Items {
+		var found bool
+		instance.ObjectMeta.Finalizers, found = sriovnetworkv1.RemoveString(sriovnetworkv1.RESOURCEINUSEFINALIZERNAME, instance.ObjectMeta.Finalizers)
+		if found {
+			shutdownLog.Info("Clearing finalizers on SriovNetworkNodePolicy ", "namespace", instance.GetNamespace(), "name", instance.GetName())
+			err = c.Update(context.TODO(), &instance)
+			if err != nil {
+				shutdownLog.Error(err, "Failed to remove finalizer")
+			}
+		}
+	}
+
 	shutdownLog.Info("Done clearing finalizers on exit")
 }
 
@@ -6,6 +6,9 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
 	"syscall"
 
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -89,3 +92,28 @@ func GetChrootExtension() string {
 	}
 	return fmt.Sprintf("chroot %s%s", vars.FilesystemRoot, consts.Host)
 }
+
+// GetPlatformType returns the platform of the node from its provider ID.
+// The provider ID is matched with the platforms of vars.PlatformsMap in a sorted order, the generic virtual platform
+// is only used if the provider ID scheme is listed in vars.GenericVirtualProviders.
+func GetPlatformType(providerID string) consts.PlatformTypes {
+	providerID = strings.ToLower(providerID)
+	keys := make([]string, 0, len(vars.PlatformsMap))
+	for key := range vars.PlatformsMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if strings.Contains(providerID, strings.ToLower(key)) {
+			return vars.PlatformsMap[key]
+		}
+	}
+
+	scheme, _, found := strings.Cut(providerID, "://")
+	if found && slices.ContainsFunc(vars.GenericVirtualProviders, func(provider string) bool {
+		return strings.ToLower(strings.TrimSpace(provider)) == scheme
+	}) {
+		return consts.VirtualGeneric
+	}
+	return consts.Baremetal
+}
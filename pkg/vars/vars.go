@@ -36,12 +36,16 @@ var (
 		"openstack": consts.VirtualOpenStack,
 	}
 
+	// GenericVirtualProviders list of the node provider ID schemes, e.g. "kvm" or "libvirt", of the virtual machines
+	// with passthrough VFs handled by the generic virtual platform, the generic virtual platform is not used if empty
+	GenericVirtualProviders []string
+
 	// SupportedVfIds list of supported virtual functions IDs
 	// loaded on daemon initialization by reading the supported-nics configmap
 	SupportedVfIds []string
 
 	// DpdkDrivers supported DPDK drivers for virtual functions
-	DpdkDrivers = []string{"igb_uio", "vfio-pci", "uio_pci_generic"}
+	DpdkDrivers = []string{consts.DeviceTypeIgbUio, consts.DeviceTypeVfioPci, consts.DeviceTypeUioPciGeneric}
 
 	// InChroot global variable to mark that the config-daemon code is inside chroot on the host file system
 	InChroot = false
@@ -49,9 +53,17 @@ var (
 	// UsingSystemdMode global variable to mark the config-daemon is running on systemd mode
 	UsingSystemdMode = false
 
+	// UsingHybridMode global variable to mark the config-daemon applies the configuration live
+	// and also writes it for the systemd service that restores it on boot
+	UsingHybridMode = false
+
 	// ParallelNicConfig global variable to perform NIC configuration in parallel
 	ParallelNicConfig = false
 
+	// LiveVfResizeDrivers contains the PF drivers that can change sriov_numvfs while VFs are allocated,
+	// the PCI core of the upstream kernel rejects it, so the list is empty by default
+	LiveVfResizeDrivers []string
+
 	// ManageSoftwareBridges global variable which reflects state of manageSoftwareBridges feature
 	ManageSoftwareBridges = false
 
@@ -2,14 +2,17 @@ package webhook
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
-	"regexp"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 
 	v1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -18,6 +21,7 @@ import (
 
 	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
 )
 
@@ -25,6 +29,8 @@ const (
 	IntelID    = "8086"
 	MellanoxID = "15b3"
 	MlxMaxVFs  = 128
+	// the netdev names are limited to IFNAMSIZ-1 characters
+	MaxNetdevNameLen = 15
 )
 
 var (
@@ -98,7 +104,7 @@ func validateSriovNetworkPoolConfig(cr *sriovnetworkv1.SriovNetworkPoolConfig, o
 	log.Log.V(2).Info("validateSriovNetworkPoolConfig", "object", cr)
 	var warnings []string
 
-	if (cr.Spec.MaxUnavailable != nil || cr.Spec.NodeSelector != nil) && cr.Spec.OvsHardwareOffloadConfig.Name != "" {
+	if cr.Spec.MaxUnavailable != nil && cr.Spec.OvsHardwareOffloadConfig.Name != "" {
 		return false, warnings, fmt.Errorf("SriovOperatorConfig can't have both parallel configuration and OvsHardwareOffloadConfig")
 	}
 
@@ -127,6 +133,9 @@ func validateSriovNetworkNodePolicy(cr *sriovnetworkv1.SriovNetworkNodePolicy, o
 	}
 
 	if operation == v1.Delete {
+		if err := validateResourceInUse(cr, nil); err != nil {
+			return false, warnings, err
+		}
 		return true, warnings, nil
 	}
 
@@ -135,7 +144,8 @@ func validateSriovNetworkNodePolicy(cr *sriovnetworkv1.SriovNetworkNodePolicy, o
 		return admit, warnings, err
 	}
 
-	admit, err = dynamicValidateSriovNetworkNodePolicy(cr)
+	admit, dynamicWarnings, err := dynamicValidateSriovNetworkNodePolicy(cr)
+	warnings = append(warnings, dynamicWarnings...)
 	if err != nil {
 		return admit, warnings, err
 	}
@@ -143,132 +153,77 @@ func validateSriovNetworkNodePolicy(cr *sriovnetworkv1.SriovNetworkNodePolicy, o
 	return admit, warnings, nil
 }
 
+// staticValidateSriovNetworkNodePolicy checks that the NIC selected by the policy is in the supported NICs list,
+// the structural checks of the policy are done by the API server with the CEL validation rules of the CRD
 func staticValidateSriovNetworkNodePolicy(cr *sriovnetworkv1.SriovNetworkNodePolicy) (bool, error) {
-	var validString = regexp.MustCompile(`^[a-zA-Z0-9_]+$`)
-	if !validString.MatchString(cr.Spec.ResourceName) {
-		return false, fmt.Errorf("resource name \"%s\" contains invalid characters, the accepted syntax of the regular expressions is: \"^[a-zA-Z0-9_]+$\"", cr.Spec.ResourceName)
-	}
-
-	if cr.Spec.NicSelector.Vendor == "" && cr.Spec.NicSelector.DeviceID == "" && len(cr.Spec.NicSelector.PfNames) == 0 && len(cr.Spec.NicSelector.RootDevices) == 0 && cr.Spec.NicSelector.NetFilter == "" {
-		return false, fmt.Errorf("at least one of these parameters (vendor, deviceID, pfNames, rootDevices or netFilter) has to be defined in nicSelector in CR %s", cr.GetName())
-	}
-
-	devMode := false
 	if os.Getenv("DEV_MODE") == "TRUE" {
-		devMode = true
 		log.Log.V(0).Info("dev mode enabled - Admitting not supported NICs")
+		return true, nil
 	}
 
-	if !devMode {
-		if cr.Spec.NicSelector.Vendor != "" {
-			if !sriovnetworkv1.IsSupportedVendor(cr.Spec.NicSelector.Vendor) {
-				return false, fmt.Errorf("vendor %s is not supported", cr.Spec.NicSelector.Vendor)
-			}
-			if cr.Spec.NicSelector.DeviceID != "" {
-				if !sriovnetworkv1.IsSupportedModel(cr.Spec.NicSelector.Vendor, cr.Spec.NicSelector.DeviceID) {
-					return false, fmt.Errorf("vendor/device %s/%s is not supported", cr.Spec.NicSelector.Vendor, cr.Spec.NicSelector.DeviceID)
-				}
-			}
-		} else if cr.Spec.NicSelector.DeviceID != "" {
-			if !sriovnetworkv1.IsSupportedDevice(cr.Spec.NicSelector.DeviceID) {
-				return false, fmt.Errorf("device %s is not supported", cr.Spec.NicSelector.DeviceID)
-			}
+	if cr.Spec.NicSelector.Vendor != "" {
+		if !sriovnetworkv1.IsSupportedVendor(cr.Spec.NicSelector.Vendor) {
+			return false, fmt.Errorf("vendor %s is not supported", cr.Spec.NicSelector.Vendor)
 		}
-	}
-
-	if len(cr.Spec.NicSelector.PfNames) > 0 {
-		for _, pf := range cr.Spec.NicSelector.PfNames {
-			if strings.Contains(pf, "#") {
-				fields := strings.Split(pf, "#")
-				if len(fields) != 2 {
-					return false, fmt.Errorf("failed to parse %s PF name in nicSelector, probably incorrect separator character usage", pf)
-				}
-				rng := strings.Split(fields[1], "-")
-				if len(rng) != 2 {
-					return false, fmt.Errorf("failed to parse %s PF name nicSelector, probably incorrect range character usage", pf)
-				}
-				rngSt, err := strconv.Atoi(rng[0])
-				if err != nil {
-					return false, fmt.Errorf("failed to parse %s PF name nicSelector, start range is incorrect", pf)
-				}
-				rngEnd, err := strconv.Atoi(rng[1])
-				if err != nil {
-					return false, fmt.Errorf("failed to parse %s PF name nicSelector, end range is incorrect", pf)
-				}
-				if rngEnd < rngSt {
-					return false, fmt.Errorf("failed to parse %s PF name nicSelector, end range shall not be smaller than start range", pf)
-				}
-				if !(rngEnd < cr.Spec.NumVfs) {
-					return false, fmt.Errorf("failed to parse %s PF name nicSelector, end range exceeds the maximum VF index ", pf)
-				}
+		if cr.Spec.NicSelector.DeviceID != "" {
+			if !sriovnetworkv1.IsSupportedModel(cr.Spec.NicSelector.Vendor, cr.Spec.NicSelector.DeviceID) {
+				return false, fmt.Errorf("vendor/device %s/%s is not supported", cr.Spec.NicSelector.Vendor, cr.Spec.NicSelector.DeviceID)
 			}
 		}
-	}
-
-	// To configure RoCE on baremetal or virtual machine:
-	// BM: DeviceType = netdevice && isRdma = true
-	// VM: DeviceType = vfio-pci && isRdma = false
-	if cr.Spec.DeviceType == consts.DeviceTypeVfioPci && cr.Spec.IsRdma {
-		return false, fmt.Errorf("'deviceType: vfio-pci' conflicts with 'isRdma: true'; Set 'deviceType' to (string)'netdevice' Or Set 'isRdma' to (bool)'false'")
-	}
-
-	// switchdev mode can be used only with ethernet links
-	if cr.Spec.LinkType != "" && !strings.EqualFold(cr.Spec.LinkType, consts.LinkTypeETH) && cr.Spec.EswitchMode == sriovnetworkv1.ESwithModeSwitchDev {
-		return false, fmt.Errorf("'eSwitchMode: switchdev' can be used only with ethernet links")
-	}
-
-	// vdpa: deviceType must be set to 'netdevice'
-	if cr.Spec.DeviceType != consts.DeviceTypeNetDevice && (cr.Spec.VdpaType == consts.VdpaTypeVirtio || cr.Spec.VdpaType == consts.VdpaTypeVhost) {
-		return false, fmt.Errorf("'deviceType: %s' conflicts with '%s'; Set 'deviceType' to (string)'netdevice' Or Remove 'vdpaType'", cr.Spec.DeviceType, cr.Spec.VdpaType)
-	}
-	// vdpa: device must be configured in switchdev mode
-	if (cr.Spec.VdpaType == consts.VdpaTypeVirtio || cr.Spec.VdpaType == consts.VdpaTypeVhost) && cr.Spec.EswitchMode != sriovnetworkv1.ESwithModeSwitchDev {
-		return false, fmt.Errorf("vdpa requires the device to be configured in switchdev mode")
-	}
-	// software bridge management: device must be configured in switchdev mode
-	if !cr.Spec.Bridge.IsEmpty() && cr.Spec.EswitchMode != sriovnetworkv1.ESwithModeSwitchDev {
-		return false, fmt.Errorf("software bridge management requires the device to be configured in switchdev mode")
-	}
-	// software bridge management: device can't be externally managed
-	if !cr.Spec.Bridge.IsEmpty() && cr.Spec.ExternallyManaged {
-		return false, fmt.Errorf("software bridge management can't be used when the device externally managed")
+	} else if cr.Spec.NicSelector.DeviceID != "" {
+		if !sriovnetworkv1.IsSupportedDevice(cr.Spec.NicSelector.DeviceID) {
+			return false, fmt.Errorf("device %s is not supported", cr.Spec.NicSelector.DeviceID)
+		}
 	}
 	return true, nil
 }
 
-func dynamicValidateSriovNetworkNodePolicy(cr *sriovnetworkv1.SriovNetworkNodePolicy) (bool, error) {
+func dynamicValidateSriovNetworkNodePolicy(cr *sriovnetworkv1.SriovNetworkNodePolicy) (bool, []string, error) {
 	nodesSelected = false
 	interfaceSelected = false
 	nodeInterfaceErrorList := make(map[string][]string)
+	impact := &policyImpact{drainNodes: map[string]bool{}, rebootNodes: map[string]bool{}}
 
 	nodeList, err := kubeclient.CoreV1().Nodes().List(context.Background(), metav1.ListOptions{
 		LabelSelector: labels.Set(cr.Spec.NodeSelector).String(),
 	})
 	if err != nil {
-		return false, err
+		return false, nil, err
 	}
 	nsList := &sriovnetworkv1.SriovNetworkNodeStateList{}
 	err = client.List(context.Background(), nsList, &runtimeclient.ListOptions{Namespace: namespace})
 	if err != nil {
-		return false, err
+		return false, nil, err
 	}
 	npList := &sriovnetworkv1.SriovNetworkNodePolicyList{}
 	err = client.List(context.Background(), npList, &runtimeclient.ListOptions{Namespace: namespace})
 	if err != nil {
-		return false, err
+		return false, nil, err
 	}
+	var mergeWarnings []string
 	for _, node := range nodeList.Items {
 		if cr.Selected(&node) {
 			nodesSelected = true
 			err = validatePolicyForNodeStateAndPolicy(nsList, npList, &node, cr, nodeInterfaceErrorList)
 			if err != nil {
-				return false, err
+				return false, nil, err
+			}
+			for _, ns := range nsList.Items {
+				if ns.GetName() != node.GetName() {
+					continue
+				}
+				nodeWarnings, merged, err := validatePolicyMerge(cr, npList, &ns, &node)
+				if err != nil {
+					return false, nil, err
+				}
+				mergeWarnings = sriovnetworkv1.UniqueAppend(mergeWarnings, nodeWarnings...)
+				impact.addNodeState(cr, &ns, merged)
 			}
 		}
 	}
 
 	if !nodesSelected {
-		return false, fmt.Errorf("no matched node is selected by the nodeSelector in CR %s", cr.GetName())
+		return false, nil, fmt.Errorf("no matched node is selected by the nodeSelector in CR %s", cr.GetName())
 	}
 	if !interfaceSelected {
 		for nodeName, messages := range nodeInterfaceErrorList {
@@ -276,10 +231,32 @@ func dynamicValidateSriovNetworkNodePolicy(cr *sriovnetworkv1.SriovNetworkNodePo
 				log.Log.V(2).Info("interface selection errors", "nodeName", nodeName, "message", message)
 			}
 		}
-		return false, fmt.Errorf("no supported NIC is selected by the nicSelector in CR %s", cr.GetName())
+		return false, nil, fmt.Errorf("no supported NIC is selected by the nicSelector in CR %s", cr.GetName())
 	}
 
-	return true, nil
+	var previous *sriovnetworkv1.SriovNetworkNodePolicy
+	for i := range npList.Items {
+		if npList.Items[i].GetName() == cr.GetName() {
+			previous = &npList.Items[i]
+			break
+		}
+	}
+	removedNodes, err := getResourceRemovedNodes(previous, cr)
+	if err != nil {
+		return false, nil, err
+	}
+	// the pods of the nodes affected by the change are listed once for the usage validation and the warnings
+	usage, err := getResourceUsage(removedNodes, impact.drainNodes, impact.rebootNodes)
+	if err != nil {
+		if len(removedNodes) > 0 {
+			return false, nil, fmt.Errorf("failed to get the usage of resource %s: %v", previous.Spec.ResourceName, err)
+		}
+		log.Log.Error(err, "failed to get the usage of the resources", "policy", cr.GetName())
+	}
+	if err := checkResourceInUse(previous, removedNodes, usage); err != nil {
+		return false, nil, err
+	}
+	return true, append(mergeWarnings, impact.warnings(cr, previous, usage)...), nil
 }
 
 func validatePolicyForNodeStateAndPolicy(nsList *sriovnetworkv1.SriovNetworkNodeStateList, npList *sriovnetworkv1.SriovNetworkNodePolicyList, node *corev1.Node, cr *sriovnetworkv1.SriovNetworkNodePolicy, nodeInterfaceErrorList map[string][]string) error {
@@ -341,6 +318,14 @@ func validatePolicyForNodeState(policy *sriovnetworkv1.SriovNetworkNodePolicy, s
 					return nil, fmt.Errorf("LinkType(%s) in CR %s is not equal to the LinkType for the PF externally value(%s)", policy.Spec.LinkType, policy.GetName(), iface.LinkType)
 				}
 			}
+			// the name of the last VF is the longest one
+			if policy.Spec.VfNameTemplate != "" {
+				vfName := sriovnetworkv1.RenderVfName(policy.Spec.VfNameTemplate, iface.Name, policy.Spec.NumVfs-1)
+				if len(vfName) > MaxNetdevNameLen {
+					return nil, fmt.Errorf("VF name %s generated from the vfNameTemplate in CR %s exceeds the maximum length(%d) interface(%s)",
+						vfName, policy.GetName(), MaxNetdevNameLen, iface.Name)
+				}
+			}
 			// vdpa: only mellanox cards are supported
 			if (policy.Spec.VdpaType == consts.VdpaTypeVirtio || policy.Spec.VdpaType == consts.VdpaTypeVhost) && iface.Vendor != MellanoxID {
 				return nil, fmt.Errorf("vendor(%s) in CR %s not supported for vdpa interface(%s)", iface.Vendor, policy.GetName(), iface.Name)
@@ -383,6 +368,168 @@ func validatePolicyForNodePolicy(current *sriovnetworkv1.SriovNetworkNodePolicy,
 	return nil
 }
 
+// validatePolicyMerge simulates on the node state the priority merge of the policies done by the operator
+// and checks the conflicts involving the policy. The VF groups overridden by a policy with the same priority
+// and the PF settings that can't be merged are rejected, the VF groups overridden by a policy with a higher
+// priority and the merged MTUs are only reported as warnings. The policies joining the same bridge must have
+// the same bridge-level settings. The node state with the merged spec is returned.
+func validatePolicyMerge(cr *sriovnetworkv1.SriovNetworkNodePolicy, npList *sriovnetworkv1.SriovNetworkNodePolicyList,
+	state *sriovnetworkv1.SriovNetworkNodeState, node *corev1.Node) ([]string, *sriovnetworkv1.SriovNetworkNodeState, error) {
+	policies := []sriovnetworkv1.SriovNetworkNodePolicy{*cr}
+	for _, np := range npList.Items {
+		if np.GetName() != cr.GetName() && np.GetName() != consts.DefaultPolicyName {
+			policies = append(policies, np)
+		}
+	}
+	sort.Sort(sriovnetworkv1.ByPriority(policies))
+	priorities := map[string]int{}
+	for _, p := range policies {
+		priorities[p.GetName()] = p.Spec.Priority
+	}
+
+	var warnings []string
+	// policies attaching the PFs of the node to a shared bridge, by bridge name
+	sharedBridges := map[string][]*sriovnetworkv1.SriovNetworkNodePolicy{}
+	merged := &sriovnetworkv1.SriovNetworkNodeState{Status: state.Status}
+	// same initial value as the previous policy priority used by the operator
+	ppp := 100
+	for i := range policies {
+		p := &policies[i]
+		if !p.Selected(node) {
+			continue
+		}
+		if name := sharedBridgeName(p); name != "" && selectsInterface(p, state) {
+			for _, other := range sharedBridges[name] {
+				if p.GetName() != cr.GetName() && other.GetName() != cr.GetName() {
+					continue
+				}
+				if conflict := sharedBridgeConflict(other, p); conflict != nil {
+					return nil, nil, fmt.Errorf("%v on node %s", conflict, node.GetName())
+				}
+			}
+			sharedBridges[name] = append(sharedBridges[name], p)
+		}
+		before := map[string]*sriovnetworkv1.Interface{}
+		for j := range merged.Spec.Interfaces {
+			before[merged.Spec.Interfaces[j].PciAddress] = merged.Spec.Interfaces[j].DeepCopy()
+		}
+		if err := p.Apply(merged, ppp == p.Spec.Priority); err != nil {
+			return nil, nil, err
+		}
+		ppp = p.Spec.Priority
+
+		for _, iface := range merged.Spec.Interfaces {
+			previous, found := before[iface.PciAddress]
+			if !found || len(iface.VfGroups) == 0 || iface.VfGroups[0].PolicyName != p.GetName() {
+				// the interface is not configured by the policy or by a previous one
+				continue
+			}
+			group := iface.VfGroups[0]
+			for _, gr := range previous.VfGroups {
+				if p.GetName() != cr.GetName() && gr.PolicyName != cr.GetName() {
+					continue
+				}
+				if vfGroupInList(gr, iface.VfGroups) {
+					if conflict := mergedPfConflict(p, previous, &iface, &gr); conflict != nil {
+						return nil, nil, fmt.Errorf("%v on node %s", conflict, node.GetName())
+					}
+					if gr.Mtu != 0 && group.Mtu != 0 && gr.Mtu != group.Mtu {
+						warnings = append(warnings, fmt.Sprintf("policies %s and %s set different MTUs on PF %s for VF ranges %s and %s, "+
+							"the PF MTU is set to %d", gr.PolicyName, p.GetName(), iface.Name, gr.VfRange, group.VfRange, iface.Mtu))
+					}
+					continue
+				}
+
+				message := fmt.Sprintf("VF range %s of policy %s (resource %s, deviceType %s) on PF %s is overridden by VF range %s of policy %s (resource %s, deviceType %s)",
+					gr.VfRange, gr.PolicyName, gr.ResourceName, gr.DeviceType, iface.Name, group.VfRange, p.GetName(), group.ResourceName, group.DeviceType)
+				if priorities[gr.PolicyName] == p.Spec.Priority {
+					return nil, nil, fmt.Errorf("%s on node %s, the policies have the same priority %d", message, node.GetName(), p.Spec.Priority)
+				}
+				warnings = sriovnetworkv1.UniqueAppend(warnings, message+" with a higher priority")
+			}
+		}
+	}
+	return warnings, merged, nil
+}
+
+// mergedPfConflict returns an error if the PF settings of the policy are different from the ones
+// of the previous policy which VF group is merged with it, the merge keeps only the settings of the policy
+func mergedPfConflict(p *sriovnetworkv1.SriovNetworkNodePolicy, previous, iface *sriovnetworkv1.Interface, gr *sriovnetworkv1.VfGroup) error {
+	conflict := func(field, previousValue, value string) error {
+		return fmt.Errorf("policies %s and %s set different %s on PF %s for VF ranges %s and %s (%s and %s)",
+			gr.PolicyName, p.GetName(), field, iface.Name, gr.VfRange, iface.VfGroups[0].VfRange, previousValue, value)
+	}
+	if sriovnetworkv1.GetEswitchModeFromSpec(previous) != sriovnetworkv1.GetEswitchModeFromSpec(iface) {
+		return conflict("eswitchMode", sriovnetworkv1.GetEswitchModeFromSpec(previous), sriovnetworkv1.GetEswitchModeFromSpec(iface))
+	}
+	if previous.LinkType != "" && iface.LinkType != "" && !strings.EqualFold(previous.LinkType, iface.LinkType) {
+		return conflict("linkType", previous.LinkType, iface.LinkType)
+	}
+	if previous.ExternallyManaged != iface.ExternallyManaged {
+		return conflict("externallyManaged", strconv.FormatBool(previous.ExternallyManaged), strconv.FormatBool(iface.ExternallyManaged))
+	}
+	if previous.VfNameTemplate != "" && iface.VfNameTemplate != "" && previous.VfNameTemplate != iface.VfNameTemplate {
+		return conflict("vfNameTemplate", previous.VfNameTemplate, iface.VfNameTemplate)
+	}
+	return nil
+}
+
+// sharedBridgeName returns the name of the bridge shared by the policies, empty if the policy doesn't join a shared bridge
+func sharedBridgeName(p *sriovnetworkv1.SriovNetworkNodePolicy) string {
+	if p.Spec.Bridge.OVS != nil {
+		return p.Spec.Bridge.OVS.BridgeName
+	}
+	if p.Spec.Bridge.Linux != nil {
+		return p.Spec.Bridge.Linux.BridgeName
+	}
+	return ""
+}
+
+// selectsInterface returns true if the policy selects an interface of the node
+func selectsInterface(p *sriovnetworkv1.SriovNetworkNodePolicy, state *sriovnetworkv1.SriovNetworkNodeState) bool {
+	for i := range state.Status.Interfaces {
+		if p.Spec.NicSelector.Selected(&state.Status.Interfaces[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// sharedBridgeConflict returns an error if the policies join the same bridge with different bridge-level settings,
+// the operator keeps only the settings of the policy applied last
+func sharedBridgeConflict(previous, p *sriovnetworkv1.SriovNetworkNodePolicy) error {
+	conflict := func(field string) error {
+		return fmt.Errorf("policies %s and %s set different %s for the shared bridge %s",
+			previous.GetName(), p.GetName(), field, sharedBridgeName(p))
+	}
+	previousBridge, bridge := previous.Spec.Bridge, p.Spec.Bridge
+	if (previousBridge.OVS == nil) != (bridge.OVS == nil) {
+		return conflict("bridge types")
+	}
+	if bridge.OVS != nil {
+		if !equality.Semantic.DeepEqual(previousBridge.OVS.Bridge, bridge.OVS.Bridge) {
+			return conflict("bridge.ovs.bridge settings")
+		}
+		if !equality.Semantic.DeepEqual(previousBridge.OVS.PortBond, bridge.OVS.PortBond) {
+			return conflict("bridge.ovs.portBond settings")
+		}
+		return nil
+	}
+	if !equality.Semantic.DeepEqual(previousBridge.Linux.Bridge, bridge.Linux.Bridge) {
+		return conflict("bridge.linux.bridge settings")
+	}
+	return nil
+}
+
+func vfGroupInList(group sriovnetworkv1.VfGroup, groups []sriovnetworkv1.VfGroup) bool {
+	for _, gr := range groups {
+		if gr.PolicyName == group.PolicyName && gr.ResourceName == group.ResourceName && gr.VfRange == group.VfRange {
+			return true
+		}
+	}
+	return false
+}
+
 func validatePfNames(current *sriovnetworkv1.SriovNetworkNodePolicy, previous *sriovnetworkv1.SriovNetworkNodePolicy) error {
 	for _, curPf := range current.Spec.NicSelector.PfNames {
 		curName, curRngSt, curRngEnd, err := sriovnetworkv1.ParseVfRange(curPf)
@@ -476,13 +623,290 @@ func validateNicModel(selector *sriovnetworkv1.SriovNetworkNicSelector, iface *s
 	}
 
 	// Check the vendor and device ID of the VF only if we are on a virtual environment
-	for key := range vars.PlatformsMap {
-		if strings.Contains(strings.ToLower(node.Spec.ProviderID), strings.ToLower(key)) &&
-			selector.NetFilter != "" && selector.NetFilter == iface.NetFilter &&
-			sriovnetworkv1.IsVfSupportedModel(iface.Vendor, iface.DeviceID) {
+	if utils.GetPlatformType(node.Spec.ProviderID) != consts.Baremetal &&
+		selector.NetFilter != "" && iface.MatchNetFilter(selector.NetFilter) &&
+		sriovnetworkv1.IsVfSupportedModel(iface.Vendor, iface.DeviceID) {
+		return nil
+	}
+
+	return fmt.Errorf("vendor and device ID is not in supported list")
+}
+
+// validateSriovNetwork checks the CNI configuration rendered from the SriovNetwork
+func validateSriovNetwork(cr *sriovnetworkv1.SriovNetwork, operation v1.Operation) (bool, []string, error) {
+	log.Log.V(2).Info("validateSriovNetwork", "object", cr)
+	var warnings []string
+
+	if operation == v1.Delete {
+		if err := validateNetworkInUse(cr, cr.Spec.NetworkNamespace); err != nil {
+			return false, warnings, err
+		}
+		return true, warnings, nil
+	}
+
+	if err := validateNetworkCniConfig(cr.Spec.IPAM, cr.Spec.MetaPluginsConfig, cr.Spec.Capabilities); err != nil {
+		return false, warnings, err
+	}
+
+	// max_tx_rate 0 means the rate is not limited
+	if cr.Spec.MinTxRate != nil && cr.Spec.MaxTxRate != nil && *cr.Spec.MaxTxRate > 0 &&
+		*cr.Spec.MinTxRate > *cr.Spec.MaxTxRate {
+		return false, warnings, fmt.Errorf("minTxRate %d can't be greater than maxTxRate %d", *cr.Spec.MinTxRate, *cr.Spec.MaxTxRate)
+	}
+
+	warnings = append(warnings, validateNetworkResourceName(cr.Spec.ResourceName)...)
+	return true, warnings, nil
+}
+
+// validateSriovIBNetwork checks the CNI configuration rendered from the SriovIBNetwork
+func validateSriovIBNetwork(cr *sriovnetworkv1.SriovIBNetwork, operation v1.Operation) (bool, []string, error) {
+	log.Log.V(2).Info("validateSriovIBNetwork", "object", cr)
+	var warnings []string
+
+	if operation == v1.Delete {
+		if err := validateNetworkInUse(cr, cr.Spec.NetworkNamespace); err != nil {
+			return false, warnings, err
+		}
+		return true, warnings, nil
+	}
+
+	if err := validateNetworkCniConfig(cr.Spec.IPAM, cr.Spec.MetaPluginsConfig, cr.Spec.Capabilities); err != nil {
+		return false, warnings, err
+	}
+
+	warnings = append(warnings, validateNetworkResourceName(cr.Spec.ResourceName)...)
+	return true, warnings, nil
+}
+
+// validateOVSNetwork checks the CNI configuration rendered from the OVSNetwork
+func validateOVSNetwork(cr *sriovnetworkv1.OVSNetwork, operation v1.Operation) (bool, []string, error) {
+	log.Log.V(2).Info("validateOVSNetwork", "object", cr)
+	var warnings []string
+
+	if operation == v1.Delete {
+		if err := validateNetworkInUse(cr, cr.Spec.NetworkNamespace); err != nil {
+			return false, warnings, err
+		}
+		return true, warnings, nil
+	}
+
+	if err := validateNetworkCniConfig(cr.Spec.IPAM, cr.Spec.MetaPluginsConfig, cr.Spec.Capabilities); err != nil {
+		return false, warnings, err
+	}
+
+	warnings = append(warnings, validateNetworkResourceName(cr.Spec.ResourceName)...)
+	return true, warnings, nil
+}
+
+// validateNetworkCniConfig parses the JSON fields of the network the same way they are inlined
+// into the NetworkAttachmentDefinition by RenderNetAttDef
+func validateNetworkCniConfig(ipam, metaPlugins, capabilities string) error {
+	if ipam != "" {
+		ipamConf := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(strings.Join(strings.Fields(ipam), "")), &ipamConf); err != nil {
+			return fmt.Errorf("invalid ipam configuration, must be a JSON object: %v", err)
+		}
+	}
+
+	if capabilities != "" {
+		capabilitiesConf := map[string]bool{}
+		if err := json.Unmarshal([]byte(capabilities), &capabilitiesConf); err != nil {
+			return fmt.Errorf("invalid capabilities, must be a JSON object with boolean values: %v", err)
+		}
+	}
+
+	if metaPlugins != "" {
+		// the meta plugins are appended to the plugins list after the main plugin
+		plugins := []map[string]interface{}{}
+		if err := json.Unmarshal([]byte("["+metaPlugins+"]"), &plugins); err != nil {
+			return fmt.Errorf("invalid metaPlugins, must be a comma separated list of JSON objects: %v", err)
+		}
+		for i, plugin := range plugins {
+			if pluginType, ok := plugin["type"].(string); !ok || pluginType == "" {
+				return fmt.Errorf("invalid metaPlugins, plugin %d has no type", i)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateNetworkInUse rejects the deletion of the network if running pods are attached to it
+func validateNetworkInUse(cr metav1.Object, networkNamespace string) error {
+	if cr.GetAnnotations()[consts.ForceDeleteAnnotation] == "true" {
+		log.Log.Info("skipping network usage validation", "network", cr.GetName(), "annotation", consts.ForceDeleteAnnotation)
+		return nil
+	}
+	if networkNamespace == "" {
+		networkNamespace = cr.GetNamespace()
+	}
+
+	pods, err := utils.GetPodsUsingNetwork(context.Background(), client, cr.GetName(), networkNamespace)
+	if err != nil {
+		return fmt.Errorf("failed to get the pods attached to network %s: %v", cr.GetName(), err)
+	}
+	if len(pods) > 0 {
+		return fmt.Errorf("network %s in namespace %s is used by %d running pod(s) %v, "+
+			"delete the pods or set the %s annotation to \"true\" to force the deletion",
+			cr.GetName(), networkNamespace, len(pods), pods, consts.ForceDeleteAnnotation)
+	}
+	return nil
+}
+
+// validateNetworkResourceName returns a warning if no SriovNetworkNodePolicy provides the resource of the network
+func validateNetworkResourceName(resourceName string) []string {
+	policyList := &sriovnetworkv1.SriovNetworkNodePolicyList{}
+	err := client.List(context.Background(), policyList, &runtimeclient.ListOptions{Namespace: vars.Namespace})
+	if err != nil {
+		log.Log.Error(err, "failed to list SriovNetworkNodePolicies")
+		return []string{fmt.Sprintf("can't check that resource %s is provided by a SriovNetworkNodePolicy: %v", resourceName, err)}
+	}
+	for _, policy := range policyList.Items {
+		if policy.Spec.ResourceName == resourceName {
 			return nil
 		}
 	}
+	return []string{fmt.Sprintf("no SriovNetworkNodePolicy in %s namespace provides the resource %s, "+
+		"pods using the network can't be scheduled until it's created", vars.Namespace, resourceName)}
+}
 
-	return fmt.Errorf("vendor and device ID is not in supported list")
+// policyImpact contains the nodes disrupted by the policy change
+type policyImpact struct {
+	// nodes where the VFs are re-created, the pods using them are evicted
+	drainNodes map[string]bool
+	// nodes that are rebooted to change the eswitch mode of the PFs
+	rebootNodes map[string]bool
+}
+
+// addNodeState checks the interfaces configured by the policy in the merged spec of the node state
+// and records the node if the merged spec changes the number of VFs or the eswitch mode of the interfaces
+func (p *policyImpact) addNodeState(policy *sriovnetworkv1.SriovNetworkNodePolicy,
+	state, merged *sriovnetworkv1.SriovNetworkNodeState) {
+	for i := range merged.Spec.Interfaces {
+		iface := &merged.Spec.Interfaces[i]
+		if !slices.ContainsFunc(iface.VfGroups, func(gr sriovnetworkv1.VfGroup) bool { return gr.PolicyName == policy.GetName() }) {
+			continue
+		}
+		for j := range state.Status.Interfaces {
+			status := &state.Status.Interfaces[j]
+			if status.PciAddress != iface.PciAddress {
+				continue
+			}
+			if !iface.ExternallyManaged && iface.NumVfs != status.NumVfs {
+				p.drainNodes[state.GetName()] = true
+			}
+			if sriovnetworkv1.GetEswitchModeFromSpec(iface) != sriovnetworkv1.GetEswitchModeFromStatus(status) {
+				p.rebootNodes[state.GetName()] = true
+			}
+		}
+	}
+}
+
+// warnings returns the warnings about the nodes and the pods disrupted by the policy change
+func (p *policyImpact) warnings(cr, previous *sriovnetworkv1.SriovNetworkNodePolicy, usage utils.ResourceUsage) []string {
+	var warnings []string
+	if len(p.drainNodes) == 0 && len(p.rebootNodes) == 0 {
+		return warnings
+	}
+
+	resourceNames := []string{cr.Spec.ResourceName}
+	if previous != nil && previous.Spec.ResourceName != cr.Spec.ResourceName {
+		resourceNames = append(resourceNames, previous.Spec.ResourceName)
+	}
+	countPods := func(nodes map[string]bool) int {
+		count := 0
+		for _, resourceName := range resourceNames {
+			count += usage.Pods(resourceName, nodes)
+		}
+		return count
+	}
+
+	if len(p.drainNodes) > 0 {
+		warnings = append(warnings, fmt.Sprintf("%s changes the number of VFs on %d node(s), "+
+			"the nodes will be drained and %d pod(s) using the %s resource will be evicted",
+			cr.GetName(), len(p.drainNodes), countPods(p.drainNodes), strings.Join(resourceNames, ",")))
+	}
+	if len(p.rebootNodes) > 0 {
+		warnings = append(warnings, fmt.Sprintf("%s changes the eswitch mode on %d node(s), "+
+			"the nodes will be rebooted and %d pod(s) using the %s resource will be evicted",
+			cr.GetName(), len(p.rebootNodes), countPods(p.rebootNodes), strings.Join(resourceNames, ",")))
+	}
+	return warnings
+}
+
+// getResourceUsage returns the usage of the resources on the union of the nodes with a single list of the pods
+func getResourceUsage(nodeSets ...map[string]bool) (utils.ResourceUsage, error) {
+	nodes := map[string]bool{}
+	for _, nodeSet := range nodeSets {
+		for nodeName := range nodeSet {
+			nodes[nodeName] = true
+		}
+	}
+	if len(nodes) == 0 {
+		return utils.ResourceUsage{}, nil
+	}
+	return utils.GetResourceUsage(context.Background(), client, nodes)
+}
+
+// validateResourceInUse rejects the deletion of the previous policy, or its update to the current one,
+// if the resource is still used by running pods on the nodes where no policy would provide it anymore
+func validateResourceInUse(previous, current *sriovnetworkv1.SriovNetworkNodePolicy) error {
+	removedNodes, err := getResourceRemovedNodes(previous, current)
+	if err != nil {
+		return err
+	}
+	usage, err := getResourceUsage(removedNodes)
+	if err != nil {
+		return fmt.Errorf("failed to get the usage of resource %s: %v", previous.Spec.ResourceName, err)
+	}
+	return checkResourceInUse(previous, removedNodes, usage)
+}
+
+// getResourceRemovedNodes returns the nodes where the resource of the previous policy would not be provided anymore
+// after its deletion or its update to the current one, nil is returned if the usage of the resource is not checked
+func getResourceRemovedNodes(previous, current *sriovnetworkv1.SriovNetworkNodePolicy) (map[string]bool, error) {
+	if previous == nil || previous.GetNamespace() != vars.Namespace {
+		return nil, nil
+	}
+	requested := current
+	if requested == nil {
+		requested = previous
+	}
+	if requested.GetAnnotations()[consts.ForceDeleteAnnotation] == "true" {
+		log.Log.Info("skipping resource usage validation", "policy", requested.GetName(), "annotation", consts.ForceDeleteAnnotation)
+		return nil, nil
+	}
+
+	npList := &sriovnetworkv1.SriovNetworkNodePolicyList{}
+	err := client.List(context.Background(), npList, &runtimeclient.ListOptions{Namespace: namespace})
+	if err != nil {
+		return nil, err
+	}
+	remaining := []sriovnetworkv1.SriovNetworkNodePolicy{}
+	for _, np := range npList.Items {
+		if np.GetName() != previous.GetName() && np.GetDeletionTimestamp().IsZero() {
+			remaining = append(remaining, np)
+		}
+	}
+	if current != nil {
+		remaining = append(remaining, *current)
+	}
+	nodeList := &corev1.NodeList{}
+	if err := client.List(context.Background(), nodeList); err != nil {
+		return nil, err
+	}
+	return utils.RemovedResourceNodes(previous, remaining, nodeList.Items), nil
+}
+
+// checkResourceInUse returns an error if the resource of the previous policy is used by running pods on the removed nodes
+func checkResourceInUse(previous *sriovnetworkv1.SriovNetworkNodePolicy, removedNodes map[string]bool, usage utils.ResourceUsage) error {
+	if len(removedNodes) == 0 {
+		return nil
+	}
+	if pods := usage.Pods(previous.Spec.ResourceName, removedNodes); pods > 0 {
+		return fmt.Errorf("resource %s is used by %d running pod(s) on the nodes where it would be removed, "+
+			"delete the pods or set the %s annotation to \"true\" to force the change",
+			previous.Spec.ResourceName, pods, consts.ForceDeleteAnnotation)
+	}
+	return nil
 }
@@ -2,19 +2,33 @@ package webhook
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"testing"
 
 	. "github.com/onsi/gomega"
 
+	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/cel"
+	structuraldefaulting "k8s.io/apiextensions-apiserver/pkg/apiserver/schema/defaulting"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	celconfig "k8s.io/apiserver/pkg/apis/cel"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
 
 	. "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	constants "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
 )
 
@@ -167,7 +181,7 @@ func TestValidateSriovOperatorConfigWithDefaultOperatorConfig(t *testing.T) {
 	g := NewGomegaWithT(t)
 
 	config := newDefaultOperatorConfig()
-	client = fake.NewClientBuilder().WithScheme(vars.Scheme).Build()
+	client = newFakeClientBuilder().Build()
 
 	ok, w, err := validateSriovOperatorConfig(config, "DELETE")
 	g.Expect(err).NotTo(HaveOccurred())
@@ -201,7 +215,7 @@ func TestValidateSriovOperatorConfigDisableDrain(t *testing.T) {
 		},
 	}
 
-	client = fake.NewClientBuilder().WithScheme(vars.Scheme).WithObjects(config, nodeState).Build()
+	client = newFakeClientBuilder().WithObjects(config, nodeState).Build()
 
 	config.Spec.DisableDrain = true
 	ok, _, err := validateSriovOperatorConfig(config, "UPDATE")
@@ -222,7 +236,7 @@ func TestValidateSriovNetworkPoolConfigWithDefault(t *testing.T) {
 	g := NewGomegaWithT(t)
 
 	config := newDefaultNetworkPoolConfig()
-	client = fake.NewClientBuilder().WithScheme(vars.Scheme).Build()
+	client = newFakeClientBuilder().Build()
 
 	ok, _, err := validateSriovNetworkPoolConfig(config, "DELETE")
 	g.Expect(err).ToNot(HaveOccurred())
@@ -242,7 +256,7 @@ func TestValidateSriovNetworkPoolConfigWithParallelAndHWOffload(t *testing.T) {
 
 	config := newDefaultNetworkPoolConfig()
 	config.Spec.OvsHardwareOffloadConfig.Name = "test"
-	client = fake.NewClientBuilder().WithScheme(vars.Scheme).Build()
+	client = newFakeClientBuilder().Build()
 
 	ok, _, err := validateSriovNetworkPoolConfig(config, "UPDATE")
 	g.Expect(err).To(HaveOccurred())
@@ -253,6 +267,19 @@ func TestValidateSriovNetworkPoolConfigWithParallelAndHWOffload(t *testing.T) {
 	g.Expect(ok).To(BeFalse())
 }
 
+func TestValidateSriovNetworkPoolConfigWithNodeSelectorAndHWOffload(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	config := newDefaultNetworkPoolConfig()
+	config.Spec.MaxUnavailable = nil
+	config.Spec.OvsHardwareOffloadConfig = OvsHardwareOffloadConfig{Name: "test", TcPolicy: "skip_sw"}
+	client = newFakeClientBuilder().Build()
+
+	ok, _, err := validateSriovNetworkPoolConfig(config, "CREATE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+}
+
 func TestValidateSriovNetworkNodePolicyWithDefaultPolicy(t *testing.T) {
 	var err error
 	var ok bool
@@ -847,123 +874,6 @@ func TestStaticValidateSriovNetworkNodePolicyWithInvalidVendorDevice(t *testing.
 	g.Expect(ok).To(Equal(false))
 }
 
-func TestStaticValidateSriovNetworkNodePolicyWithConflictIsRdmaAndDeviceType(t *testing.T) {
-	policy := &SriovNetworkNodePolicy{
-		Spec: SriovNetworkNodePolicySpec{
-			DeviceType: constants.DeviceTypeVfioPci,
-			NicSelector: SriovNetworkNicSelector{
-				Vendor:   "8086",
-				DeviceID: "158b",
-			},
-			NodeSelector: map[string]string{
-				"feature.node.kubernetes.io/network-sriov.capable": "true",
-			},
-			NumVfs:       1,
-			Priority:     99,
-			ResourceName: "p0",
-			IsRdma:       true,
-		},
-	}
-	g := NewGomegaWithT(t)
-	ok, err := staticValidateSriovNetworkNodePolicy(policy)
-	g.Expect(err).To(MatchError(ContainSubstring("'deviceType: vfio-pci' conflicts with 'isRdma: true'")))
-	g.Expect(ok).To(Equal(false))
-}
-
-func TestStaticValidateSriovNetworkNodePolicyWithConflictDeviceTypeAndVirtioVdpaType(t *testing.T) {
-	policy := &SriovNetworkNodePolicy{
-		Spec: SriovNetworkNodePolicySpec{
-			DeviceType: constants.DeviceTypeVfioPci,
-			NicSelector: SriovNetworkNicSelector{
-				Vendor:   "15b3",
-				DeviceID: "101d",
-			},
-			NodeSelector: map[string]string{
-				"feature.node.kubernetes.io/network-sriov.capable": "true",
-			},
-			NumVfs:       1,
-			Priority:     99,
-			ResourceName: "p0",
-			VdpaType:     constants.VdpaTypeVirtio,
-			EswitchMode:  "switchdev",
-		},
-	}
-	g := NewGomegaWithT(t)
-	ok, err := staticValidateSriovNetworkNodePolicy(policy)
-	g.Expect(err).To(MatchError(ContainSubstring("'deviceType: vfio-pci' conflicts with 'virtio'")))
-	g.Expect(ok).To(Equal(false))
-}
-
-func TestStaticValidateSriovNetworkNodePolicyWithConflictDeviceTypeAndVhostVdpaType(t *testing.T) {
-	policy := &SriovNetworkNodePolicy{
-		Spec: SriovNetworkNodePolicySpec{
-			DeviceType: constants.DeviceTypeVfioPci,
-			NicSelector: SriovNetworkNicSelector{
-				Vendor:   "15b3",
-				DeviceID: "101d",
-			},
-			NodeSelector: map[string]string{
-				"feature.node.kubernetes.io/network-sriov.capable": "true",
-			},
-			NumVfs:       1,
-			Priority:     99,
-			ResourceName: "p0",
-			VdpaType:     constants.VdpaTypeVhost,
-			EswitchMode:  "switchdev",
-		},
-	}
-	g := NewGomegaWithT(t)
-	ok, err := staticValidateSriovNetworkNodePolicy(policy)
-	g.Expect(err).To(MatchError(ContainSubstring("'deviceType: vfio-pci' conflicts with 'vhost'")))
-	g.Expect(ok).To(Equal(false))
-}
-
-func TestStaticValidateSriovNetworkNodePolicyVirtioVdpaMustSpecifySwitchDev(t *testing.T) {
-	policy := &SriovNetworkNodePolicy{
-		Spec: SriovNetworkNodePolicySpec{
-			DeviceType: "netdevice",
-			NicSelector: SriovNetworkNicSelector{
-				Vendor:   "15b3",
-				DeviceID: "101d",
-			},
-			NodeSelector: map[string]string{
-				"feature.node.kubernetes.io/network-sriov.capable": "true",
-			},
-			NumVfs:       1,
-			Priority:     99,
-			ResourceName: "p0",
-			VdpaType:     constants.VdpaTypeVirtio,
-		},
-	}
-	g := NewGomegaWithT(t)
-	ok, err := staticValidateSriovNetworkNodePolicy(policy)
-	g.Expect(err).To(MatchError(ContainSubstring("vdpa requires the device to be configured in switchdev mode")))
-	g.Expect(ok).To(Equal(false))
-}
-
-func TestStaticValidateSriovNetworkNodePolicyVhostVdpaMustSpecifySwitchDev(t *testing.T) {
-	policy := &SriovNetworkNodePolicy{
-		Spec: SriovNetworkNodePolicySpec{
-			DeviceType: "netdevice",
-			NicSelector: SriovNetworkNicSelector{
-				Vendor:   "15b3",
-				DeviceID: "101d",
-			},
-			NodeSelector: map[string]string{
-				"feature.node.kubernetes.io/network-sriov.capable": "true",
-			},
-			NumVfs:       1,
-			Priority:     99,
-			ResourceName: "p0",
-			VdpaType:     constants.VdpaTypeVhost,
-		},
-	}
-	g := NewGomegaWithT(t)
-	ok, err := staticValidateSriovNetworkNodePolicy(policy)
-	g.Expect(err).To(MatchError(ContainSubstring("vdpa requires the device to be configured in switchdev mode")))
-	g.Expect(ok).To(Equal(false))
-}
-
 func TestValidatePolicyForNodeStateVirtioVdpaWithNotSupportedVendor(t *testing.T) {
 	state := newNodeState()
 	policy := &SriovNetworkNodePolicy{
@@ -1086,85 +996,35 @@ func TestValidatePolicyForNodeStateWithValidPfName(t *testing.T) {
 	g.Expect(interfaceSelected).To(Equal(true))
 }
 
-func TestStaticValidateSriovNetworkNodePolicyWithInvalidNicSelector(t *testing.T) {
-	policy := &SriovNetworkNodePolicy{
-		Spec: SriovNetworkNodePolicySpec{
-			DeviceType:  "netdevice",
-			NicSelector: SriovNetworkNicSelector{},
-			NodeSelector: map[string]string{
-				"feature.node.kubernetes.io/network-sriov.capable": "true",
-			},
-			ResourceName: "p0",
-		},
-	}
-	g := NewGomegaWithT(t)
-	ok, err := staticValidateSriovNetworkNodePolicy(policy)
-	g.Expect(err).To(HaveOccurred())
-	g.Expect(ok).To(Equal(false))
-}
-
-func TestStaticValidateSriovNetworkNodePolicyWithInvalidLinkTypeForSwitchdev(t *testing.T) {
+func TestValidatePolicyForNodeStateWithTooLongVfName(t *testing.T) {
+	state := newNodeState()
 	policy := &SriovNetworkNodePolicy{
-		Spec: SriovNetworkNodePolicySpec{
-			DeviceType:  "netdevice",
-			LinkType:    "ib",
-			IsRdma:      true,
-			EswitchMode: "switchdev",
-			NicSelector: SriovNetworkNicSelector{
-				PfNames: []string{"ens803f1"},
-			},
-			NodeSelector: map[string]string{
-				"feature.node.kubernetes.io/network-sriov.capable": "true",
-			},
-			ResourceName: "p0",
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "p1",
 		},
-	}
-	g := NewGomegaWithT(t)
-	ok, err := staticValidateSriovNetworkNodePolicy(policy)
-	g.Expect(err).To(HaveOccurred())
-	g.Expect(ok).To(Equal(false))
-}
-
-func TestStaticValidateSriovNetworkNodePolicyWithBridgeConfigWithoutSwitchdev(t *testing.T) {
-	policy := &SriovNetworkNodePolicy{
 		Spec: SriovNetworkNodePolicySpec{
 			DeviceType: "netdevice",
-			Bridge:     Bridge{OVS: &OVSConfig{}},
 			NicSelector: SriovNetworkNicSelector{
-				PfNames: []string{"ens803f1"},
+				PfNames:     []string{"ens803f0"},
+				RootDevices: []string{"0000:86:00.0"},
+				Vendor:      "8086",
 			},
 			NodeSelector: map[string]string{
 				"feature.node.kubernetes.io/network-sriov.capable": "true",
 			},
-			ResourceName: "p0",
+			NumVfs:         63,
+			Priority:       99,
+			ResourceName:   "p0",
+			VfNameTemplate: "{pfName}_sriov_vf{vfID}",
 		},
 	}
 	g := NewGomegaWithT(t)
-	ok, err := staticValidateSriovNetworkNodePolicy(policy)
-	g.Expect(err).To(HaveOccurred())
-	g.Expect(ok).To(Equal(false))
-}
+	_, err := validatePolicyForNodeState(policy, state, NewNode())
+	g.Expect(err).To(MatchError("VF name ens803f0_sriov_vf62 generated from the vfNameTemplate in CR p1 exceeds the maximum length(15) interface(ens803f0)"))
 
-func TestStaticValidateSriovNetworkNodePolicyWithBridgeConfigWithExternallyManaged(t *testing.T) {
-	policy := &SriovNetworkNodePolicy{
-		Spec: SriovNetworkNodePolicySpec{
-			DeviceType:        "netdevice",
-			Bridge:            Bridge{OVS: &OVSConfig{}},
-			EswitchMode:       "switchdev",
-			ExternallyManaged: true,
-			NicSelector: SriovNetworkNicSelector{
-				PfNames: []string{"ens803f1"},
-			},
-			NodeSelector: map[string]string{
-				"feature.node.kubernetes.io/network-sriov.capable": "true",
-			},
-			ResourceName: "p0",
-		},
-	}
-	g := NewGomegaWithT(t)
-	ok, err := staticValidateSriovNetworkNodePolicy(policy)
-	g.Expect(err).To(HaveOccurred())
-	g.Expect(ok).To(Equal(false))
+	policy.Spec.VfNameTemplate = "{pfName}v{vfID}"
+	_, err = validatePolicyForNodeState(policy, state, NewNode())
+	g.Expect(err).NotTo(HaveOccurred())
 }
 
 func TestValidatePolicyForNodeStateWithValidNetFilter(t *testing.T) {
@@ -1254,6 +1114,34 @@ func TestValidatePolicyForNodeStateWithValidVFAndNetFilter(t *testing.T) {
 	g.Expect(interfaceSelected).To(Equal(true))
 }
 
+func TestValidateNicModelWithGenericVirtualProvider(t *testing.T) {
+	iface := &InterfaceExt{
+		DeviceID:   "154c",
+		Driver:     "iavf",
+		Mtu:        1500,
+		Name:       "ens5",
+		PciAddress: "0000:07:00.0",
+		Vendor:     "8086",
+		NumVfs:     1,
+		TotalVfs:   1,
+		NetFilter:  "generic/Network:storage",
+	}
+	selector := &SriovNetworkNicSelector{
+		PfNames:   []string{"ens5"},
+		NetFilter: "generic/Network:storage",
+	}
+	node := &corev1.Node{Spec: corev1.NodeSpec{ProviderID: "kvm://vm-1"}}
+	g := NewGomegaWithT(t)
+
+	// the generic virtual platform is opt-in
+	err := validateNicModel(selector, iface, node)
+	g.Expect(err).To(MatchError(ContainSubstring("vendor and device ID is not in supported list")))
+
+	defer func(providers []string) { vars.GenericVirtualProviders = providers }(vars.GenericVirtualProviders)
+	vars.GenericVirtualProviders = []string{"libvirt", "kvm"}
+	g.Expect(validateNicModel(selector, iface, node)).To(Succeed())
+}
+
 func TestValidatePolicyForNodeStateWithExternallyManageAndSwitchdev(t *testing.T) {
 	state := newNodeState()
 	policy := &SriovNetworkNodePolicy{
@@ -1330,3 +1218,618 @@ func TestValidatePolicyForNodePolicyAllowSwitchdevWithExternallyManage(t *testin
 	err := validatePolicyForNodePolicy(policy, appliedPolicy)
 	g.Expect(err).NotTo(HaveOccurred())
 }
+
+func TestValidateSriovNetworkWithValidConfig(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	vars.Namespace = "openshift-sriov-network-operator"
+	policy := &SriovNetworkNodePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "p1", Namespace: vars.Namespace},
+		Spec:       SriovNetworkNodePolicySpec{ResourceName: "resource1"},
+	}
+	client = newFakeClientBuilder().WithObjects(policy).Build()
+	network := &SriovNetwork{
+		ObjectMeta: metav1.ObjectMeta{Name: "net1", Namespace: vars.Namespace},
+		Spec: SriovNetworkSpec{
+			ResourceName:      "resource1",
+			IPAM:              `{"type": "host-local", "subnet": "10.56.217.0/24"}`,
+			Capabilities:      `{"mac": true, "ips": true}`,
+			MetaPluginsConfig: `{"type": "tuning", "sysctl": {"net.ipv4.conf.IFNAME.accept_redirects": "1"}}, {"type": "vrf", "vrfname": "red"}`,
+			MinTxRate:         ptr.To(100),
+			MaxTxRate:         ptr.To(1000),
+		},
+	}
+
+	ok, w, err := validateSriovNetwork(network, "CREATE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+	g.Expect(w).To(BeEmpty())
+}
+
+func TestValidateSriovNetworkWithInvalidConfig(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	vars.Namespace = "openshift-sriov-network-operator"
+	client = newFakeClientBuilder().Build()
+	testCases := []struct {
+		name   string
+		mutate func(*SriovNetwork)
+	}{
+		{"broken ipam", func(n *SriovNetwork) { n.Spec.IPAM = `{"type": "host-local"` }},
+		{"ipam not an object", func(n *SriovNetwork) { n.Spec.IPAM = `["host-local"]` }},
+		{"broken capabilities", func(n *SriovNetwork) { n.Spec.Capabilities = `{"mac": "yes"}` }},
+		{"broken metaPlugins", func(n *SriovNetwork) { n.Spec.MetaPluginsConfig = `{"type": "tuning"},` }},
+		{"metaPlugin without type", func(n *SriovNetwork) { n.Spec.MetaPluginsConfig = `{"vrfname": "red"}` }},
+		{"minTxRate greater than maxTxRate", func(n *SriovNetwork) {
+			n.Spec.MinTxRate = ptr.To(1000)
+			n.Spec.MaxTxRate = ptr.To(100)
+		}},
+	}
+	for _, tc := range testCases {
+		network := &SriovNetwork{
+			ObjectMeta: metav1.ObjectMeta{Name: "net1", Namespace: vars.Namespace},
+			Spec:       SriovNetworkSpec{ResourceName: "resource1"},
+		}
+		tc.mutate(network)
+		ok, _, err := validateSriovNetwork(network, "CREATE")
+		g.Expect(err).To(HaveOccurred(), tc.name)
+		g.Expect(ok).To(BeFalse(), tc.name)
+
+		// deletion is allowed when no pod is attached to the network
+		ok, _, err = validateSriovNetwork(network, "DELETE")
+		g.Expect(err).NotTo(HaveOccurred(), tc.name)
+		g.Expect(ok).To(BeTrue(), tc.name)
+	}
+}
+
+func TestValidateCustomResourceSkipsNetworkMetadataUpdates(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	vars.Namespace = "openshift-sriov-network-operator"
+	client = newFakeClientBuilder().Build()
+	// network stored before the validation of the CNI configuration
+	stored := &SriovNetwork{
+		TypeMeta: metav1.TypeMeta{Kind: "SriovNetwork", APIVersion: "sriovnetwork.openshift.io/v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: "net1", Namespace: vars.Namespace,
+			Finalizers: []string{NETATTDEFFINALIZERNAME}},
+		Spec: SriovNetworkSpec{ResourceName: "resource1", IPAM: `{"type": "host-local"`,
+			MinTxRate: ptr.To(1000), MaxTxRate: ptr.To(100)},
+	}
+	review := func(oldObj, obj *SriovNetwork) *admissionv1.AdmissionResponse {
+		oldRaw, err := json.Marshal(oldObj)
+		g.Expect(err).NotTo(HaveOccurred())
+		raw, err := json.Marshal(obj)
+		g.Expect(err).NotTo(HaveOccurred())
+		return ValidateCustomResource(admissionv1.AdmissionReview{Request: &admissionv1.AdmissionRequest{
+			Kind:      metav1.GroupVersionKind{Kind: "SriovNetwork"},
+			Operation: admissionv1.Update,
+			OldObject: runtime.RawExtension{Raw: oldRaw},
+			Object:    runtime.RawExtension{Raw: raw},
+		}})
+	}
+
+	// the finalizer is removed from the network being deleted
+	deleting := stored.DeepCopy()
+	deleting.DeletionTimestamp = ptr.To(metav1.Now())
+	updated := deleting.DeepCopy()
+	updated.Finalizers = nil
+	response := review(deleting, updated)
+	g.Expect(response.Allowed).To(BeTrue())
+	g.Expect(response.Warnings).To(BeEmpty())
+
+	// the metadata is updated without changing the spec
+	updated = stored.DeepCopy()
+	updated.Annotations = map[string]string{"test": "test"}
+	response = review(stored, updated)
+	g.Expect(response.Allowed).To(BeTrue())
+	g.Expect(response.Warnings).To(BeEmpty())
+
+	// the invalid spec is still rejected when it's changed
+	updated = stored.DeepCopy()
+	updated.Spec.ResourceName = "resource2"
+	response = review(stored, updated)
+	g.Expect(response.Allowed).To(BeFalse())
+}
+
+func TestValidateSriovNetworkWithMaxTxRateNotLimited(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	vars.Namespace = "openshift-sriov-network-operator"
+	client = newFakeClientBuilder().Build()
+	network := &SriovNetwork{
+		ObjectMeta: metav1.ObjectMeta{Name: "net1", Namespace: vars.Namespace},
+		Spec: SriovNetworkSpec{
+			ResourceName: "resource1",
+			MinTxRate:    ptr.To(100),
+			MaxTxRate:    ptr.To(0),
+		},
+	}
+
+	ok, _, err := validateSriovNetwork(network, "CREATE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+}
+
+func TestValidateNetworksWithoutPolicyForResource(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	vars.Namespace = "openshift-sriov-network-operator"
+	policy := &SriovNetworkNodePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "p1", Namespace: vars.Namespace},
+		Spec:       SriovNetworkNodePolicySpec{ResourceName: "other"},
+	}
+	client = newFakeClientBuilder().WithObjects(policy).Build()
+
+	ok, w, err := validateSriovNetwork(&SriovNetwork{Spec: SriovNetworkSpec{ResourceName: "resource1"}}, "CREATE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+	g.Expect(w).To(ConsistOf(ContainSubstring("resource1")))
+
+	ok, w, err = validateSriovIBNetwork(&SriovIBNetwork{Spec: SriovIBNetworkSpec{ResourceName: "resource1"}}, "CREATE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+	g.Expect(w).To(ConsistOf(ContainSubstring("resource1")))
+
+	ok, w, err = validateOVSNetwork(&OVSNetwork{Spec: OVSNetworkSpec{ResourceName: "resource1", IPAM: "{"}}, "CREATE")
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(ok).To(BeFalse())
+	g.Expect(w).To(BeEmpty())
+}
+
+// newFakeClientBuilder returns a fake client builder with the spec.nodeName field index used to list the pods of a node
+func newFakeClientBuilder() *fake.ClientBuilder {
+	return fake.NewClientBuilder().WithScheme(vars.Scheme).
+		WithIndex(&corev1.Pod{}, utils.PodNodeNameIndex, utils.PodIndexers()[utils.PodNodeNameIndex])
+}
+
+func newPodWithResource(name, nodeName, resourceName string) *corev1.Pod {
+	resources := corev1.ResourceList{corev1.ResourceName(vars.ResourcePrefix + "/" + resourceName): resource.MustParse("1")}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: corev1.PodSpec{
+			NodeName:   nodeName,
+			Containers: []corev1.Container{{Name: "test", Resources: corev1.ResourceRequirements{Requests: resources, Limits: resources}}},
+		},
+	}
+}
+
+func TestPolicyImpactWarningsWithNumVfsAndEswitchModeChange(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	vars.ResourcePrefix = "openshift.io"
+	client = newFakeClientBuilder().WithObjects(
+		newPodWithResource("pod1", "worker-0", "p1"),
+		newPodWithResource("pod2", "worker-1", "p1"),
+		newPodWithResource("pod3", "worker-0", "other"),
+	).Build()
+	state := newNodeState()
+	state.Name = "worker-0"
+	node := NewNode()
+	node.Name = "worker-0"
+	node.Labels = map[string]string{"feature.node.kubernetes.io/network-sriov.capable": "true"}
+	policy := newNodePolicy()
+	policy.Spec.EswitchMode = "switchdev"
+
+	_, merged, err := validatePolicyMerge(policy, &SriovNetworkNodePolicyList{}, state, node)
+	g.Expect(err).ToNot(HaveOccurred())
+	impact := &policyImpact{drainNodes: map[string]bool{}, rebootNodes: map[string]bool{}}
+	impact.addNodeState(policy, state, merged)
+	g.Expect(impact.drainNodes).To(HaveKey("worker-0"))
+	g.Expect(impact.rebootNodes).To(HaveKey("worker-0"))
+
+	usage, err := getResourceUsage(impact.drainNodes, impact.rebootNodes)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(usage.Pods("p1", nil)).To(Equal(1), "only the pods of the affected nodes are counted")
+	warnings := impact.warnings(policy, nil, usage)
+	g.Expect(warnings).To(ConsistOf(
+		"p1 changes the number of VFs on 1 node(s), the nodes will be drained and 1 pod(s) using the p1 resource will be evicted",
+		"p1 changes the eswitch mode on 1 node(s), the nodes will be rebooted and 1 pod(s) using the p1 resource will be evicted",
+	))
+}
+
+func TestPolicyImpactWarningsWithoutChange(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	client = newFakeClientBuilder().Build()
+	state := newNodeState()
+	state.Name = "worker-0"
+	node := NewNode()
+	node.Name = "worker-0"
+	node.Labels = map[string]string{"feature.node.kubernetes.io/network-sriov.capable": "true"}
+	policy := newNodePolicy()
+	policy.Spec.NumVfs = 4
+
+	_, merged, err := validatePolicyMerge(policy, &SriovNetworkNodePolicyList{}, state, node)
+	g.Expect(err).ToNot(HaveOccurred())
+	impact := &policyImpact{drainNodes: map[string]bool{}, rebootNodes: map[string]bool{}}
+	impact.addNodeState(policy, state, merged)
+	g.Expect(impact.warnings(policy, nil, utils.ResourceUsage{})).To(BeEmpty())
+}
+
+func TestPolicyImpactWithMergedNumVfs(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	node, p1, p2 := newMergeTestPolicies()
+	node.Name = "worker-0"
+	state := newNodeState()
+	state.Name = "worker-0"
+	state.Status.Interfaces[1].NumVfs = 8
+	p1.Spec.NumVfs = 8
+	p2.Spec.NumVfs = 6
+	npList := &SriovNetworkNodePolicyList{Items: []SriovNetworkNodePolicy{*p1}}
+
+	// the PF keeps the 8 VFs of p1, p2 doesn't change the number of VFs
+	_, merged, err := validatePolicyMerge(p2, npList, state, node)
+	g.Expect(err).ToNot(HaveOccurred())
+	impact := &policyImpact{drainNodes: map[string]bool{}, rebootNodes: map[string]bool{}}
+	impact.addNodeState(p2, state, merged)
+	g.Expect(impact.drainNodes).To(BeEmpty())
+	g.Expect(impact.rebootNodes).To(BeEmpty())
+
+	p2.Spec.NumVfs = 12
+	_, merged, err = validatePolicyMerge(p2, npList, state, node)
+	g.Expect(err).ToNot(HaveOccurred())
+	impact.addNodeState(p2, state, merged)
+	g.Expect(impact.drainNodes).To(HaveKey("worker-0"))
+}
+
+func TestValidateResourceInUse(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	vars.ResourcePrefix = "openshift.io"
+	vars.Namespace = "openshift-sriov-network-operator"
+	namespace = vars.Namespace
+	node := NewNode()
+	node.Name = "worker-0"
+	node.Labels = map[string]string{"feature.node.kubernetes.io/network-sriov.capable": "true"}
+	previous := newNodePolicy()
+	previous.Namespace = namespace
+	current := previous.DeepCopy()
+	current.Spec.ResourceName = "p2"
+	client = newFakeClientBuilder().WithObjects(
+		node,
+		previous,
+		newPodWithResource("pod1", "worker-0", "p1"),
+	).Build()
+
+	err := validateResourceInUse(previous, current)
+	g.Expect(err).To(MatchError(ContainSubstring("resource p1 is used by 1 running pod(s)")))
+	// deleted policy
+	g.Expect(validateResourceInUse(previous, nil)).To(MatchError(ContainSubstring("resource p1 is used by 1 running pod(s)")))
+	// resource name not changed
+	g.Expect(validateResourceInUse(previous, previous)).To(Succeed())
+	// the node is not selected anymore
+	unselected := previous.DeepCopy()
+	unselected.Spec.NodeSelector = map[string]string{"other": "true"}
+	g.Expect(validateResourceInUse(previous, unselected)).To(MatchError(ContainSubstring("resource p1 is used by 1 running pod(s)")))
+
+	// forced deletion
+	forced := previous.DeepCopy()
+	forced.Annotations = map[string]string{constants.ForceDeleteAnnotation: "true"}
+	g.Expect(validateResourceInUse(forced, nil)).To(Succeed())
+	forced.Spec.ResourceName = "p2"
+	g.Expect(validateResourceInUse(previous, forced)).To(Succeed())
+
+	// the resource is still provided by another policy
+	other := newNodePolicy()
+	other.Name = "p-other"
+	other.Namespace = namespace
+	g.Expect(client.Create(context.Background(), other)).To(Succeed())
+	g.Expect(validateResourceInUse(previous, current)).To(Succeed())
+	g.Expect(validateResourceInUse(previous, nil)).To(Succeed())
+}
+
+func TestValidateResourceInUseWithoutPods(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	vars.ResourcePrefix = "openshift.io"
+	vars.Namespace = "openshift-sriov-network-operator"
+	namespace = vars.Namespace
+	previous := newNodePolicy()
+	previous.Namespace = namespace
+	client = newFakeClientBuilder().WithObjects(
+		previous,
+		newPodWithResource("pod1", "worker-0", "other"),
+	).Build()
+
+	ok, _, err := validateSriovNetworkNodePolicy(previous, "DELETE")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+}
+
+func TestValidateNetworkInUse(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	vars.Namespace = "openshift-sriov-network-operator"
+	network := &SriovNetwork{
+		ObjectMeta: metav1.ObjectMeta{Name: "net1", Namespace: vars.Namespace},
+		Spec:       SriovNetworkSpec{ResourceName: "p1", NetworkNamespace: "app"},
+	}
+	scheduled := corev1.PodSpec{NodeName: "worker-0"}
+	attached := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "app",
+		Annotations: map[string]string{constants.PodNetworksAnnotation: "net1@net1, other"}}, Spec: scheduled}
+	attachedJSON := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod2", Namespace: "app",
+		Annotations: map[string]string{constants.PodNetworksAnnotation: `[{"name":"net1","namespace":"app"}]`}}, Spec: scheduled}
+	completed := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod3", Namespace: "app",
+		Annotations: map[string]string{constants.PodNetworksAnnotation: "app/net1"}}, Spec: scheduled,
+		Status: corev1.PodStatus{Phase: corev1.PodSucceeded}}
+	pending := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod4", Namespace: "app",
+		Annotations: map[string]string{constants.PodNetworksAnnotation: "net1"}},
+		Status: corev1.PodStatus{Phase: corev1.PodPending}}
+	otherNetwork := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod5", Namespace: "other",
+		Annotations: map[string]string{constants.PodNetworksAnnotation: "net1"}}, Spec: scheduled}
+	crossNamespace := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod6", Namespace: "other",
+		Annotations: map[string]string{constants.PodNetworksAnnotation: "app/net1"}}, Spec: scheduled}
+	client = newFakeClientBuilder().
+		WithObjects(attached, attachedJSON, completed, pending, otherNetwork, crossNamespace).Build()
+
+	ok, _, err := validateSriovNetwork(network, "DELETE")
+	g.Expect(err).To(MatchError(ContainSubstring("network net1 in namespace app is used by 3 running pod(s) [app/pod1 app/pod2 other/pod6]")))
+	g.Expect(ok).To(BeFalse())
+
+	network.Annotations = map[string]string{constants.ForceDeleteAnnotation: "true"}
+	ok, _, err = validateSriovNetwork(network, "DELETE")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+
+	g.Expect(client.Delete(context.Background(), attached)).To(Succeed())
+	g.Expect(client.Delete(context.Background(), attachedJSON)).To(Succeed())
+	g.Expect(client.Delete(context.Background(), crossNamespace)).To(Succeed())
+	network.Annotations = nil
+	ok, _, err = validateSriovNetwork(network, "DELETE")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+}
+
+func newMergeTestPolicies() (*corev1.Node, *SriovNetworkNodePolicy, *SriovNetworkNodePolicy) {
+	node := NewNode()
+	node.Name = "worker-0"
+	node.Labels = map[string]string{"feature.node.kubernetes.io/network-sriov.capable": "true"}
+	p1 := newNodePolicy()
+	p2 := newNodePolicy()
+	p2.Name = "p2"
+	p2.Spec.ResourceName = "p2"
+	p2.Spec.NicSelector = SriovNetworkNicSelector{PfNames: []string{"ens803f1#3-5"}}
+	return node, p1, p2
+}
+
+func TestValidatePolicyMergeWithOverlappingVfRange(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	node, p1, p2 := newMergeTestPolicies()
+	// p2 selects all the VFs of the PFs by vendor and overlaps the VF range of p1
+	p2.Spec.NicSelector = SriovNetworkNicSelector{Vendor: "8086"}
+	p2.Spec.NumVfs = 8
+	p2.Spec.DeviceType = "vfio-pci"
+	npList := &SriovNetworkNodePolicyList{Items: []SriovNetworkNodePolicy{*p1}}
+
+	_, _, err := validatePolicyMerge(p2, npList, newNodeState(), node)
+	g.Expect(err).To(MatchError("VF range 0-2 of policy p1 (resource p1, deviceType netdevice) on PF ens803f1 is overridden by " +
+		"VF range 0-7 of policy p2 (resource p2, deviceType vfio-pci) on node worker-0, the policies have the same priority 99"))
+
+	p2.Spec.Priority = 10
+	warnings, _, err := validatePolicyMerge(p2, npList, newNodeState(), node)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(warnings).To(ConsistOf("VF range 0-2 of policy p1 (resource p1, deviceType netdevice) on PF ens803f1 is overridden by " +
+		"VF range 0-7 of policy p2 (resource p2, deviceType vfio-pci) with a higher priority"))
+}
+
+func TestValidatePolicyMergeWithDifferentPfSettings(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	node, p1, p2 := newMergeTestPolicies()
+	p1.Spec.Mtu = 1500
+	p2.Spec.Mtu = 9000
+	npList := &SriovNetworkNodePolicyList{Items: []SriovNetworkNodePolicy{*p1}}
+
+	warnings, _, err := validatePolicyMerge(p2, npList, newNodeState(), node)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(warnings).To(ConsistOf("policies p1 and p2 set different MTUs on PF ens803f1 for VF ranges 0-2 and 3-5, the PF MTU is set to 9000"))
+
+	p2.Spec.Mtu = 1500
+	p2.Spec.EswitchMode = "switchdev"
+	_, _, err = validatePolicyMerge(p2, npList, newNodeState(), node)
+	g.Expect(err).To(MatchError("policies p1 and p2 set different eswitchMode on PF ens803f1 for VF ranges 0-2 and 3-5 (legacy and switchdev) on node worker-0"))
+
+	p1.Spec.LinkType = "eth"
+	npList.Items = []SriovNetworkNodePolicy{*p1}
+	p2.Spec.EswitchMode = ""
+	p2.Spec.LinkType = "ib"
+	_, _, err = validatePolicyMerge(p2, npList, newNodeState(), node)
+	g.Expect(err).To(MatchError(ContainSubstring("set different linkType on PF ens803f1")))
+
+	p1.Spec.LinkType = ""
+	p1.Spec.VfNameTemplate = "{pfName}v{vfID}"
+	npList.Items = []SriovNetworkNodePolicy{*p1}
+	p2.Spec.LinkType = ""
+	_, _, err = validatePolicyMerge(p2, npList, newNodeState(), node)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	p2.Spec.VfNameTemplate = "{pfName}_{vfID}"
+	_, _, err = validatePolicyMerge(p2, npList, newNodeState(), node)
+	g.Expect(err).To(MatchError("policies p1 and p2 set different vfNameTemplate on PF ens803f1 for VF ranges 0-2 and 3-5 ({pfName}v{vfID} and {pfName}_{vfID}) on node worker-0"))
+}
+
+func TestValidatePolicyMergeIgnoresOtherPoliciesConflicts(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	node, p1, p2 := newMergeTestPolicies()
+	p2.Spec.NicSelector = SriovNetworkNicSelector{Vendor: "8086"}
+	p2.Spec.NumVfs = 8
+	p3 := newNodePolicy()
+	p3.Name = "p3"
+	p3.Spec.ResourceName = "p3"
+	p3.Spec.NicSelector = SriovNetworkNicSelector{PfNames: []string{"ens803f0"}}
+	p3.Spec.Priority = 10
+	npList := &SriovNetworkNodePolicyList{Items: []SriovNetworkNodePolicy{*p1, *p2}}
+
+	// p3 overrides only the VFs of p2 on ens803f0
+	warnings, _, err := validatePolicyMerge(p3, npList, newNodeState(), node)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(warnings).To(ConsistOf(ContainSubstring("of policy p2 (resource p2, deviceType netdevice) on PF ens803f0 is overridden by")))
+}
+
+func TestValidatePolicyMergeWithDifferentSharedBridgeSettings(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	node, p1, p2 := newMergeTestPolicies()
+	p2.Spec.NicSelector = SriovNetworkNicSelector{PfNames: []string{"ens803f0"}}
+	p1.Spec.Bridge = Bridge{OVS: &OVSConfig{BridgeName: "br-shared",
+		PortBond: &OVSPortBondConfig{Name: "bond-shared", Mode: "balance-tcp"}}}
+	p2.Spec.Bridge = *p1.Spec.Bridge.DeepCopy()
+	npList := &SriovNetworkNodePolicyList{Items: []SriovNetworkNodePolicy{*p1}}
+
+	_, _, err := validatePolicyMerge(p2, npList, newNodeState(), node)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	p2.Spec.Bridge.OVS.PortBond.Mode = "active-backup"
+	_, _, err = validatePolicyMerge(p2, npList, newNodeState(), node)
+	g.Expect(err).To(MatchError("policies p1 and p2 set different bridge.ovs.portBond settings for the shared bridge br-shared on node worker-0"))
+
+	p2.Spec.Bridge = *p1.Spec.Bridge.DeepCopy()
+	p2.Spec.Bridge.OVS.Bridge.DatapathType = "netdev"
+	_, _, err = validatePolicyMerge(p2, npList, newNodeState(), node)
+	g.Expect(err).To(MatchError(ContainSubstring("set different bridge.ovs.bridge settings for the shared bridge br-shared")))
+
+	p2.Spec.Bridge = Bridge{Linux: &LinuxBridgeConfig{BridgeName: "br-shared"}}
+	_, _, err = validatePolicyMerge(p2, npList, newNodeState(), node)
+	g.Expect(err).To(MatchError(ContainSubstring("set different bridge types for the shared bridge br-shared")))
+
+	p1.Spec.Bridge = Bridge{Linux: &LinuxBridgeConfig{BridgeName: "br-shared", Bridge: LinuxBridgeOptions{VlanFiltering: true}}}
+	npList.Items = []SriovNetworkNodePolicy{*p1}
+	_, _, err = validatePolicyMerge(p2, npList, newNodeState(), node)
+	g.Expect(err).To(MatchError(ContainSubstring("set different bridge.linux.bridge settings for the shared bridge br-shared")))
+
+	// the policy doesn't select any PF of the node
+	p2.Spec.NicSelector = SriovNetworkNicSelector{PfNames: []string{"ens1"}}
+	_, _, err = validatePolicyMerge(p2, npList, newNodeState(), node)
+	g.Expect(err).ToNot(HaveOccurred())
+}
+
+// newPolicyCELValidator compiles the CEL validation rules of the SriovNetworkNodePolicy CRD
+func newPolicyCELValidator(g *WithT) (*structuralschema.Structural, *cel.Validator) {
+	data, err := os.ReadFile("../../config/crd/bases/sriovnetwork.openshift.io_sriovnetworknodepolicies.yaml")
+	g.Expect(err).ToNot(HaveOccurred())
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	g.Expect(yaml.Unmarshal(data, crd)).To(Succeed())
+	g.Expect(crd.Spec.Versions).To(HaveLen(1))
+
+	props := &apiextensions.JSONSchemaProps{}
+	g.Expect(apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(
+		crd.Spec.Versions[0].Schema.OpenAPIV3Schema, props, nil)).To(Succeed())
+	s, err := structuralschema.NewStructural(props)
+	g.Expect(err).ToNot(HaveOccurred())
+	validator := cel.NewValidator(s, true, celconfig.PerCallLimit)
+	g.Expect(validator).ToNot(BeNil())
+	return s, validator
+}
+
+func TestSriovNetworkNodePolicyCELValidationRules(t *testing.T) {
+	g := NewGomegaWithT(t)
+	s, validator := newPolicyCELValidator(g)
+
+	newPolicySpec := func(mutate func(*SriovNetworkNodePolicySpec)) SriovNetworkNodePolicySpec {
+		spec := SriovNetworkNodePolicySpec{
+			ResourceName: "p0",
+			NodeSelector: map[string]string{"feature.node.kubernetes.io/network-sriov.capable": "true"},
+			NumVfs:       8,
+			DeviceType:   constants.DeviceTypeNetDevice,
+			NicSelector:  SriovNetworkNicSelector{PfNames: []string{"ens803f1#0-3"}},
+		}
+		mutate(&spec)
+		return spec
+	}
+	testCases := []struct {
+		name        string
+		spec        SriovNetworkNodePolicySpec
+		expectedErr string
+	}{
+		{"valid policy", newPolicySpec(func(s *SriovNetworkNodePolicySpec) {}), ""},
+		{"empty nicSelector", newPolicySpec(func(s *SriovNetworkNodePolicySpec) {
+			s.NicSelector = SriovNetworkNicSelector{}
+		}), "at least one of these parameters (vendor, deviceID, pfNames, rootDevices or netFilter) has to be defined in nicSelector"},
+		{"VF range end smaller than start", newPolicySpec(func(s *SriovNetworkNodePolicySpec) {
+			s.NicSelector.PfNames = []string{"ens803f1#3-0"}
+		}), "the end of the VF index range in 'nicSelector.pfNames' shall not be smaller than the start"},
+		{"VF range end exceeding numVfs", newPolicySpec(func(s *SriovNetworkNodePolicySpec) {
+			s.NicSelector.PfNames = []string{"ens803f1#0-8"}
+		}), "the end of the VF index range in 'nicSelector.pfNames' exceeds the maximum VF index"},
+		{"isRdma with vfio-pci", newPolicySpec(func(s *SriovNetworkNodePolicySpec) {
+			s.DeviceType = constants.DeviceTypeVfioPci
+			s.IsRdma = true
+		}), "'isRdma: true' can be used only with 'deviceType: netdevice'"},
+		{"isRdma with uio_pci_generic", newPolicySpec(func(s *SriovNetworkNodePolicySpec) {
+			s.DeviceType = constants.DeviceTypeUioPciGeneric
+			s.IsRdma = true
+		}), "'isRdma: true' can be used only with 'deviceType: netdevice'"},
+		{"switchdev with infiniband link", newPolicySpec(func(s *SriovNetworkNodePolicySpec) {
+			s.LinkType = "ib"
+			s.EswitchMode = ESwithModeSwitchDev
+		}), "'eSwitchMode: switchdev' can be used only with ethernet links"},
+		{"vdpa with vfio-pci", newPolicySpec(func(s *SriovNetworkNodePolicySpec) {
+			s.DeviceType = constants.DeviceTypeVfioPci
+			s.VdpaType = constants.VdpaTypeVirtio
+			s.EswitchMode = ESwithModeSwitchDev
+		}), "'vdpaType' can be used only with 'deviceType: netdevice'"},
+		{"vdpa without switchdev", newPolicySpec(func(s *SriovNetworkNodePolicySpec) {
+			s.VdpaType = constants.VdpaTypeVhost
+		}), "vdpa requires the device to be configured in switchdev mode"},
+		{"bridge without switchdev", newPolicySpec(func(s *SriovNetworkNodePolicySpec) {
+			s.Bridge = Bridge{OVS: &OVSConfig{}}
+		}), "software bridge management requires the device to be configured in switchdev mode"},
+		{"bridge with externally managed device", newPolicySpec(func(s *SriovNetworkNodePolicySpec) {
+			s.Bridge = Bridge{OVS: &OVSConfig{}}
+			s.EswitchMode = ESwithModeSwitchDev
+			s.ExternallyManaged = true
+		}), "software bridge management can't be used when the device externally managed"},
+		{"OVS port bond without bridge name", newPolicySpec(func(s *SriovNetworkNodePolicySpec) {
+			s.Bridge = Bridge{OVS: &OVSConfig{PortBond: &OVSPortBondConfig{Name: "bond-shared"}}}
+			s.EswitchMode = ESwithModeSwitchDev
+		}), "'bridge.ovs.portBond' can be used only with 'bridge.ovs.bridgeName'"},
+		{"OVS bond with bridge name", newPolicySpec(func(s *SriovNetworkNodePolicySpec) {
+			s.Bridge = Bridge{OVS: &OVSConfig{BridgeName: "br-shared", Bond: &OVSBondConfig{Name: "bond0"}}}
+			s.EswitchMode = ESwithModeSwitchDev
+		}), "'bridge.ovs.bond' can't be used with 'bridge.ovs.bridgeName'"},
+		{"OVS and Linux bridges together", newPolicySpec(func(s *SriovNetworkNodePolicySpec) {
+			s.Bridge = Bridge{OVS: &OVSConfig{}, Linux: &LinuxBridgeConfig{}}
+			s.EswitchMode = ESwithModeSwitchDev
+		}), "'bridge.ovs' and 'bridge.linux' can't be used together"},
+		{"Linux bridge uplink VLANs without VLAN filtering", newPolicySpec(func(s *SriovNetworkNodePolicySpec) {
+			s.Bridge = Bridge{Linux: &LinuxBridgeConfig{Uplink: LinuxUplinkConfig{Vlans: []int{100}}}}
+			s.EswitchMode = ESwithModeSwitchDev
+		}), "'bridge.linux.uplink.vlans' requires 'bridge.linux.bridge.vlanFiltering'"},
+		{"valid VF name template", newPolicySpec(func(s *SriovNetworkNodePolicySpec) {
+			s.VfNameTemplate = "{pfName}v{vfID}"
+		}), ""},
+		{"VF name template without VF index", newPolicySpec(func(s *SriovNetworkNodePolicySpec) {
+			s.VfNameTemplate = "{pfName}v"
+		}), "'vfNameTemplate' must contain the {vfID} placeholder"},
+		{"VF name template with unknown placeholder", newPolicySpec(func(s *SriovNetworkNodePolicySpec) {
+			s.VfNameTemplate = "{pfName}v{vfID}{vfId}"
+		}), "'vfNameTemplate' contains an unknown placeholder"},
+		{"VF name template with switchdev", newPolicySpec(func(s *SriovNetworkNodePolicySpec) {
+			s.VfNameTemplate = "{pfName}v{vfID}"
+			s.EswitchMode = ESwithModeSwitchDev
+		}), "'vfNameTemplate' can be used only with 'eSwitchMode: legacy'"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			policy := &SriovNetworkNodePolicy{
+				TypeMeta:   metav1.TypeMeta{APIVersion: GroupVersion.String(), Kind: "SriovNetworkNodePolicy"},
+				ObjectMeta: metav1.ObjectMeta{Name: "cel-policy", Namespace: "default"},
+				Spec:       tc.spec,
+			}
+			obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(policy)
+			g.Expect(err).ToNot(HaveOccurred())
+			structuraldefaulting.Default(obj, s)
+
+			errs, _ := validator.Validate(context.Background(), field.NewPath("root"), s, obj, nil, celconfig.RuntimeCELCostBudget)
+			if tc.expectedErr == "" {
+				g.Expect(errs.ToAggregate()).ToNot(HaveOccurred())
+				return
+			}
+			g.Expect(errs.ToAggregate()).To(MatchError(ContainSubstring(tc.expectedErr)))
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 
 	v1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -63,6 +64,10 @@ func ValidateCustomResource(ar v1.AdmissionReview) *v1.AdmissionResponse {
 			return toV1AdmissionResponse(err)
 		}
 
+		if skipUpdateValidation(ar, &policy) {
+			break
+		}
+
 		if reviewResponse.Allowed, reviewResponse.Warnings, err = validateSriovNetworkNodePolicy(&policy, ar.Request.Operation); err != nil {
 			reviewResponse.Result = &metav1.Status{
 				Reason: metav1.StatusReason(err.Error()),
@@ -97,11 +102,98 @@ func ValidateCustomResource(ar v1.AdmissionReview) *v1.AdmissionResponse {
 				Reason: metav1.StatusReason(err.Error()),
 			}
 		}
+
+	case "SriovNetwork":
+		network := sriovnetworkv1.SriovNetwork{}
+
+		err = json.Unmarshal(raw, &network)
+		if err != nil {
+			log.Log.Error(err, "failed to unmarshal object")
+			return toV1AdmissionResponse(err)
+		}
+
+		if skipUpdateValidation(ar, &network) {
+			break
+		}
+
+		if reviewResponse.Allowed, reviewResponse.Warnings, err = validateSriovNetwork(&network, ar.Request.Operation); err != nil {
+			reviewResponse.Result = &metav1.Status{
+				Reason: metav1.StatusReason(err.Error()),
+			}
+		}
+
+	case "SriovIBNetwork":
+		network := sriovnetworkv1.SriovIBNetwork{}
+
+		err = json.Unmarshal(raw, &network)
+		if err != nil {
+			log.Log.Error(err, "failed to unmarshal object")
+			return toV1AdmissionResponse(err)
+		}
+
+		if skipUpdateValidation(ar, &network) {
+			break
+		}
+
+		if reviewResponse.Allowed, reviewResponse.Warnings, err = validateSriovIBNetwork(&network, ar.Request.Operation); err != nil {
+			reviewResponse.Result = &metav1.Status{
+				Reason: metav1.StatusReason(err.Error()),
+			}
+		}
+
+	case "OVSNetwork":
+		network := sriovnetworkv1.OVSNetwork{}
+
+		err = json.Unmarshal(raw, &network)
+		if err != nil {
+			log.Log.Error(err, "failed to unmarshal object")
+			return toV1AdmissionResponse(err)
+		}
+
+		if skipUpdateValidation(ar, &network) {
+			break
+		}
+
+		if reviewResponse.Allowed, reviewResponse.Warnings, err = validateOVSNetwork(&network, ar.Request.Operation); err != nil {
+			reviewResponse.Result = &metav1.Status{
+				Reason: metav1.StatusReason(err.Error()),
+			}
+		}
 	}
 
 	return &reviewResponse
 }
 
+// skipUpdateValidation returns true if only the metadata of the object is changed by the update, e.g. the finalizer
+// or the annotations, or if the object is being deleted. The objects stored before a validation was added can then
+// still be updated by the operator and deleted.
+func skipUpdateValidation(ar v1.AdmissionReview, obj metav1.Object) bool {
+	if ar.Request.Operation != v1.Update {
+		return false
+	}
+	if obj.GetDeletionTimestamp() != nil {
+		return true
+	}
+	return !specChanged(ar.Request.OldObject.Raw, ar.Request.Object.Raw)
+}
+
+// specChanged returns true if the spec of the object is different from the one of the previous object
+func specChanged(oldRaw, raw []byte) bool {
+	type object struct {
+		Spec map[string]interface{} `json:"spec,omitempty"`
+	}
+	oldObj, obj := object{}, object{}
+	if err := json.Unmarshal(oldRaw, &oldObj); err != nil {
+		log.Log.Error(err, "failed to unmarshal the previous object")
+		return true
+	}
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		log.Log.Error(err, "failed to unmarshal object")
+		return true
+	}
+	return !equality.Semantic.DeepEqual(oldObj.Spec, obj.Spec)
+}
+
 func toV1AdmissionResponse(err error) *v1.AdmissionResponse {
 	return &v1.AdmissionResponse{
 		Result: &metav1.Status{